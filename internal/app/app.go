@@ -6,21 +6,30 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/alexgolang/ishare-task/internal/app/auth"
 	"github.com/alexgolang/ishare-task/internal/app/config"
 	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/notify"
+	"github.com/alexgolang/ishare-task/internal/app/scheduler"
 	"github.com/alexgolang/ishare-task/internal/app/service"
+	"github.com/alexgolang/ishare-task/internal/app/storage"
 	"github.com/alexgolang/ishare-task/internal/app/transport/httpserver"
 	"github.com/alexgolang/ishare-task/internal/app/transport/httpserver/handlers"
+	"github.com/alexgolang/ishare-task/internal/app/transport/httpserver/middleware"
 )
 
 type App struct {
 	server *httpserver.Server
 	db     *sqlite.Database
 	logger *log.Logger
+
+	scheduler *scheduler.Scheduler
 }
 
 func NewApp() (*App, error) {
@@ -47,17 +56,142 @@ func NewApp() (*App, error) {
 		return nil, fmt.Errorf("failed to create auth service: %w", err)
 	}
 
-	taskService := service.NewTaskService(logger, db)
+	attachmentMaxSize, err := strconv.ParseInt(cfg.AttachmentMaxSize, 10, 64)
+	if err != nil || attachmentMaxSize <= 0 {
+		return nil, fmt.Errorf("failed to parse attachment max size %q", cfg.AttachmentMaxSize)
+	}
+
+	recurrenceInterval, err := time.ParseDuration(cfg.RecurrenceInterval)
+	if err != nil || recurrenceInterval <= 0 {
+		return nil, fmt.Errorf("failed to parse recurrence interval %q", cfg.RecurrenceInterval)
+	}
+
+	reminderInterval, err := time.ParseDuration(cfg.ReminderInterval)
+	if err != nil || reminderInterval <= 0 {
+		return nil, fmt.Errorf("failed to parse reminder interval %q", cfg.ReminderInterval)
+	}
+
+	trashRetention, err := time.ParseDuration(cfg.TrashRetention)
+	if err != nil || trashRetention <= 0 {
+		return nil, fmt.Errorf("failed to parse trash retention %q", cfg.TrashRetention)
+	}
+
+	purgeInterval, err := time.ParseDuration(cfg.PurgeInterval)
+	if err != nil || purgeInterval <= 0 {
+		return nil, fmt.Errorf("failed to parse purge interval %q", cfg.PurgeInterval)
+	}
+
+	requireIfMatch, err := strconv.ParseBool(cfg.RequireIfMatch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse require if-match %q", cfg.RequireIfMatch)
+	}
+
+	idempotencyTTL, err := time.ParseDuration(cfg.IdempotencyTTL)
+	if err != nil || idempotencyTTL <= 0 {
+		return nil, fmt.Errorf("failed to parse idempotency ttl %q", cfg.IdempotencyTTL)
+	}
+
+	idempotencyMaxBodySize, err := strconv.ParseInt(cfg.IdempotencyMaxBodySize, 10, 64)
+	if err != nil || idempotencyMaxBodySize <= 0 {
+		return nil, fmt.Errorf("failed to parse idempotency max body size %q", cfg.IdempotencyMaxBodySize)
+	}
+
+	bulkMaxOperations, err := strconv.Atoi(cfg.BulkMaxOperations)
+	if err != nil || bulkMaxOperations <= 0 {
+		return nil, fmt.Errorf("failed to parse bulk max operations %q", cfg.BulkMaxOperations)
+	}
+
+	importMaxRows, err := strconv.Atoi(cfg.ImportMaxRows)
+	if err != nil || importMaxRows <= 0 {
+		return nil, fmt.Errorf("failed to parse import max rows %q", cfg.ImportMaxRows)
+	}
+
+	var adminClients []string
+	if cfg.AdminClients != "" {
+		adminClients = strings.Split(cfg.AdminClients, ",")
+	}
+
+	notifier, err := newNotifier(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	blobStore, err := storage.NewLocalBlobStore(cfg.AttachmentsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create blob store: %w", err)
+	}
 
-	taskHandler := handlers.NewTaskHandler(taskService)
-	authHandler := handlers.NewAuthHandler(authService)
+	workflow := domain.DefaultWorkflow()
+	if cfg.WorkflowFile != "" {
+		data, err := os.ReadFile(cfg.WorkflowFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read workflow file: %w", err)
+		}
+
+		workflow, err = domain.ParseWorkflow(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse workflow file: %w", err)
+		}
+	}
 
-	server := httpserver.NewServer(taskHandler, authHandler, authService, cfg.Port)
+	taskService := service.NewTaskService(logger, db, blobStore, workflow)
+	commentService := service.NewCommentService(logger, db)
+	projectService := service.NewProjectService(logger, db)
+	reminderService := service.NewReminderService(logger, db, notifier)
+	idempotencyService := service.NewIdempotencyService(logger, db, idempotencyTTL)
+	bulkService := service.NewBulkService(logger, db, taskService, bulkMaxOperations)
+	importService := service.NewImportService(logger, db, taskService, importMaxRows)
+	calendarService := service.NewCalendarService(logger, db, taskService)
+	worklogService := service.NewWorklogService(logger, db)
+	viewService := service.NewViewService(logger, db, workflow)
+	templateService := service.NewTemplateService(logger, db, taskService)
+	attachmentService := service.NewAttachmentService(logger, db, blobStore, attachmentMaxSize, strings.Split(cfg.AttachmentAllowedTypes, ","))
+
+	taskHandler := handlers.NewTaskHandler(logger, taskService, requireIfMatch)
+	commentHandler := handlers.NewCommentHandler(logger, commentService)
+	attachmentHandler := handlers.NewAttachmentHandler(logger, attachmentService)
+	projectHandler := handlers.NewProjectHandler(logger, projectService, taskService)
+	reminderHandler := handlers.NewReminderHandler(logger, reminderService)
+	bulkHandler := handlers.NewBulkHandler(logger, bulkService)
+	transferHandler := handlers.NewTransferHandler(logger, taskService, importService)
+	calendarHandler := handlers.NewCalendarHandler(logger, calendarService, cfg.PublicURL)
+	worklogHandler := handlers.NewWorklogHandler(logger, worklogService)
+	viewHandler := handlers.NewViewHandler(logger, viewService)
+	templateHandler := handlers.NewTemplateHandler(logger, templateService)
+	authHandler := handlers.NewAuthHandler(logger, authService)
+	idempotency := middleware.NewIdempotencyMiddleware(idempotencyService, logger, idempotencyMaxBodySize)
+
+	server := httpserver.NewServer(taskHandler, commentHandler, attachmentHandler, projectHandler, reminderHandler, worklogHandler, bulkHandler, transferHandler, calendarHandler, viewHandler, templateHandler, authHandler, authService, adminClients, idempotency, cfg.Port)
+
+	jobs := scheduler.New(logger)
+	jobs.Every("recurrence", recurrenceInterval, func(ctx context.Context) error {
+		spawned, err := taskService.SpawnDueOccurrences(ctx, time.Now().UTC())
+		if spawned > 0 {
+			logger.Printf("Spawned %d recurring task occurrence(s)", spawned)
+		}
+		return err
+	})
+	jobs.Every("reminders", reminderInterval, func(ctx context.Context) error {
+		_, err := reminderService.FireDueReminders(ctx, time.Now().UTC())
+		return err
+	})
+	jobs.Every("purge", purgeInterval, func(ctx context.Context) error {
+		purged, err := taskService.PurgeDeletedTasks(ctx, time.Now().UTC().Add(-trashRetention))
+		if purged > 0 {
+			logger.Printf("Purged %d task(s) from the trash", purged)
+		}
+		return err
+	})
+	jobs.Every("idempotency", purgeInterval, func(ctx context.Context) error {
+		_, err := idempotencyService.PurgeExpired(ctx, time.Now().UTC())
+		return err
+	})
 
 	return &App{
-		server: server,
-		db:     db,
-		logger: logger,
+		server:    server,
+		db:        db,
+		logger:    logger,
+		scheduler: jobs,
 	}, nil
 }
 
@@ -65,6 +199,8 @@ func (a *App) Run() error {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
+	a.scheduler.Start()
+
 	serverErrChan := make(chan error, 1)
 	go func() {
 		a.logger.Printf("Starting server on port %s", a.server.GetPort())
@@ -80,6 +216,11 @@ func (a *App) Run() error {
 		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer shutdownCancel()
 
+		// Background jobs and in-flight requests share the shutdown window.
+		if err := a.scheduler.Stop(shutdownCtx); err != nil {
+			a.logger.Printf("Scheduler shutdown error: %v", err)
+		}
+
 		if err := a.server.Shutdown(shutdownCtx); err != nil {
 			a.logger.Printf("Server shutdown error: %v", err)
 		}
@@ -93,6 +234,31 @@ func (a *App) Run() error {
 
 	case err := <-serverErrChan:
 		a.logger.Printf("Server error: %v", err)
+
+		stopCtx, stopCancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer stopCancel()
+		_ = a.scheduler.Stop(stopCtx)
+
 		return err
 	}
 }
+
+func newNotifier(cfg *config.Config, logger *log.Logger) (notify.Notifier, error) {
+	switch cfg.Notifier {
+	case "log":
+		return notify.NewLogNotifier(logger), nil
+	case "webhook":
+		if cfg.NotifierWebhookURL == "" {
+			return nil, fmt.Errorf("NOTIFIER_WEBHOOK_URL is required for the webhook notifier")
+		}
+		return notify.NewWebhookNotifier(cfg.NotifierWebhookURL), nil
+	case "smtp":
+		var to []string
+		if cfg.NotifierSMTPTo != "" {
+			to = strings.Split(cfg.NotifierSMTPTo, ",")
+		}
+		return notify.NewSMTPNotifier(cfg.NotifierSMTPAddr, cfg.NotifierSMTPFrom, to), nil
+	default:
+		return nil, fmt.Errorf("unknown notifier %q", cfg.Notifier)
+	}
+}
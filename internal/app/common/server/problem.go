@@ -0,0 +1,129 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/filter"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem details.
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details object. Every error response of the
+// API is a Problem.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// RequestID identifies the request in the server logs.
+	RequestID string `json:"request_id,omitempty"`
+	// InvalidParams lists the invalid fields of a request that failed validation.
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+	// Extensions are additional members specific to the problem.
+	Extensions map[string]any `json:"-"`
+}
+
+// @Description Invalid field of a request
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+func (p Problem) MarshalJSON() ([]byte, error) {
+	type problem Problem
+
+	data, err := json.Marshal(problem(p))
+	if err != nil || len(p.Extensions) == 0 {
+		return data, err
+	}
+
+	members := map[string]any{}
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+
+	for name, value := range p.Extensions {
+		if _, ok := members[name]; !ok {
+			members[name] = value
+		}
+	}
+
+	return json.Marshal(members)
+}
+
+// NewProblem describes a failed request with the given status code.
+func NewProblem(status int, detail string, r *http.Request) Problem {
+	return Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		Instance:  r.URL.Path,
+		RequestID: chiMiddleware.GetReqID(r.Context()),
+	}
+}
+
+// RespondProblem writes a problem details response.
+func RespondProblem(problem Problem, w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+// ProblemFromError maps an error to problem details by its domain error kind.
+// Errors of no known kind are internal errors; their message is written to
+// logger under the request ID instead of being sent to the client.
+func ProblemFromError(logger *log.Logger, err error, r *http.Request) Problem {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, domain.ErrVersionMismatch):
+		status = http.StatusPreconditionFailed
+	case errors.Is(err, domain.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrValidation):
+		status = http.StatusBadRequest
+	case errors.Is(err, domain.ErrConflict):
+		status = http.StatusConflict
+	case errors.Is(err, domain.ErrForbidden):
+		status = http.StatusForbidden
+	}
+
+	if status == http.StatusInternalServerError {
+		problem := NewProblem(status, "", r)
+		logger.Printf("request %s: %s %s: %v", problem.RequestID, r.Method, RedactPath(r.URL.Path), err)
+		return problem
+	}
+
+	problem := NewProblem(status, err.Error(), r)
+
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		for _, field := range validationErr.Fields {
+			problem.InvalidParams = append(problem.InvalidParams, InvalidParam{Name: field.Field, Reason: field.Message})
+		}
+	}
+
+	var filterErr *filter.Error
+	if errors.As(err, &filterErr) {
+		problem.InvalidParams = append(problem.InvalidParams, InvalidParam{Name: "filter", Reason: filterErr.Error()})
+		problem.Extensions = map[string]any{"position": filterErr.Pos}
+	}
+
+	var transitionErr *domain.TransitionError
+	if errors.As(err, &transitionErr) {
+		problem.Extensions = map[string]any{
+			"from":    transitionErr.From,
+			"to":      transitionErr.To,
+			"allowed": transitionErr.Allowed,
+		}
+	}
+
+	return problem
+}
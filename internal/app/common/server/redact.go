@@ -0,0 +1,17 @@
+package server
+
+import "strings"
+
+const (
+	calendarFeedPrefix = "/calendar/"
+	calendarFeedSuffix = ".ics"
+)
+
+// RedactPath hides secrets carried in a request path, such as the token of a
+// calendar feed URL, so the path can be logged.
+func RedactPath(path string) string {
+	if strings.HasPrefix(path, calendarFeedPrefix) && strings.HasSuffix(path, calendarFeedSuffix) {
+		return calendarFeedPrefix + "REDACTED" + calendarFeedSuffix
+	}
+	return path
+}
@@ -2,49 +2,80 @@ package server
 
 import (
 	"encoding/json"
+	"log"
 	"net/http"
 )
 
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message,omitempty"`
-}
-
 func RespondOK(data any, w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(data)
 }
 
-func RespondError(err error, w http.ResponseWriter, r *http.Request) {
+// RespondCreated answers a request that created the resource at location.
+// A nil data writes no body.
+func RespondCreated(location string, data any, w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Location", location)
+	if data == nil {
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusInternalServerError)
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(data)
+}
 
-	errorResponse := ErrorResponse{
-		Error: err.Error(),
-	}
+func RespondNoContent(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
 
-	_ = json.NewEncoder(w).Encode(errorResponse)
+// RespondError is the single place where errors are turned into responses:
+// the status code follows from the error's domain kind (see ProblemFromError).
+func RespondError(logger *log.Logger, err error, w http.ResponseWriter, r *http.Request) {
+	RespondProblem(ProblemFromError(logger, err, r), w, r)
 }
 
 func RespondNotFound(message string, w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusNotFound)
-	
-	errorResponse := ErrorResponse{
-		Error: message,
-	}
-	
-	_ = json.NewEncoder(w).Encode(errorResponse)
+	RespondProblem(NewProblem(http.StatusNotFound, message, r), w, r)
 }
 
 func RespondBadRequest(message string, w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusBadRequest)
-	
-	errorResponse := ErrorResponse{
-		Error: message,
-	}
-	
-	_ = json.NewEncoder(w).Encode(errorResponse)
-}
\ No newline at end of file
+	RespondProblem(NewProblem(http.StatusBadRequest, message, r), w, r)
+}
+
+func RespondUnauthorized(message string, w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("WWW-Authenticate", "Bearer")
+	RespondProblem(NewProblem(http.StatusUnauthorized, message, r), w, r)
+}
+
+func RespondForbidden(message string, w http.ResponseWriter, r *http.Request) {
+	RespondProblem(NewProblem(http.StatusForbidden, message, r), w, r)
+}
+
+func RespondConflict(message string, w http.ResponseWriter, r *http.Request) {
+	RespondProblem(NewProblem(http.StatusConflict, message, r), w, r)
+}
+
+func RespondNotModified(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotModified)
+}
+
+func RespondPreconditionFailed(message string, w http.ResponseWriter, r *http.Request) {
+	RespondProblem(NewProblem(http.StatusPreconditionFailed, message, r), w, r)
+}
+
+func RespondPreconditionRequired(message string, w http.ResponseWriter, r *http.Request) {
+	RespondProblem(NewProblem(http.StatusPreconditionRequired, message, r), w, r)
+}
+
+func RespondUnprocessableEntity(message string, w http.ResponseWriter, r *http.Request) {
+	RespondProblem(NewProblem(http.StatusUnprocessableEntity, message, r), w, r)
+}
+
+func RespondRequestEntityTooLarge(message string, w http.ResponseWriter, r *http.Request) {
+	RespondProblem(NewProblem(http.StatusRequestEntityTooLarge, message, r), w, r)
+}
+
+func RespondUnsupportedMediaType(message string, w http.ResponseWriter, r *http.Request) {
+	RespondProblem(NewProblem(http.StatusUnsupportedMediaType, message, r), w, r)
+}
@@ -11,6 +11,34 @@ var (
 	defaultJWTPrivateKey  = "123"
 	defaultJWTIssuer      = "123"
 	defaultJWTTokenExpiry = "3600s"
+
+	defaultAttachmentsDir         = "attachments"
+	defaultAttachmentMaxSize      = "10485760"
+	defaultAttachmentAllowedTypes = "image/png,image/jpeg,image/gif,image/webp,text/plain,application/pdf,application/zip"
+
+	defaultWorkflowFile = ""
+
+	defaultRecurrenceInterval = "1m"
+
+	defaultReminderInterval   = "30s"
+	defaultNotifier           = "log"
+	defaultNotifierSMTPAddr   = "localhost:25"
+	defaultNotifierSMTPFrom   = "tasks@localhost"
+	defaultNotifierSMTPTo     = ""
+	defaultNotifierWebhookURL = ""
+
+	defaultTrashRetention = "720h"
+	defaultPurgeInterval  = "1h"
+	defaultAdminClients   = ""
+
+	defaultRequireIfMatch         = "false"
+	defaultIdempotencyTTL         = "24h"
+	defaultIdempotencyMaxBodySize = "16777216"
+
+	defaultBulkMaxOperations = "500"
+	defaultImportMaxRows     = "10000"
+
+	defaultPublicURL = ""
 )
 
 type Config struct {
@@ -19,6 +47,34 @@ type Config struct {
 	JWTPrivateKey  string
 	JWTIssuer      string
 	JWTTokenExpiry string
+
+	AttachmentsDir         string
+	AttachmentMaxSize      string
+	AttachmentAllowedTypes string
+
+	WorkflowFile string
+
+	RecurrenceInterval string
+
+	ReminderInterval   string
+	Notifier           string
+	NotifierWebhookURL string
+	NotifierSMTPAddr   string
+	NotifierSMTPFrom   string
+	NotifierSMTPTo     string
+
+	TrashRetention string
+	PurgeInterval  string
+	AdminClients   string
+
+	RequireIfMatch         string
+	IdempotencyTTL         string
+	IdempotencyMaxBodySize string
+
+	BulkMaxOperations string
+	ImportMaxRows     string
+
+	PublicURL string
 }
 
 func Read() *Config {
@@ -28,6 +84,34 @@ func Read() *Config {
 		JWTPrivateKey:  getJWTPrivateKey(),
 		JWTIssuer:      getEnvOrDefault("JWT_ISSUER", defaultJWTIssuer),
 		JWTTokenExpiry: getEnvOrDefault("JWT_TOKEN_EXPIRY", defaultJWTTokenExpiry),
+
+		AttachmentsDir:         getEnvOrDefault("ATTACHMENTS_DIR", defaultAttachmentsDir),
+		AttachmentMaxSize:      getEnvOrDefault("ATTACHMENT_MAX_SIZE", defaultAttachmentMaxSize),
+		AttachmentAllowedTypes: getEnvOrDefault("ATTACHMENT_ALLOWED_TYPES", defaultAttachmentAllowedTypes),
+
+		WorkflowFile: getEnvOrDefault("WORKFLOW_FILE", defaultWorkflowFile),
+
+		RecurrenceInterval: getEnvOrDefault("RECURRENCE_INTERVAL", defaultRecurrenceInterval),
+
+		ReminderInterval:   getEnvOrDefault("REMINDER_INTERVAL", defaultReminderInterval),
+		Notifier:           getEnvOrDefault("NOTIFIER", defaultNotifier),
+		NotifierWebhookURL: getEnvOrDefault("NOTIFIER_WEBHOOK_URL", defaultNotifierWebhookURL),
+		NotifierSMTPAddr:   getEnvOrDefault("NOTIFIER_SMTP_ADDR", defaultNotifierSMTPAddr),
+		NotifierSMTPFrom:   getEnvOrDefault("NOTIFIER_SMTP_FROM", defaultNotifierSMTPFrom),
+		NotifierSMTPTo:     getEnvOrDefault("NOTIFIER_SMTP_TO", defaultNotifierSMTPTo),
+
+		TrashRetention: getEnvOrDefault("TRASH_RETENTION", defaultTrashRetention),
+		PurgeInterval:  getEnvOrDefault("PURGE_INTERVAL", defaultPurgeInterval),
+		AdminClients:   getEnvOrDefault("ADMIN_CLIENTS", defaultAdminClients),
+
+		RequireIfMatch:         getEnvOrDefault("REQUIRE_IF_MATCH", defaultRequireIfMatch),
+		IdempotencyTTL:         getEnvOrDefault("IDEMPOTENCY_TTL", defaultIdempotencyTTL),
+		IdempotencyMaxBodySize: getEnvOrDefault("IDEMPOTENCY_MAX_BODY_SIZE", defaultIdempotencyMaxBodySize),
+
+		BulkMaxOperations: getEnvOrDefault("BULK_MAX_OPERATIONS", defaultBulkMaxOperations),
+		ImportMaxRows:     getEnvOrDefault("IMPORT_MAX_ROWS", defaultImportMaxRows),
+
+		PublicURL: getEnvOrDefault("PUBLIC_URL", defaultPublicURL),
 	}
 
 	return cfg
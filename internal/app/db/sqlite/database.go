@@ -1,14 +1,17 @@
 package sqlite
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/alexgolang/ishare-task/internal/app/db/sqlite/sqlc"
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/mattn/go-sqlite3"
 	"github.com/pressly/goose/v3"
 )
 
@@ -18,7 +21,7 @@ type Database struct {
 }
 
 func NewDatabase(dbPath string) (*Database, error) {
-	db, err := sql.Open("sqlite3", dbPath)
+	db, err := sql.Open("sqlite3", withForeignKeys(dbPath))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
@@ -27,11 +30,25 @@ func NewDatabase(dbPath string) (*Database, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
+	// SQLite allows a single writer; sharing one connection also keeps
+	// ":memory:" databases consistent.
+	db.SetMaxOpenConns(1)
+
 	queries := sqlc.New(db)
 
 	return &Database{db: db, Queries: queries}, nil
 }
 
+// withForeignKeys adds the driver option that enforces foreign keys to a data
+// source name, so that every connection of the pool enforces them.
+func withForeignKeys(dbPath string) string {
+	separator := "?"
+	if strings.Contains(dbPath, "?") {
+		separator = "&"
+	}
+	return dbPath + separator + "_foreign_keys=on"
+}
+
 func (d *Database) Close() error {
 	return d.db.Close()
 }
@@ -51,3 +68,79 @@ func (d *Database) RunMigrations() error {
 	log.Println("Migrations completed successfully")
 	return nil
 }
+
+// WithTx runs fn inside a single transaction, committing on success and
+// rolling back if fn returns an error.
+func (d *Database) WithTx(ctx context.Context, fn func(q *sqlc.Queries) error) error {
+	return d.withTx(ctx, func(tx *sql.Tx) error {
+		return fn(d.Queries.WithTx(tx))
+	})
+}
+
+// Savepoint runs fn inside a savepoint of the enclosing transaction. When fn
+// fails, only its own changes are rolled back and the transaction remains
+// usable.
+type Savepoint func(fn func() error) error
+
+// WithSavepoints is like WithTx, and also passes fn a Savepoint for steps
+// that are allowed to fail without aborting the whole transaction.
+func (d *Database) WithSavepoints(ctx context.Context, fn func(q *sqlc.Queries, savepoint Savepoint) error) error {
+	return d.withTx(ctx, func(tx *sql.Tx) error {
+		n := 0
+		savepoint := func(step func() error) error {
+			n++
+			name := fmt.Sprintf("step_%d", n)
+
+			if _, err := tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+				return fmt.Errorf("failed to create savepoint: %w", err)
+			}
+
+			if err := step(); err != nil {
+				if _, rollbackErr := tx.ExecContext(ctx, "ROLLBACK TO "+name); rollbackErr != nil {
+					return fmt.Errorf("failed to roll back savepoint: %w", rollbackErr)
+				}
+				if _, releaseErr := tx.ExecContext(ctx, "RELEASE "+name); releaseErr != nil {
+					return fmt.Errorf("failed to release savepoint: %w", releaseErr)
+				}
+				return err
+			}
+
+			if _, err := tx.ExecContext(ctx, "RELEASE "+name); err != nil {
+				return fmt.Errorf("failed to release savepoint: %w", err)
+			}
+
+			return nil
+		}
+
+		return fn(d.Queries.WithTx(tx), savepoint)
+	})
+}
+
+func (d *Database) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// IsUniqueViolation reports whether err was caused by a UNIQUE or PRIMARY KEY
+// constraint.
+func IsUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+
+	return sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique || sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey
+}
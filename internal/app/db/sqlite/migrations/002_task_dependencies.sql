@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS task_dependencies (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    blocked_by_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, blocked_by_id),
+    CHECK (task_id <> blocked_by_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_dependencies_blocked_by_id ON task_dependencies(blocked_by_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_task_dependencies_blocked_by_id;
+DROP TABLE IF EXISTS task_dependencies;
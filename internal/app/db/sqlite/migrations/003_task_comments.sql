@@ -0,0 +1,28 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS task_comments (
+    id UUID PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    author TEXT NOT NULL,
+    body TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_comments_task_id_created_at ON task_comments(task_id, created_at);
+
+CREATE TABLE IF NOT EXISTS task_comment_revisions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    comment_id UUID NOT NULL REFERENCES task_comments(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    edited_by TEXT NOT NULL,
+    edited_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_comment_revisions_comment_id ON task_comment_revisions(comment_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_task_comment_revisions_comment_id;
+DROP TABLE IF EXISTS task_comment_revisions;
+DROP INDEX IF EXISTS idx_task_comments_task_id_created_at;
+DROP TABLE IF EXISTS task_comments;
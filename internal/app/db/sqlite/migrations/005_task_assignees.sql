@@ -0,0 +1,26 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS task_assignees (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    assignee TEXT NOT NULL,
+    assigned_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, assignee)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_assignees_assignee ON task_assignees(assignee);
+
+CREATE TABLE IF NOT EXISTS task_assignment_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    assignee TEXT NOT NULL,
+    action TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_assignment_events_task_id ON task_assignment_events(task_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_task_assignment_events_task_id;
+DROP TABLE IF EXISTS task_assignment_events;
+DROP INDEX IF EXISTS idx_task_assignees_assignee;
+DROP TABLE IF EXISTS task_assignees;
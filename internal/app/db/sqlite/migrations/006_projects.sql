@@ -0,0 +1,34 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS projects (
+    id UUID PRIMARY KEY,
+    key TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    description TEXT,
+    archived BOOLEAN NOT NULL DEFAULT FALSE,
+    last_task_seq INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Tasks created before projects existed belong to the default project.
+INSERT INTO projects (id, key, name, description)
+VALUES ('00000000-0000-0000-0000-000000000001', 'TASK', 'Default', 'Tasks created without a project');
+
+ALTER TABLE tasks ADD COLUMN project_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001';
+ALTER TABLE tasks ADD COLUMN seq INTEGER NOT NULL DEFAULT 0;
+
+UPDATE tasks SET seq = (
+    SELECT COUNT(*) FROM tasks t
+    WHERE t.created_at < tasks.created_at OR (t.created_at = tasks.created_at AND t.id <= tasks.id)
+);
+
+UPDATE projects SET last_task_seq = (SELECT COUNT(*) FROM tasks)
+WHERE id = '00000000-0000-0000-0000-000000000001';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tasks_project_seq ON tasks(project_id, seq);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tasks_project_seq;
+ALTER TABLE tasks DROP COLUMN seq;
+ALTER TABLE tasks DROP COLUMN project_id;
+DROP TABLE IF EXISTS projects;
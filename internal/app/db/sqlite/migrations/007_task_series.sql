@@ -0,0 +1,29 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS task_series (
+    id UUID PRIMARY KEY,
+    project_id UUID NOT NULL REFERENCES projects(id),
+    rrule TEXT NOT NULL,
+    dtstart DATETIME NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT,
+    priority TEXT NOT NULL,
+    occurrences INTEGER NOT NULL DEFAULT 0,
+    next_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_series_next_at ON task_series(next_at);
+
+ALTER TABLE tasks ADD COLUMN due_at DATETIME;
+ALTER TABLE tasks ADD COLUMN series_id UUID;
+
+-- Guards against spawning the same occurrence twice.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tasks_series_due ON tasks(series_id, due_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tasks_series_due;
+ALTER TABLE tasks DROP COLUMN series_id;
+ALTER TABLE tasks DROP COLUMN due_at;
+DROP INDEX IF EXISTS idx_task_series_next_at;
+DROP TABLE IF EXISTS task_series;
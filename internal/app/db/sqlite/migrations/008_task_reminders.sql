@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS task_reminders (
+    id UUID PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    -- Set for reminders relative to the due date; remind_at follows due_at.
+    offset_seconds INTEGER,
+    remind_at DATETIME NOT NULL,
+    created_by TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    -- Set when the reminder is claimed for delivery, so it is never sent twice.
+    fired_at DATETIME,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_reminders_task_id ON task_reminders(task_id);
+CREATE INDEX IF NOT EXISTS idx_task_reminders_pending ON task_reminders(remind_at) WHERE fired_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_task_reminders_pending;
+DROP INDEX IF EXISTS idx_task_reminders_task_id;
+DROP TABLE IF EXISTS task_reminders;
@@ -0,0 +1,47 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS task_events (
+    id UUID PRIMARY KEY,
+    -- No foreign key: the history of a task outlives the task itself.
+    task_id UUID NOT NULL,
+    action TEXT NOT NULL CHECK (action IN ('baseline', 'created', 'updated', 'deleted')),
+    actor TEXT NOT NULL,
+    request_id TEXT,
+    -- JSON array of {"Field", "Before", "After"} objects.
+    changes TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_events_task_id ON task_events(task_id, created_at);
+
+-- Changes made before this migration were never recorded, so every existing
+-- task starts its history with a baseline of its current state, taken at the
+-- time it was last updated.
+INSERT INTO task_events (id, task_id, action, actor, changes, created_at)
+SELECT
+    lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-'
+        || substr('89ab', 1 + (abs(random()) % 4), 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6))),
+    t.id,
+    'baseline',
+    'system:migration',
+    json_array(
+        json_object('Field', 'key', 'Before', NULL, 'After', p.key || '-' || t.seq),
+        json_object('Field', 'project_id', 'Before', NULL, 'After', t.project_id),
+        json_object('Field', 'title', 'Before', NULL, 'After', t.title),
+        json_object('Field', 'description', 'Before', NULL, 'After', COALESCE(t.description, '')),
+        json_object('Field', 'status', 'Before', NULL, 'After', t.status),
+        json_object('Field', 'priority', 'Before', NULL, 'After', t.priority),
+        json_object('Field', 'assignees', 'Before', NULL, 'After', json((
+            SELECT json_group_array(assignee) FROM (
+                SELECT assignee FROM task_assignees a WHERE a.task_id = t.id ORDER BY assigned_at, assignee
+            )
+        ))),
+        json_object('Field', 'due_at', 'Before', NULL, 'After', strftime('%Y-%m-%dT%H:%M:%SZ', t.due_at)),
+        json_object('Field', 'series_id', 'Before', NULL, 'After', t.series_id)
+    ),
+    t.updated_at
+FROM tasks t
+JOIN projects p ON p.id = t.project_id;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_task_events_task_id;
+DROP TABLE IF EXISTS task_events;
@@ -0,0 +1,51 @@
+-- +goose Up
+ALTER TABLE tasks ADD COLUMN deleted_at DATETIME;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_deleted_at ON tasks(deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Rebuild task_events to allow the restored and purged actions.
+CREATE TABLE task_events_new (
+    id UUID PRIMARY KEY,
+    -- No foreign key: the history of a task outlives the task itself.
+    task_id UUID NOT NULL,
+    action TEXT NOT NULL CHECK (action IN ('baseline', 'created', 'updated', 'deleted', 'restored', 'purged')),
+    actor TEXT NOT NULL,
+    request_id TEXT,
+    -- JSON array of {"Field", "Before", "After"} objects.
+    changes TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO task_events_new (id, task_id, action, actor, request_id, changes, created_at)
+SELECT id, task_id, action, actor, request_id, changes, created_at FROM task_events ORDER BY rowid;
+
+DROP INDEX IF EXISTS idx_task_events_task_id;
+DROP TABLE task_events;
+ALTER TABLE task_events_new RENAME TO task_events;
+
+CREATE INDEX IF NOT EXISTS idx_task_events_task_id ON task_events(task_id, created_at);
+
+-- +goose Down
+CREATE TABLE task_events_old (
+    id UUID PRIMARY KEY,
+    task_id UUID NOT NULL,
+    action TEXT NOT NULL CHECK (action IN ('baseline', 'created', 'updated', 'deleted')),
+    actor TEXT NOT NULL,
+    request_id TEXT,
+    changes TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO task_events_old (id, task_id, action, actor, request_id, changes, created_at)
+SELECT id, task_id, action, actor, request_id, changes, created_at FROM task_events
+WHERE action NOT IN ('restored', 'purged') ORDER BY rowid;
+
+DROP INDEX IF EXISTS idx_task_events_task_id;
+DROP TABLE task_events;
+ALTER TABLE task_events_old RENAME TO task_events;
+
+CREATE INDEX IF NOT EXISTS idx_task_events_task_id ON task_events(task_id, created_at);
+
+DELETE FROM tasks WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_tasks_deleted_at;
+ALTER TABLE tasks DROP COLUMN deleted_at;
@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    client_id TEXT NOT NULL,
+    key TEXT NOT NULL,
+    -- SHA-256 of the method, URL and body of the first request.
+    request_hash TEXT NOT NULL,
+    -- The response fields stay NULL while the first request is in progress.
+    status_code INTEGER,
+    headers TEXT,
+    body BLOB,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at DATETIME NOT NULL,
+    PRIMARY KEY (client_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_idempotency_keys_expires_at;
+DROP TABLE IF EXISTS idempotency_keys;
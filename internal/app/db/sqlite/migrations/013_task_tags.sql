@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS task_tags (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    tag TEXT NOT NULL,
+    PRIMARY KEY (task_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_tags_tag ON task_tags(tag);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_task_tags_tag;
+DROP TABLE IF EXISTS task_tags;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- external_id identifies a task in the system it was imported from.
+ALTER TABLE tasks ADD COLUMN external_id TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tasks_external_id ON tasks(external_id) WHERE external_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tasks_external_id;
+ALTER TABLE tasks DROP COLUMN external_id;
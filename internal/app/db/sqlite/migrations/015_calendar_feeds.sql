@@ -0,0 +1,10 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    client_id TEXT PRIMARY KEY,
+    -- SHA-256 of the feed token; the token itself is only shown once.
+    token_hash TEXT NOT NULL UNIQUE,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS calendar_feeds;
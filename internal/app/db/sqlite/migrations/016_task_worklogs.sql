@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS task_worklogs (
+    id UUID PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    author TEXT NOT NULL,
+    started_at DATETIME NOT NULL,
+    -- ended_at and duration_seconds stay NULL while a timer is running.
+    ended_at DATETIME,
+    duration_seconds INTEGER,
+    note TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_worklogs_task_id_started_at ON task_worklogs(task_id, started_at);
+CREATE INDEX IF NOT EXISTS idx_task_worklogs_started_at ON task_worklogs(started_at);
+
+-- A client runs at most one timer at a time.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_task_worklogs_running ON task_worklogs(author) WHERE ended_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_task_worklogs_running;
+DROP INDEX IF EXISTS idx_task_worklogs_started_at;
+DROP INDEX IF EXISTS idx_task_worklogs_task_id_started_at;
+DROP TABLE IF EXISTS task_worklogs;
@@ -0,0 +1,13 @@
+-- +goose Up
+-- estimate is the size of a task, in story points or hours; remaining is the
+-- part of it still to do. Both are NULL for unestimated tasks.
+ALTER TABLE tasks ADD COLUMN estimate REAL;
+ALTER TABLE tasks ADD COLUMN remaining REAL;
+
+-- Burndown reports replay the history of all tasks up to a point in time.
+CREATE INDEX IF NOT EXISTS idx_task_events_created_at ON task_events(created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_task_events_created_at;
+ALTER TABLE tasks DROP COLUMN remaining;
+ALTER TABLE tasks DROP COLUMN estimate;
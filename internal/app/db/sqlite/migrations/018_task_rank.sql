@@ -0,0 +1,23 @@
+-- +goose Up
+-- rank orders the tasks of a status (a board column). Ranks are fractional
+-- keys that compare as plain strings, so moving a task only rewrites its own.
+ALTER TABLE tasks ADD COLUMN rank TEXT NOT NULL DEFAULT '';
+
+-- Existing tasks are ranked in the order they were created, using four
+-- character keys ("c" and three base-62 digits) starting at c001.
+UPDATE tasks SET rank = (
+    SELECT 'c'
+        || substr('0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz', (r.n / 3844) % 62 + 1, 1)
+        || substr('0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz', (r.n / 62) % 62 + 1, 1)
+        || substr('0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz', r.n % 62 + 1, 1)
+    FROM (
+        SELECT id, row_number() OVER (PARTITION BY status ORDER BY created_at, seq) AS n FROM tasks
+    ) r
+    WHERE r.id = tasks.id
+);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_status_rank ON tasks(status, rank);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tasks_status_rank;
+ALTER TABLE tasks DROP COLUMN rank;
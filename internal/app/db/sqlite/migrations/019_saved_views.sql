@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS saved_views (
+    id UUID PRIMARY KEY,
+    owner TEXT NOT NULL,
+    name TEXT NOT NULL,
+    shared BOOLEAN NOT NULL DEFAULT FALSE,
+    -- JSON object with the Filter, Sort and Columns of the view.
+    definition TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL,
+    UNIQUE (owner, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_views_shared ON saved_views(shared) WHERE shared;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_saved_views_shared;
+DROP TABLE IF EXISTS saved_views;
@@ -0,0 +1,25 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS task_templates (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    description TEXT,
+    -- JSON array of the template's tasks, each with its subtasks.
+    definition TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+-- parent_id makes a task a subtask of another one; template_id records the
+-- template a task was created from.
+ALTER TABLE tasks ADD COLUMN parent_id UUID REFERENCES tasks(id) ON DELETE SET NULL;
+ALTER TABLE tasks ADD COLUMN template_id UUID REFERENCES task_templates(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_parent_id ON tasks(parent_id);
+CREATE INDEX IF NOT EXISTS idx_tasks_template_id ON tasks(template_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_tasks_template_id;
+DROP INDEX IF EXISTS idx_tasks_parent_id;
+ALTER TABLE tasks DROP COLUMN template_id;
+ALTER TABLE tasks DROP COLUMN parent_id;
+DROP TABLE IF EXISTS task_templates;
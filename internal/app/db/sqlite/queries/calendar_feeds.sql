@@ -0,0 +1,15 @@
+-- name: UpsertCalendarFeed :exec
+INSERT INTO calendar_feeds (client_id, token_hash, created_at)
+VALUES (?, ?, ?)
+ON CONFLICT (client_id) DO UPDATE SET
+    token_hash = excluded.token_hash,
+    created_at = excluded.created_at;
+
+-- name: GetCalendarFeed :one
+SELECT * FROM calendar_feeds WHERE client_id = ?;
+
+-- name: GetCalendarFeedByTokenHash :one
+SELECT * FROM calendar_feeds WHERE token_hash = ?;
+
+-- name: DeleteCalendarFeed :execrows
+DELETE FROM calendar_feeds WHERE client_id = ?;
@@ -0,0 +1,19 @@
+-- name: CreateIdempotencyKey :exec
+INSERT INTO idempotency_keys (client_id, key, request_hash, created_at, expires_at)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: GetIdempotencyKey :one
+SELECT * FROM idempotency_keys WHERE client_id = ? AND key = ?;
+
+-- name: CompleteIdempotencyKey :exec
+UPDATE idempotency_keys SET
+    status_code = sqlc.arg(status_code),
+    headers = sqlc.arg(headers),
+    body = sqlc.arg(body)
+WHERE client_id = sqlc.arg(client_id) AND key = sqlc.arg(key);
+
+-- name: DeleteIdempotencyKey :exec
+DELETE FROM idempotency_keys WHERE client_id = ? AND key = ?;
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys WHERE expires_at <= ?;
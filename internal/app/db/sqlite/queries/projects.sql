@@ -0,0 +1,28 @@
+-- name: CreateProject :exec
+INSERT INTO projects (id, key, name, description, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: GetProject :one
+SELECT * FROM projects WHERE id = ?;
+
+-- name: ListProjects :many
+SELECT * FROM projects ORDER BY key;
+
+-- name: UpdateProject :exec
+UPDATE projects SET
+    name = sqlc.arg(name),
+    description = sqlc.narg(description),
+    archived = sqlc.arg(archived),
+    updated_at = sqlc.arg(updated_at)
+WHERE id = sqlc.arg(id);
+
+-- name: DeleteProject :execrows
+DELETE FROM projects WHERE id = ?;
+
+-- name: CountProjectTasks :one
+SELECT COUNT(*) FROM tasks WHERE project_id = ?;
+
+-- name: NextProjectTaskSeq :one
+UPDATE projects SET last_task_seq = last_task_seq + 1
+WHERE id = ?
+RETURNING last_task_seq;
@@ -0,0 +1,22 @@
+-- name: CreateSavedView :exec
+INSERT INTO saved_views (id, owner, name, shared, definition, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetSavedView :one
+SELECT * FROM saved_views WHERE id = ?;
+
+-- name: ListSavedViews :many
+SELECT * FROM saved_views
+WHERE owner = sqlc.arg(client) OR shared
+ORDER BY name, id;
+
+-- name: UpdateSavedView :exec
+UPDATE saved_views SET
+    name = sqlc.arg(name),
+    shared = sqlc.arg(shared),
+    definition = sqlc.arg(definition),
+    updated_at = sqlc.arg(updated_at)
+WHERE id = sqlc.arg(id);
+
+-- name: DeleteSavedView :execrows
+DELETE FROM saved_views WHERE id = ?;
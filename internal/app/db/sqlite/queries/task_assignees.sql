@@ -0,0 +1,20 @@
+-- name: CreateTaskAssignee :exec
+INSERT INTO task_assignees (task_id, assignee, assigned_at)
+VALUES (?, ?, ?);
+
+-- name: DeleteTaskAssignee :execrows
+DELETE FROM task_assignees WHERE task_id = ? AND assignee = ?;
+
+-- name: ListTaskAssignees :many
+SELECT assignee FROM task_assignees
+WHERE task_id = ?
+ORDER BY assigned_at, assignee;
+
+-- name: CreateTaskAssignmentEvent :exec
+INSERT INTO task_assignment_events (task_id, assignee, action, actor, created_at)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: ListTaskAssignmentEvents :many
+SELECT * FROM task_assignment_events
+WHERE task_id = ?
+ORDER BY created_at, id;
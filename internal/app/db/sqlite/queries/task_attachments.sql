@@ -0,0 +1,19 @@
+-- name: CreateTaskAttachment :exec
+INSERT INTO task_attachments (id, task_id, filename, content_type, size_bytes, sha256, storage_key, uploaded_by, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetTaskAttachment :one
+SELECT * FROM task_attachments
+WHERE id = ? AND task_id = ?;
+
+-- name: ListTaskAttachments :many
+SELECT * FROM task_attachments
+WHERE task_id = ?
+ORDER BY created_at, id;
+
+-- name: ListTaskAttachmentStorageKeys :many
+SELECT storage_key FROM task_attachments
+WHERE task_id = ?;
+
+-- name: DeleteTaskAttachment :execrows
+DELETE FROM task_attachments WHERE id = ? AND task_id = ?;
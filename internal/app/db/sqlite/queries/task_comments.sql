@@ -0,0 +1,34 @@
+-- name: CreateTaskComment :exec
+INSERT INTO task_comments (id, task_id, author, body, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: GetTaskComment :one
+SELECT * FROM task_comments
+WHERE id = ? AND task_id = ? AND deleted_at IS NULL;
+
+-- name: ListTaskComments :many
+SELECT * FROM task_comments
+WHERE task_id = ? AND deleted_at IS NULL
+ORDER BY created_at, id
+LIMIT ? OFFSET ?;
+
+-- name: CountTaskComments :one
+SELECT COUNT(*) FROM task_comments
+WHERE task_id = ? AND deleted_at IS NULL;
+
+-- name: UpdateTaskCommentBody :execrows
+UPDATE task_comments SET body = ?, updated_at = ?
+WHERE id = ? AND deleted_at IS NULL;
+
+-- name: SoftDeleteTaskComment :execrows
+UPDATE task_comments SET deleted_at = ?
+WHERE id = ? AND task_id = ? AND deleted_at IS NULL;
+
+-- name: CreateTaskCommentRevision :exec
+INSERT INTO task_comment_revisions (comment_id, body, edited_by, edited_at)
+VALUES (?, ?, ?, ?);
+
+-- name: ListTaskCommentRevisions :many
+SELECT * FROM task_comment_revisions
+WHERE comment_id = ?
+ORDER BY edited_at, id;
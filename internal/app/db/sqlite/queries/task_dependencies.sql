@@ -0,0 +1,40 @@
+-- name: CreateTaskDependency :exec
+INSERT INTO task_dependencies (task_id, blocked_by_id, created_at)
+VALUES (?, ?, ?)
+ON CONFLICT (task_id, blocked_by_id) DO NOTHING;
+
+-- name: DeleteTaskDependency :execrows
+DELETE FROM task_dependencies WHERE task_id = ? AND blocked_by_id = ?;
+
+-- name: GetTransitiveBlockerIDs :many
+WITH RECURSIVE blockers(id) AS (
+    SELECT d.blocked_by_id FROM task_dependencies d
+    JOIN tasks t ON t.id = d.blocked_by_id
+    WHERE d.task_id = sqlc.arg(task_id) AND t.deleted_at IS NULL
+    UNION
+    SELECT d.blocked_by_id FROM task_dependencies d
+    JOIN blockers b ON d.task_id = b.id
+    JOIN tasks t ON t.id = d.blocked_by_id
+    WHERE t.deleted_at IS NULL
+)
+SELECT CAST(id AS TEXT) AS id FROM blockers;
+
+-- name: GetDependencyEdges :many
+WITH RECURSIVE reachable(id) AS (
+    SELECT CAST(sqlc.arg(task_id) AS TEXT)
+    UNION
+    SELECT d.blocked_by_id FROM task_dependencies d
+    JOIN reachable r ON d.task_id = r.id
+    JOIN tasks t ON t.id = d.blocked_by_id
+    WHERE t.deleted_at IS NULL
+)
+SELECT task_dependencies.task_id, task_dependencies.blocked_by_id, task_dependencies.created_at FROM task_dependencies
+WHERE task_dependencies.task_id IN (SELECT id FROM reachable)
+    AND task_dependencies.blocked_by_id IN (SELECT id FROM reachable);
+
+-- name: CountOpenBlockers :one
+SELECT COUNT(*) FROM task_dependencies d
+JOIN tasks t ON t.id = d.blocked_by_id
+WHERE d.task_id = sqlc.arg(task_id)
+    AND t.status NOT IN (SELECT value FROM json_each(sqlc.arg(done_statuses)))
+    AND t.deleted_at IS NULL;
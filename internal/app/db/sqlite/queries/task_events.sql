@@ -0,0 +1,31 @@
+-- name: CreateTaskEvent :exec
+INSERT INTO task_events (id, task_id, action, actor, request_id, changes, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListTaskEvents :many
+SELECT * FROM task_events
+WHERE task_id = ?
+ORDER BY created_at, rowid;
+
+-- name: ListTaskEventsUntil :many
+SELECT * FROM task_events
+WHERE task_id = sqlc.arg(task_id) AND created_at <= sqlc.arg(until)
+ORDER BY created_at, rowid;
+
+-- name: ListTaskEventsBefore :many
+SELECT * FROM task_events
+WHERE created_at < sqlc.arg(before)
+    AND (sqlc.arg(project_id) = '' OR task_id IN (
+        SELECT e.task_id FROM task_events e, json_each(e.changes) c
+        WHERE json_extract(c.value, '$.Field') = 'project_id'
+            AND json_extract(c.value, '$.After') = sqlc.arg(project_id)
+        UNION
+        SELECT id FROM tasks WHERE project_id = sqlc.arg(project_id)
+    ))
+    AND (sqlc.arg(tag) = '' OR task_id IN (
+        SELECT e.task_id FROM task_events e, json_each(e.changes) c, json_each(c.value, '$.After') t
+        WHERE json_extract(c.value, '$.Field') = 'tags' AND t.value = sqlc.arg(tag)
+        UNION
+        SELECT task_id FROM task_tags WHERE tag = sqlc.arg(tag)
+    ))
+ORDER BY created_at, rowid;
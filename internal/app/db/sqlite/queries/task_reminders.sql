@@ -0,0 +1,38 @@
+-- name: CreateTaskReminder :exec
+INSERT INTO task_reminders (id, task_id, offset_seconds, remind_at, created_by, created_at)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: GetTaskReminder :one
+SELECT * FROM task_reminders WHERE id = ? AND task_id = ?;
+
+-- name: ListTaskReminders :many
+SELECT * FROM task_reminders WHERE task_id = ? ORDER BY remind_at;
+
+-- name: DeleteTaskReminder :execrows
+DELETE FROM task_reminders WHERE id = ? AND task_id = ?;
+
+-- name: ListRelativeTaskReminders :many
+SELECT * FROM task_reminders
+WHERE task_id = ? AND offset_seconds IS NOT NULL AND fired_at IS NULL;
+
+-- name: RescheduleTaskReminder :exec
+UPDATE task_reminders SET remind_at = ? WHERE id = ?;
+
+-- name: ListDueTaskReminders :many
+SELECT * FROM task_reminders
+WHERE fired_at IS NULL AND remind_at <= sqlc.arg(now)
+    AND task_id IN (SELECT id FROM tasks WHERE deleted_at IS NULL)
+ORDER BY remind_at
+LIMIT sqlc.arg(limit);
+
+-- name: ClaimTaskReminder :execrows
+UPDATE task_reminders SET fired_at = sqlc.arg(fired_at), attempts = attempts + 1
+WHERE id = sqlc.arg(id) AND fired_at IS NULL;
+
+-- name: ReleaseTaskReminder :exec
+UPDATE task_reminders SET fired_at = NULL, last_error = sqlc.arg(last_error)
+WHERE id = sqlc.arg(id);
+
+-- name: FailTaskReminder :exec
+UPDATE task_reminders SET last_error = sqlc.arg(last_error)
+WHERE id = sqlc.arg(id);
@@ -0,0 +1,48 @@
+-- name: CreateTaskSeries :exec
+INSERT INTO task_series (id, project_id, rrule, dtstart, title, description, priority, occurrences, next_at, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetTaskSeries :one
+SELECT * FROM task_series WHERE id = ?;
+
+-- name: ListDueTaskSeries :many
+SELECT * FROM task_series
+WHERE next_at IS NOT NULL AND next_at <= ?
+ORDER BY next_at;
+
+-- name: AdvanceTaskSeries :exec
+UPDATE task_series SET
+    occurrences = sqlc.arg(occurrences),
+    next_at = sqlc.narg(next_at),
+    updated_at = sqlc.arg(updated_at)
+WHERE id = sqlc.arg(id);
+
+-- name: UpdateTaskSeries :exec
+UPDATE task_series SET
+    title = sqlc.arg(title),
+    description = sqlc.narg(description),
+    priority = sqlc.arg(priority),
+    updated_at = sqlc.arg(updated_at)
+WHERE id = sqlc.arg(id);
+
+-- name: DeleteTaskSeries :execrows
+DELETE FROM task_series WHERE id = ?;
+
+-- name: GetLatestSeriesTask :one
+SELECT * FROM tasks
+WHERE series_id = ? AND deleted_at IS NULL
+ORDER BY due_at DESC
+LIMIT 1;
+
+-- name: SeriesOccurrenceExists :one
+SELECT EXISTS (SELECT 1 FROM tasks WHERE series_id = ? AND due_at = ?);
+
+-- name: ListSeriesTaskIDs :many
+SELECT id FROM tasks WHERE series_id = ? AND deleted_at IS NULL ORDER BY due_at;
+
+-- name: ListOpenSeriesTaskIDs :many
+SELECT id FROM tasks
+WHERE series_id = sqlc.arg(series_id)
+    AND status NOT IN (SELECT value FROM json_each(sqlc.arg(done_statuses)))
+    AND deleted_at IS NULL
+ORDER BY due_at;
@@ -0,0 +1,12 @@
+-- name: CreateTaskTag :exec
+INSERT INTO task_tags (task_id, tag)
+VALUES (?, ?)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteTaskTags :exec
+DELETE FROM task_tags WHERE task_id = ?;
+
+-- name: ListTaskTags :many
+SELECT tag FROM task_tags
+WHERE task_id = ?
+ORDER BY tag;
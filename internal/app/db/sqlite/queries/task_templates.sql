@@ -0,0 +1,20 @@
+-- name: CreateTaskTemplate :exec
+INSERT INTO task_templates (id, name, description, definition, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: GetTaskTemplate :one
+SELECT * FROM task_templates WHERE id = ?;
+
+-- name: ListTaskTemplates :many
+SELECT * FROM task_templates ORDER BY name, id;
+
+-- name: UpdateTaskTemplate :exec
+UPDATE task_templates SET
+    name = sqlc.arg(name),
+    description = sqlc.arg(description),
+    definition = sqlc.arg(definition),
+    updated_at = sqlc.arg(updated_at)
+WHERE id = sqlc.arg(id);
+
+-- name: DeleteTaskTemplate :execrows
+DELETE FROM task_templates WHERE id = ?;
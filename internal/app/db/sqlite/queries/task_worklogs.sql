@@ -0,0 +1,33 @@
+-- name: CreateTaskWorklog :exec
+INSERT INTO task_worklogs (id, task_id, author, started_at, ended_at, duration_seconds, note, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetTaskWorklog :one
+SELECT * FROM task_worklogs WHERE id = ? AND task_id = ?;
+
+-- name: ListTaskWorklogs :many
+SELECT * FROM task_worklogs
+WHERE task_id = ?
+ORDER BY started_at, id;
+
+-- name: GetRunningWorklog :one
+SELECT * FROM task_worklogs WHERE author = ? AND ended_at IS NULL;
+
+-- name: StopTaskWorklog :execrows
+UPDATE task_worklogs SET ended_at = ?, duration_seconds = ?, note = ?
+WHERE id = ? AND ended_at IS NULL;
+
+-- name: DeleteTaskWorklog :execrows
+DELETE FROM task_worklogs WHERE id = ? AND task_id = ?;
+
+-- name: SumTaskWorklogs :many
+SELECT task_id, author, CAST(substr(started_at, 1, 10) AS TEXT) AS day,
+    CAST(SUM(duration_seconds) AS INTEGER) AS seconds, COUNT(*) AS entries
+FROM task_worklogs
+WHERE ended_at IS NOT NULL
+    AND (sqlc.narg(task_id) IS NULL OR task_id = sqlc.narg(task_id))
+    AND (sqlc.narg(author) IS NULL OR author = sqlc.narg(author))
+    AND (sqlc.narg(started_from) IS NULL OR started_at >= sqlc.narg(started_from))
+    AND (sqlc.narg(started_before) IS NULL OR started_at < sqlc.narg(started_before))
+GROUP BY task_id, author, day
+ORDER BY day, task_id, author;
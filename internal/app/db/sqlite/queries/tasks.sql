@@ -1,21 +1,89 @@
 -- name: CreateTask :exec
-INSERT INTO tasks (id, title, description, status, priority, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?);
+INSERT INTO tasks (id, title, description, status, priority, created_at, updated_at, project_id, seq, due_at, series_id, estimate, remaining, rank, parent_id, template_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 
 -- name: GetTask :one
+SELECT * FROM tasks WHERE id = ? AND deleted_at IS NULL;
+
+-- name: GetTaskIncludingDeleted :one
 SELECT * FROM tasks WHERE id = ?;
 
+-- name: GetTaskByKey :one
+SELECT * FROM tasks
+WHERE project_id = (SELECT id FROM projects WHERE key = sqlc.arg(key))
+    AND seq = sqlc.arg(seq)
+    AND deleted_at IS NULL;
+
 -- name: UpdateTask :exec
-UPDATE tasks SET 
-    title = COALESCE(NULLIF(sqlc.arg(title), ''), title),
-    description = COALESCE(sqlc.narg(description), description),
-    status = COALESCE(NULLIF(sqlc.arg(status), ''), status),
-    priority = COALESCE(NULLIF(sqlc.arg(priority), ''), priority),
-    updated_at = sqlc.arg(updated_at)
+UPDATE tasks SET
+    title = sqlc.arg(title),
+    description = sqlc.narg(description),
+    status = sqlc.arg(status),
+    priority = sqlc.arg(priority),
+    due_at = sqlc.narg(due_at),
+    estimate = sqlc.narg(estimate),
+    remaining = sqlc.narg(remaining),
+    rank = sqlc.arg(rank),
+    updated_at = sqlc.arg(updated_at),
+    version = version + 1
 WHERE id = sqlc.arg(id);
 
 -- name: DeleteTask :execrows
 DELETE FROM tasks WHERE id = ?;
 
 -- name: GetTasks :many
-SELECT * FROM tasks;
\ No newline at end of file
+SELECT * FROM tasks
+WHERE deleted_at IS NULL
+    AND (sqlc.narg(assignee) IS NULL
+    OR EXISTS (SELECT 1 FROM task_assignees a WHERE a.task_id = tasks.id AND a.assignee = sqlc.narg(assignee)))
+    AND (sqlc.narg(project_id) IS NULL OR project_id = sqlc.narg(project_id))
+    AND (sqlc.narg(tag) IS NULL
+    OR EXISTS (SELECT 1 FROM task_tags t WHERE t.task_id = tasks.id AND t.tag = sqlc.narg(tag)))
+ORDER BY status, rank, id;
+
+-- name: SoftDeleteTask :execrows
+UPDATE tasks SET deleted_at = sqlc.arg(deleted_at), version = version + 1
+WHERE id = sqlc.arg(id) AND deleted_at IS NULL;
+
+-- name: RestoreTask :execrows
+UPDATE tasks SET deleted_at = NULL, rank = sqlc.arg(rank), updated_at = sqlc.arg(updated_at), version = version + 1
+WHERE id = sqlc.arg(id) AND deleted_at IS NOT NULL;
+
+-- name: ListDeletedTasks :many
+SELECT * FROM tasks
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at DESC;
+
+-- name: ListPurgeableTaskIDs :many
+SELECT id FROM tasks
+WHERE deleted_at IS NOT NULL AND deleted_at <= sqlc.arg(deleted_before)
+ORDER BY deleted_at
+LIMIT sqlc.arg(limit);
+
+-- name: GetTaskByExternalID :one
+SELECT * FROM tasks WHERE external_id = ?;
+
+-- name: SetTaskExternalID :exec
+UPDATE tasks SET external_id = ?, version = version + 1 WHERE id = ?;
+
+-- name: GetLastTaskRank :one
+SELECT rank FROM tasks
+WHERE status = sqlc.arg(status) AND id != sqlc.arg(id) AND deleted_at IS NULL
+ORDER BY rank DESC
+LIMIT 1;
+
+-- name: GetNextTaskRank :one
+SELECT rank FROM tasks
+WHERE status = sqlc.arg(status) AND rank > sqlc.arg(rank) AND id != sqlc.arg(id) AND deleted_at IS NULL
+ORDER BY rank
+LIMIT 1;
+
+-- name: GetPreviousTaskRank :one
+SELECT rank FROM tasks
+WHERE status = sqlc.arg(status) AND rank < sqlc.arg(rank) AND id != sqlc.arg(id) AND deleted_at IS NULL
+ORDER BY rank DESC
+LIMIT 1;
+
+-- name: SetTaskRank :exec
+UPDATE tasks SET rank = sqlc.arg(rank), updated_at = sqlc.arg(updated_at), version = version + 1
+WHERE id = sqlc.arg(id);
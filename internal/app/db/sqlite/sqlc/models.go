@@ -11,6 +11,44 @@ import (
 	"github.com/alexgolang/ishare-task/internal/app/domain"
 )
 
+type CalendarFeed struct {
+	ClientID  string    `json:"client_id"`
+	TokenHash string    `json:"token_hash"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type IdempotencyKey struct {
+	ClientID    string         `json:"client_id"`
+	Key         string         `json:"key"`
+	RequestHash string         `json:"request_hash"`
+	StatusCode  sql.NullInt64  `json:"status_code"`
+	Headers     sql.NullString `json:"headers"`
+	Body        []byte         `json:"body"`
+	CreatedAt   time.Time      `json:"created_at"`
+	ExpiresAt   time.Time      `json:"expires_at"`
+}
+
+type Project struct {
+	ID          string         `json:"id"`
+	Key         string         `json:"key"`
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+	Archived    bool           `json:"archived"`
+	LastTaskSeq int64          `json:"last_task_seq"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+type SavedView struct {
+	ID         string    `json:"id"`
+	Owner      string    `json:"owner"`
+	Name       string    `json:"name"`
+	Shared     bool      `json:"shared"`
+	Definition string    `json:"definition"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
 type Task struct {
 	ID          string              `json:"id"`
 	Title       string              `json:"title"`
@@ -19,4 +57,128 @@ type Task struct {
 	Priority    domain.TaskPriority `json:"priority"`
 	CreatedAt   time.Time           `json:"created_at"`
 	UpdatedAt   time.Time           `json:"updated_at"`
+	ProjectID   string              `json:"project_id"`
+	Seq         int64               `json:"seq"`
+	DueAt       sql.NullTime        `json:"due_at"`
+	SeriesID    sql.NullString      `json:"series_id"`
+	DeletedAt   sql.NullTime        `json:"deleted_at"`
+	Version     int64               `json:"version"`
+	ExternalID  sql.NullString      `json:"external_id"`
+	Estimate    sql.NullFloat64     `json:"estimate"`
+	Remaining   sql.NullFloat64     `json:"remaining"`
+	Rank        string              `json:"rank"`
+	ParentID    sql.NullString      `json:"parent_id"`
+	TemplateID  sql.NullString      `json:"template_id"`
+}
+
+type TaskAssignee struct {
+	TaskID     string    `json:"task_id"`
+	Assignee   string    `json:"assignee"`
+	AssignedAt time.Time `json:"assigned_at"`
+}
+
+type TaskAssignmentEvent struct {
+	ID        int64     `json:"id"`
+	TaskID    string    `json:"task_id"`
+	Assignee  string    `json:"assignee"`
+	Action    string    `json:"action"`
+	Actor     string    `json:"actor"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TaskAttachment struct {
+	ID          string    `json:"id"`
+	TaskID      string    `json:"task_id"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"`
+	SizeBytes   int64     `json:"size_bytes"`
+	Sha256      string    `json:"sha256"`
+	StorageKey  string    `json:"storage_key"`
+	UploadedBy  string    `json:"uploaded_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type TaskComment struct {
+	ID        string       `json:"id"`
+	TaskID    string       `json:"task_id"`
+	Author    string       `json:"author"`
+	Body      string       `json:"body"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+	DeletedAt sql.NullTime `json:"deleted_at"`
+}
+
+type TaskCommentRevision struct {
+	ID        int64     `json:"id"`
+	CommentID string    `json:"comment_id"`
+	Body      string    `json:"body"`
+	EditedBy  string    `json:"edited_by"`
+	EditedAt  time.Time `json:"edited_at"`
+}
+
+type TaskDependency struct {
+	TaskID      string    `json:"task_id"`
+	BlockedByID string    `json:"blocked_by_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type TaskEvent struct {
+	ID        string         `json:"id"`
+	TaskID    string         `json:"task_id"`
+	Action    string         `json:"action"`
+	Actor     string         `json:"actor"`
+	RequestID sql.NullString `json:"request_id"`
+	Changes   string         `json:"changes"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+type TaskReminder struct {
+	ID            string         `json:"id"`
+	TaskID        string         `json:"task_id"`
+	OffsetSeconds sql.NullInt64  `json:"offset_seconds"`
+	RemindAt      time.Time      `json:"remind_at"`
+	CreatedBy     string         `json:"created_by"`
+	CreatedAt     time.Time      `json:"created_at"`
+	FiredAt       sql.NullTime   `json:"fired_at"`
+	Attempts      int64          `json:"attempts"`
+	LastError     sql.NullString `json:"last_error"`
+}
+
+type TaskSeries struct {
+	ID          string              `json:"id"`
+	ProjectID   string              `json:"project_id"`
+	Rrule       string              `json:"rrule"`
+	Dtstart     time.Time           `json:"dtstart"`
+	Title       string              `json:"title"`
+	Description sql.NullString      `json:"description"`
+	Priority    domain.TaskPriority `json:"priority"`
+	Occurrences int64               `json:"occurrences"`
+	NextAt      sql.NullTime        `json:"next_at"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+type TaskTag struct {
+	TaskID string `json:"task_id"`
+	Tag    string `json:"tag"`
+}
+
+type TaskTemplate struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+	Definition  string         `json:"definition"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+type TaskWorklog struct {
+	ID              string        `json:"id"`
+	TaskID          string        `json:"task_id"`
+	Author          string        `json:"author"`
+	StartedAt       time.Time     `json:"started_at"`
+	EndedAt         sql.NullTime  `json:"ended_at"`
+	DurationSeconds sql.NullInt64 `json:"duration_seconds"`
+	Note            string        `json:"note"`
+	CreatedAt       time.Time     `json:"created_at"`
 }
@@ -6,14 +6,111 @@ package sqlc
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
 type Querier interface {
+	AdvanceTaskSeries(ctx context.Context, arg AdvanceTaskSeriesParams) error
+	ClaimTaskReminder(ctx context.Context, arg ClaimTaskReminderParams) (int64, error)
+	CompleteIdempotencyKey(ctx context.Context, arg CompleteIdempotencyKeyParams) error
+	CountOpenBlockers(ctx context.Context, arg CountOpenBlockersParams) (int64, error)
+	CountProjectTasks(ctx context.Context, projectID string) (int64, error)
+	CountTaskComments(ctx context.Context, taskID string) (int64, error)
+	CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) error
+	CreateProject(ctx context.Context, arg CreateProjectParams) error
+	CreateSavedView(ctx context.Context, arg CreateSavedViewParams) error
 	CreateTask(ctx context.Context, arg CreateTaskParams) error
+	CreateTaskAssignee(ctx context.Context, arg CreateTaskAssigneeParams) error
+	CreateTaskAssignmentEvent(ctx context.Context, arg CreateTaskAssignmentEventParams) error
+	CreateTaskAttachment(ctx context.Context, arg CreateTaskAttachmentParams) error
+	CreateTaskComment(ctx context.Context, arg CreateTaskCommentParams) error
+	CreateTaskCommentRevision(ctx context.Context, arg CreateTaskCommentRevisionParams) error
+	CreateTaskDependency(ctx context.Context, arg CreateTaskDependencyParams) error
+	CreateTaskEvent(ctx context.Context, arg CreateTaskEventParams) error
+	CreateTaskReminder(ctx context.Context, arg CreateTaskReminderParams) error
+	CreateTaskSeries(ctx context.Context, arg CreateTaskSeriesParams) error
+	CreateTaskTag(ctx context.Context, arg CreateTaskTagParams) error
+	CreateTaskTemplate(ctx context.Context, arg CreateTaskTemplateParams) error
+	CreateTaskWorklog(ctx context.Context, arg CreateTaskWorklogParams) error
+	DeleteCalendarFeed(ctx context.Context, clientID string) (int64, error)
+	DeleteExpiredIdempotencyKeys(ctx context.Context, expiresAt time.Time) (int64, error)
+	DeleteIdempotencyKey(ctx context.Context, arg DeleteIdempotencyKeyParams) error
+	DeleteProject(ctx context.Context, id string) (int64, error)
+	DeleteSavedView(ctx context.Context, id string) (int64, error)
 	DeleteTask(ctx context.Context, id string) (int64, error)
+	DeleteTaskAssignee(ctx context.Context, arg DeleteTaskAssigneeParams) (int64, error)
+	DeleteTaskAttachment(ctx context.Context, arg DeleteTaskAttachmentParams) (int64, error)
+	DeleteTaskDependency(ctx context.Context, arg DeleteTaskDependencyParams) (int64, error)
+	DeleteTaskReminder(ctx context.Context, arg DeleteTaskReminderParams) (int64, error)
+	DeleteTaskSeries(ctx context.Context, id string) (int64, error)
+	DeleteTaskTags(ctx context.Context, taskID string) error
+	DeleteTaskTemplate(ctx context.Context, id string) (int64, error)
+	DeleteTaskWorklog(ctx context.Context, arg DeleteTaskWorklogParams) (int64, error)
+	FailTaskReminder(ctx context.Context, arg FailTaskReminderParams) error
+	GetCalendarFeed(ctx context.Context, clientID string) (CalendarFeed, error)
+	GetCalendarFeedByTokenHash(ctx context.Context, tokenHash string) (CalendarFeed, error)
+	GetDependencyEdges(ctx context.Context, taskID string) ([]TaskDependency, error)
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	GetLastTaskRank(ctx context.Context, arg GetLastTaskRankParams) (string, error)
+	GetLatestSeriesTask(ctx context.Context, seriesID sql.NullString) (Task, error)
+	GetNextTaskRank(ctx context.Context, arg GetNextTaskRankParams) (string, error)
+	GetPreviousTaskRank(ctx context.Context, arg GetPreviousTaskRankParams) (string, error)
+	GetProject(ctx context.Context, id string) (Project, error)
+	GetRunningWorklog(ctx context.Context, author string) (TaskWorklog, error)
+	GetSavedView(ctx context.Context, id string) (SavedView, error)
 	GetTask(ctx context.Context, id string) (Task, error)
-	GetTasks(ctx context.Context) ([]Task, error)
+	GetTaskAttachment(ctx context.Context, arg GetTaskAttachmentParams) (TaskAttachment, error)
+	GetTaskByExternalID(ctx context.Context, externalID sql.NullString) (Task, error)
+	GetTaskByKey(ctx context.Context, arg GetTaskByKeyParams) (Task, error)
+	GetTaskComment(ctx context.Context, arg GetTaskCommentParams) (TaskComment, error)
+	GetTaskIncludingDeleted(ctx context.Context, id string) (Task, error)
+	GetTaskReminder(ctx context.Context, arg GetTaskReminderParams) (TaskReminder, error)
+	GetTaskSeries(ctx context.Context, id string) (TaskSeries, error)
+	GetTaskTemplate(ctx context.Context, id string) (TaskTemplate, error)
+	GetTaskWorklog(ctx context.Context, arg GetTaskWorklogParams) (TaskWorklog, error)
+	GetTasks(ctx context.Context, arg GetTasksParams) ([]Task, error)
+	GetTransitiveBlockerIDs(ctx context.Context, taskID string) ([]string, error)
+	ListDeletedTasks(ctx context.Context) ([]Task, error)
+	ListDueTaskReminders(ctx context.Context, arg ListDueTaskRemindersParams) ([]TaskReminder, error)
+	ListDueTaskSeries(ctx context.Context, nextAt sql.NullTime) ([]TaskSeries, error)
+	ListOpenSeriesTaskIDs(ctx context.Context, arg ListOpenSeriesTaskIDsParams) ([]string, error)
+	ListProjects(ctx context.Context) ([]Project, error)
+	ListPurgeableTaskIDs(ctx context.Context, arg ListPurgeableTaskIDsParams) ([]string, error)
+	ListRelativeTaskReminders(ctx context.Context, taskID string) ([]TaskReminder, error)
+	ListSavedViews(ctx context.Context, client string) ([]SavedView, error)
+	ListSeriesTaskIDs(ctx context.Context, seriesID sql.NullString) ([]string, error)
+	ListTaskAssignees(ctx context.Context, taskID string) ([]string, error)
+	ListTaskAssignmentEvents(ctx context.Context, taskID string) ([]TaskAssignmentEvent, error)
+	ListTaskAttachmentStorageKeys(ctx context.Context, taskID string) ([]string, error)
+	ListTaskAttachments(ctx context.Context, taskID string) ([]TaskAttachment, error)
+	ListTaskCommentRevisions(ctx context.Context, commentID string) ([]TaskCommentRevision, error)
+	ListTaskComments(ctx context.Context, arg ListTaskCommentsParams) ([]TaskComment, error)
+	ListTaskEvents(ctx context.Context, taskID string) ([]TaskEvent, error)
+	ListTaskEventsBefore(ctx context.Context, arg ListTaskEventsBeforeParams) ([]TaskEvent, error)
+	ListTaskEventsUntil(ctx context.Context, arg ListTaskEventsUntilParams) ([]TaskEvent, error)
+	ListTaskReminders(ctx context.Context, taskID string) ([]TaskReminder, error)
+	ListTaskTags(ctx context.Context, taskID string) ([]string, error)
+	ListTaskTemplates(ctx context.Context) ([]TaskTemplate, error)
+	ListTaskWorklogs(ctx context.Context, taskID string) ([]TaskWorklog, error)
+	NextProjectTaskSeq(ctx context.Context, id string) (int64, error)
+	ReleaseTaskReminder(ctx context.Context, arg ReleaseTaskReminderParams) error
+	RescheduleTaskReminder(ctx context.Context, arg RescheduleTaskReminderParams) error
+	RestoreTask(ctx context.Context, arg RestoreTaskParams) (int64, error)
+	SeriesOccurrenceExists(ctx context.Context, arg SeriesOccurrenceExistsParams) (int64, error)
+	SetTaskExternalID(ctx context.Context, arg SetTaskExternalIDParams) error
+	SetTaskRank(ctx context.Context, arg SetTaskRankParams) error
+	SoftDeleteTask(ctx context.Context, arg SoftDeleteTaskParams) (int64, error)
+	SoftDeleteTaskComment(ctx context.Context, arg SoftDeleteTaskCommentParams) (int64, error)
+	StopTaskWorklog(ctx context.Context, arg StopTaskWorklogParams) (int64, error)
+	SumTaskWorklogs(ctx context.Context, arg SumTaskWorklogsParams) ([]SumTaskWorklogsRow, error)
+	UpdateProject(ctx context.Context, arg UpdateProjectParams) error
+	UpdateSavedView(ctx context.Context, arg UpdateSavedViewParams) error
 	UpdateTask(ctx context.Context, arg UpdateTaskParams) error
+	UpdateTaskCommentBody(ctx context.Context, arg UpdateTaskCommentBodyParams) (int64, error)
+	UpdateTaskSeries(ctx context.Context, arg UpdateTaskSeriesParams) error
+	UpdateTaskTemplate(ctx context.Context, arg UpdateTaskTemplateParams) error
+	UpsertCalendarFeed(ctx context.Context, arg UpsertCalendarFeedParams) error
 }
 
 var _ Querier = (*Queries)(nil)
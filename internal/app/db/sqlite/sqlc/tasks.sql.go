@@ -14,8 +14,8 @@ import (
 )
 
 const createTask = `-- name: CreateTask :exec
-INSERT INTO tasks (id, title, description, status, priority, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?)
+INSERT INTO tasks (id, title, description, status, priority, created_at, updated_at, project_id, seq, due_at, series_id, estimate, remaining, rank, parent_id, template_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type CreateTaskParams struct {
@@ -26,6 +26,15 @@ type CreateTaskParams struct {
 	Priority    domain.TaskPriority `json:"priority"`
 	CreatedAt   time.Time           `json:"created_at"`
 	UpdatedAt   time.Time           `json:"updated_at"`
+	ProjectID   string              `json:"project_id"`
+	Seq         int64               `json:"seq"`
+	DueAt       sql.NullTime        `json:"due_at"`
+	SeriesID    sql.NullString      `json:"series_id"`
+	Estimate    sql.NullFloat64     `json:"estimate"`
+	Remaining   sql.NullFloat64     `json:"remaining"`
+	Rank        string              `json:"rank"`
+	ParentID    sql.NullString      `json:"parent_id"`
+	TemplateID  sql.NullString      `json:"template_id"`
 }
 
 func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) error {
@@ -37,6 +46,15 @@ func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) error {
 		arg.Priority,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.ProjectID,
+		arg.Seq,
+		arg.DueAt,
+		arg.SeriesID,
+		arg.Estimate,
+		arg.Remaining,
+		arg.Rank,
+		arg.ParentID,
+		arg.TemplateID,
 	)
 	return err
 }
@@ -53,8 +71,78 @@ func (q *Queries) DeleteTask(ctx context.Context, id string) (int64, error) {
 	return result.RowsAffected()
 }
 
+const getLastTaskRank = `-- name: GetLastTaskRank :one
+SELECT rank FROM tasks
+WHERE status = ?1 AND id != ?2 AND deleted_at IS NULL
+ORDER BY rank DESC
+LIMIT 1
+`
+
+type GetLastTaskRankParams struct {
+	Status domain.TaskStatus `json:"status"`
+	ID     string            `json:"id"`
+}
+
+func (q *Queries) GetLastTaskRank(ctx context.Context, arg GetLastTaskRankParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getLastTaskRank,
+		arg.Status,
+		arg.ID,
+	)
+	var rank string
+	err := row.Scan(&rank)
+	return rank, err
+}
+
+const getNextTaskRank = `-- name: GetNextTaskRank :one
+SELECT rank FROM tasks
+WHERE status = ?1 AND rank > ?2 AND id != ?3 AND deleted_at IS NULL
+ORDER BY rank
+LIMIT 1
+`
+
+type GetNextTaskRankParams struct {
+	Status domain.TaskStatus `json:"status"`
+	Rank   string            `json:"rank"`
+	ID     string            `json:"id"`
+}
+
+func (q *Queries) GetNextTaskRank(ctx context.Context, arg GetNextTaskRankParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getNextTaskRank,
+		arg.Status,
+		arg.Rank,
+		arg.ID,
+	)
+	var rank string
+	err := row.Scan(&rank)
+	return rank, err
+}
+
+const getPreviousTaskRank = `-- name: GetPreviousTaskRank :one
+SELECT rank FROM tasks
+WHERE status = ?1 AND rank < ?2 AND id != ?3 AND deleted_at IS NULL
+ORDER BY rank DESC
+LIMIT 1
+`
+
+type GetPreviousTaskRankParams struct {
+	Status domain.TaskStatus `json:"status"`
+	Rank   string            `json:"rank"`
+	ID     string            `json:"id"`
+}
+
+func (q *Queries) GetPreviousTaskRank(ctx context.Context, arg GetPreviousTaskRankParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getPreviousTaskRank,
+		arg.Status,
+		arg.Rank,
+		arg.ID,
+	)
+	var rank string
+	err := row.Scan(&rank)
+	return rank, err
+}
+
 const getTask = `-- name: GetTask :one
-SELECT id, title, description, status, priority, created_at, updated_at FROM tasks WHERE id = ?
+SELECT id, title, description, status, priority, created_at, updated_at, project_id, seq, due_at, series_id, deleted_at, version, external_id, estimate, remaining, rank, parent_id, template_id FROM tasks WHERE id = ? AND deleted_at IS NULL
 `
 
 func (q *Queries) GetTask(ctx context.Context, id string) (Task, error) {
@@ -68,16 +156,149 @@ func (q *Queries) GetTask(ctx context.Context, id string) (Task, error) {
 		&i.Priority,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ProjectID,
+		&i.Seq,
+		&i.DueAt,
+		&i.SeriesID,
+		&i.DeletedAt,
+		&i.Version,
+		&i.ExternalID,
+		&i.Estimate,
+		&i.Remaining,
+		&i.Rank,
+		&i.ParentID,
+		&i.TemplateID,
+	)
+	return i, err
+}
+
+const getTaskByExternalID = `-- name: GetTaskByExternalID :one
+SELECT id, title, description, status, priority, created_at, updated_at, project_id, seq, due_at, series_id, deleted_at, version, external_id, estimate, remaining, rank, parent_id, template_id FROM tasks WHERE external_id = ?
+`
+
+func (q *Queries) GetTaskByExternalID(ctx context.Context, externalID sql.NullString) (Task, error) {
+	row := q.db.QueryRowContext(ctx, getTaskByExternalID, externalID)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.Status,
+		&i.Priority,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ProjectID,
+		&i.Seq,
+		&i.DueAt,
+		&i.SeriesID,
+		&i.DeletedAt,
+		&i.Version,
+		&i.ExternalID,
+		&i.Estimate,
+		&i.Remaining,
+		&i.Rank,
+		&i.ParentID,
+		&i.TemplateID,
+	)
+	return i, err
+}
+
+const getTaskByKey = `-- name: GetTaskByKey :one
+SELECT id, title, description, status, priority, created_at, updated_at, project_id, seq, due_at, series_id, deleted_at, version, external_id, estimate, remaining, rank, parent_id, template_id FROM tasks
+WHERE project_id = (SELECT id FROM projects WHERE key = ?1)
+    AND seq = ?2
+    AND deleted_at IS NULL
+`
+
+type GetTaskByKeyParams struct {
+	Key string `json:"key"`
+	Seq int64  `json:"seq"`
+}
+
+func (q *Queries) GetTaskByKey(ctx context.Context, arg GetTaskByKeyParams) (Task, error) {
+	row := q.db.QueryRowContext(ctx, getTaskByKey,
+		arg.Key,
+		arg.Seq,
+	)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.Status,
+		&i.Priority,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ProjectID,
+		&i.Seq,
+		&i.DueAt,
+		&i.SeriesID,
+		&i.DeletedAt,
+		&i.Version,
+		&i.ExternalID,
+		&i.Estimate,
+		&i.Remaining,
+		&i.Rank,
+		&i.ParentID,
+		&i.TemplateID,
+	)
+	return i, err
+}
+
+const getTaskIncludingDeleted = `-- name: GetTaskIncludingDeleted :one
+SELECT id, title, description, status, priority, created_at, updated_at, project_id, seq, due_at, series_id, deleted_at, version, external_id, estimate, remaining, rank, parent_id, template_id FROM tasks WHERE id = ?
+`
+
+func (q *Queries) GetTaskIncludingDeleted(ctx context.Context, id string) (Task, error) {
+	row := q.db.QueryRowContext(ctx, getTaskIncludingDeleted, id)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.Status,
+		&i.Priority,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ProjectID,
+		&i.Seq,
+		&i.DueAt,
+		&i.SeriesID,
+		&i.DeletedAt,
+		&i.Version,
+		&i.ExternalID,
+		&i.Estimate,
+		&i.Remaining,
+		&i.Rank,
+		&i.ParentID,
+		&i.TemplateID,
 	)
 	return i, err
 }
 
 const getTasks = `-- name: GetTasks :many
-SELECT id, title, description, status, priority, created_at, updated_at FROM tasks
+SELECT id, title, description, status, priority, created_at, updated_at, project_id, seq, due_at, series_id, deleted_at, version, external_id, estimate, remaining, rank, parent_id, template_id FROM tasks
+WHERE deleted_at IS NULL
+    AND (?1 IS NULL
+    OR EXISTS (SELECT 1 FROM task_assignees a WHERE a.task_id = tasks.id AND a.assignee = ?1))
+    AND (?2 IS NULL OR project_id = ?2)
+    AND (?3 IS NULL
+    OR EXISTS (SELECT 1 FROM task_tags t WHERE t.task_id = tasks.id AND t.tag = ?3))
+ORDER BY status, rank, id
 `
 
-func (q *Queries) GetTasks(ctx context.Context) ([]Task, error) {
-	rows, err := q.db.QueryContext(ctx, getTasks)
+type GetTasksParams struct {
+	Assignee  sql.NullString `json:"assignee"`
+	ProjectID sql.NullString `json:"project_id"`
+	Tag       sql.NullString `json:"tag"`
+}
+
+func (q *Queries) GetTasks(ctx context.Context, arg GetTasksParams) ([]Task, error) {
+	rows, err := q.db.QueryContext(ctx, getTasks,
+		arg.Assignee,
+		arg.ProjectID,
+		arg.Tag,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -93,6 +314,18 @@ func (q *Queries) GetTasks(ctx context.Context) ([]Task, error) {
 			&i.Priority,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ProjectID,
+			&i.Seq,
+			&i.DueAt,
+			&i.SeriesID,
+			&i.DeletedAt,
+			&i.Version,
+			&i.ExternalID,
+			&i.Estimate,
+			&i.Remaining,
+			&i.Rank,
+			&i.ParentID,
+			&i.TemplateID,
 		); err != nil {
 			return nil, err
 		}
@@ -107,23 +340,200 @@ func (q *Queries) GetTasks(ctx context.Context) ([]Task, error) {
 	return items, nil
 }
 
+const listDeletedTasks = `-- name: ListDeletedTasks :many
+SELECT id, title, description, status, priority, created_at, updated_at, project_id, seq, due_at, series_id, deleted_at, version, external_id, estimate, remaining, rank, parent_id, template_id FROM tasks
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at DESC
+`
+
+func (q *Queries) ListDeletedTasks(ctx context.Context) ([]Task, error) {
+	rows, err := q.db.QueryContext(ctx, listDeletedTasks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Task{}
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.Priority,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ProjectID,
+			&i.Seq,
+			&i.DueAt,
+			&i.SeriesID,
+			&i.DeletedAt,
+			&i.Version,
+			&i.ExternalID,
+			&i.Estimate,
+			&i.Remaining,
+			&i.Rank,
+			&i.ParentID,
+			&i.TemplateID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPurgeableTaskIDs = `-- name: ListPurgeableTaskIDs :many
+SELECT id FROM tasks
+WHERE deleted_at IS NOT NULL AND deleted_at <= ?1
+ORDER BY deleted_at
+LIMIT ?2
+`
+
+type ListPurgeableTaskIDsParams struct {
+	DeletedBefore sql.NullTime `json:"deleted_before"`
+	Limit         int64        `json:"limit"`
+}
+
+func (q *Queries) ListPurgeableTaskIDs(ctx context.Context, arg ListPurgeableTaskIDsParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listPurgeableTaskIDs,
+		arg.DeletedBefore,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const restoreTask = `-- name: RestoreTask :execrows
+UPDATE tasks SET deleted_at = NULL, rank = ?1, updated_at = ?2, version = version + 1
+WHERE id = ?3 AND deleted_at IS NOT NULL
+`
+
+type RestoreTaskParams struct {
+	Rank      string    `json:"rank"`
+	UpdatedAt time.Time `json:"updated_at"`
+	ID        string    `json:"id"`
+}
+
+func (q *Queries) RestoreTask(ctx context.Context, arg RestoreTaskParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreTask,
+		arg.Rank,
+		arg.UpdatedAt,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setTaskExternalID = `-- name: SetTaskExternalID :exec
+UPDATE tasks SET external_id = ?, version = version + 1 WHERE id = ?
+`
+
+type SetTaskExternalIDParams struct {
+	ExternalID sql.NullString `json:"external_id"`
+	ID         string         `json:"id"`
+}
+
+func (q *Queries) SetTaskExternalID(ctx context.Context, arg SetTaskExternalIDParams) error {
+	_, err := q.db.ExecContext(ctx, setTaskExternalID,
+		arg.ExternalID,
+		arg.ID,
+	)
+	return err
+}
+
+const setTaskRank = `-- name: SetTaskRank :exec
+UPDATE tasks SET rank = ?1, updated_at = ?2, version = version + 1
+WHERE id = ?3
+`
+
+type SetTaskRankParams struct {
+	Rank      string    `json:"rank"`
+	UpdatedAt time.Time `json:"updated_at"`
+	ID        string    `json:"id"`
+}
+
+func (q *Queries) SetTaskRank(ctx context.Context, arg SetTaskRankParams) error {
+	_, err := q.db.ExecContext(ctx, setTaskRank,
+		arg.Rank,
+		arg.UpdatedAt,
+		arg.ID,
+	)
+	return err
+}
+
+const softDeleteTask = `-- name: SoftDeleteTask :execrows
+UPDATE tasks SET deleted_at = ?1, version = version + 1
+WHERE id = ?2 AND deleted_at IS NULL
+`
+
+type SoftDeleteTaskParams struct {
+	DeletedAt sql.NullTime `json:"deleted_at"`
+	ID        string       `json:"id"`
+}
+
+func (q *Queries) SoftDeleteTask(ctx context.Context, arg SoftDeleteTaskParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, softDeleteTask,
+		arg.DeletedAt,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateTask = `-- name: UpdateTask :exec
-UPDATE tasks SET 
-    title = COALESCE(NULLIF(?1, ''), title),
-    description = COALESCE(?2, description),
-    status = COALESCE(NULLIF(?3, ''), status),
-    priority = COALESCE(NULLIF(?4, ''), priority),
-    updated_at = ?5
-WHERE id = ?6
+UPDATE tasks SET
+    title = ?1,
+    description = ?2,
+    status = ?3,
+    priority = ?4,
+    due_at = ?5,
+    estimate = ?6,
+    remaining = ?7,
+    rank = ?8,
+    updated_at = ?9,
+    version = version + 1
+WHERE id = ?10
 `
 
 type UpdateTaskParams struct {
-	Title       interface{}    `json:"title"`
-	Description sql.NullString `json:"description"`
-	Status      interface{}    `json:"status"`
-	Priority    interface{}    `json:"priority"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	ID          string         `json:"id"`
+	Title       string              `json:"title"`
+	Description sql.NullString      `json:"description"`
+	Status      domain.TaskStatus   `json:"status"`
+	Priority    domain.TaskPriority `json:"priority"`
+	DueAt       sql.NullTime        `json:"due_at"`
+	Estimate    sql.NullFloat64     `json:"estimate"`
+	Remaining   sql.NullFloat64     `json:"remaining"`
+	Rank        string              `json:"rank"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+	ID          string              `json:"id"`
 }
 
 func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) error {
@@ -132,6 +542,10 @@ func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) error {
 		arg.Description,
 		arg.Status,
 		arg.Priority,
+		arg.DueAt,
+		arg.Estimate,
+		arg.Remaining,
+		arg.Rank,
 		arg.UpdatedAt,
 		arg.ID,
 	)
@@ -0,0 +1,174 @@
+package sqlite
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/filter"
+)
+
+// taskFilterColumns are the columns of the task fields filter expressions
+// compare directly.
+var taskFilterColumns = map[string]string{
+	"status":      "status",
+	"priority":    "priority",
+	"title":       "title",
+	"description": "description",
+	"estimate":    "estimate",
+	"remaining":   "remaining",
+	"due":         "due_at",
+	"created":     "created_at",
+	"updated":     "updated_at",
+}
+
+// taskFilterSets are the tables and columns of the task fields that hold
+// several values.
+var taskFilterSets = map[string]struct{ table, column string }{
+	"tag":      {"task_tags", "tag"},
+	"assignee": {"task_assignees", "assignee"},
+}
+
+// Filter keeps the tasks a filter expression matches. Relative times in the
+// expression are taken from now.
+func (q *TaskQuery) Filter(expr filter.Expr, now time.Time) *TaskQuery {
+	c := &filterCompiler{now: now}
+	condition, err := c.compile(expr)
+	if err != nil {
+		q.err = err
+		return q
+	}
+	return q.Where(condition, c.args...)
+}
+
+type filterCompiler struct {
+	now  time.Time
+	args []any
+}
+
+func (c *filterCompiler) compile(expr filter.Expr) (string, error) {
+	switch e := expr.(type) {
+	case *filter.And:
+		return c.binary(e.Left, "AND", e.Right)
+
+	case *filter.Or:
+		return c.binary(e.Left, "OR", e.Right)
+
+	case *filter.Not:
+		inner, err := c.compile(e.Expr)
+		if err != nil {
+			return "", err
+		}
+		// Comparisons with a missing value are unknown in SQL; the
+		// expression language treats them as false, also when negated.
+		return "NOT COALESCE(" + inner + ", FALSE)", nil
+
+	case *filter.Comparison:
+		return c.comparison(e)
+	}
+
+	return "", fmt.Errorf("cannot compile filter expression %T", expr)
+}
+
+func (c *filterCompiler) binary(left filter.Expr, operator string, right filter.Expr) (string, error) {
+	l, err := c.compile(left)
+	if err != nil {
+		return "", err
+	}
+
+	r, err := c.compile(right)
+	if err != nil {
+		return "", err
+	}
+
+	return "(" + l + " " + operator + " " + r + ")", nil
+}
+
+func (c *filterCompiler) comparison(e *filter.Comparison) (string, error) {
+	isNull := len(e.Values) == 1 && e.Values[0].Kind == filter.KindNull
+
+	if e.Field == "project" {
+		if isNull {
+			return "", fmt.Errorf("cannot compare project with null")
+		}
+		return c.negate(e.Op, "project_id IN (SELECT id FROM projects WHERE id IN ("+c.list(e.Values, false)+") OR key IN ("+c.list(e.Values, true)+"))"), nil
+	}
+
+	if set, ok := taskFilterSets[e.Field]; ok {
+		members := "SELECT 1 FROM " + set.table + " WHERE task_id = tasks.id"
+		switch {
+		case isNull:
+			return c.negate(e.Op, "NOT EXISTS ("+members+")"), nil
+		case e.Op == filter.OpIn || e.Op == filter.OpNotIn:
+			if len(e.Values) == 0 {
+				return c.negate(e.Op, "FALSE"), nil
+			}
+			return c.negate(e.Op, "EXISTS ("+members+" AND "+set.column+" IN ("+c.list(e.Values, false)+"))"), nil
+		default:
+			return c.negate(e.Op, "EXISTS ("+members+" AND "+set.column+" = "+c.bind(e.Values[0])+")"), nil
+		}
+	}
+
+	column, ok := taskFilterColumns[e.Field]
+	if !ok {
+		return "", fmt.Errorf("cannot filter tasks by %q", e.Field)
+	}
+
+	if isNull {
+		condition := column + " IS NULL"
+		if e.Field == "description" {
+			condition = "COALESCE(description, '') = ''"
+		}
+		return c.negate(e.Op, condition), nil
+	}
+
+	switch e.Op {
+	case filter.OpIn, filter.OpNotIn:
+		if len(e.Values) == 0 {
+			return c.negate(e.Op, "FALSE"), nil
+		}
+		return c.negate(e.Op, column+" IN ("+c.list(e.Values, false)+")"), nil
+
+	case filter.OpNe:
+		return c.negate(e.Op, column+" = "+c.bind(e.Values[0])), nil
+
+	case filter.OpContains:
+		c.args = append(c.args, "%"+escapeLike(e.Values[0].Text)+"%")
+		return column + ` LIKE ? ESCAPE '\'`, nil
+	}
+
+	return column + " " + string(e.Op) + " " + c.bind(e.Values[0]), nil
+}
+
+// negate returns a condition as it is for positive operators, and its
+// negation for !=, not in and != null, which keep the tasks missing the value.
+func (c *filterCompiler) negate(op filter.Op, condition string) string {
+	if op == filter.OpNe || op == filter.OpNotIn {
+		return "NOT COALESCE(" + condition + ", FALSE)"
+	}
+	return "(" + condition + ")"
+}
+
+// bind adds a value as a query argument and returns its placeholder.
+func (c *filterCompiler) bind(value filter.Value) string {
+	switch value.Kind {
+	case filter.KindNumber:
+		c.args = append(c.args, value.Number)
+	case filter.KindTime:
+		c.args = append(c.args, value.TimeAt(c.now).UTC())
+	default:
+		c.args = append(c.args, value.Text)
+	}
+	return "?"
+}
+
+func (c *filterCompiler) list(values []filter.Value, upper bool) string {
+	placeholders := make([]string, len(values))
+	for i, value := range values {
+		if upper {
+			value.Text = strings.ToUpper(value.Text)
+		}
+		placeholders[i] = c.bind(value)
+	}
+	return strings.Join(placeholders, ", ")
+}
@@ -0,0 +1,252 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+)
+
+// taskSortExpressions are the SQL expressions tasks are sorted by. Each one
+// yields text, so that the keys of the last task on a page can be handed out
+// in a cursor and compared again as they are.
+var taskSortExpressions = map[domain.TaskSortField]string{
+	domain.TaskSortCreatedAt: "CAST(created_at AS TEXT)",
+	domain.TaskSortUpdatedAt: "CAST(updated_at AS TEXT)",
+	domain.TaskSortDueAt:     "COALESCE(CAST(due_at AS TEXT), '~')",
+	domain.TaskSortPriority:  "CASE priority WHEN 'high' THEN '2' WHEN 'medium' THEN '1' ELSE '0' END",
+	domain.TaskSortStatus:    "status",
+	domain.TaskSortTitle:     "lower(title)",
+	domain.TaskSortRank:      "rank",
+}
+
+// TaskQuery lists tasks with conditions, sort orders and keyset pagination
+// chosen at run time, which the static sqlc queries cannot express. Values
+// are always bound as parameters, and sort expressions come from a fixed
+// list, so no input ends up in the SQL text. Deleted tasks are never listed.
+type TaskQuery struct {
+	conditions []string
+	args       []any
+	sort       []domain.TaskSort
+	afterKeys  []string
+	afterID    string
+	limit      int
+	// err is the first error adding a condition, returned by Build.
+	err error
+}
+
+// TaskQueryRow is a task found by a TaskQuery, with the values of its sort
+// keys.
+type TaskQueryRow struct {
+	ID   string
+	Keys []string
+}
+
+func NewTaskQuery() *TaskQuery {
+	return &TaskQuery{}
+}
+
+// Where adds a condition on the tasks table, with a ? placeholder for each
+// argument.
+func (q *TaskQuery) Where(condition string, args ...any) *TaskQuery {
+	q.conditions = append(q.conditions, "("+condition+")")
+	q.args = append(q.args, args...)
+	return q
+}
+
+// InProject keeps the tasks of a project.
+func (q *TaskQuery) InProject(projectID string) *TaskQuery {
+	return q.Where("project_id = ?", projectID)
+}
+
+// AssignedTo keeps the tasks assigned to assignee.
+func (q *TaskQuery) AssignedTo(assignee string) *TaskQuery {
+	return q.Where("EXISTS (SELECT 1 FROM task_assignees a WHERE a.task_id = tasks.id AND a.assignee = ?)", assignee)
+}
+
+// Tagged keeps the tasks carrying tag.
+func (q *TaskQuery) Tagged(tag string) *TaskQuery {
+	return q.Where("EXISTS (SELECT 1 FROM task_tags t WHERE t.task_id = tasks.id AND t.tag = ?)", tag)
+}
+
+// StatusIn keeps the tasks with one of the statuses; give at least one.
+func (q *TaskQuery) StatusIn(statuses ...domain.TaskStatus) *TaskQuery {
+	args := make([]any, len(statuses))
+	for i, status := range statuses {
+		args[i] = status
+	}
+	return q.Where("status IN ("+placeholders(len(args))+")", args...)
+}
+
+// PriorityIn keeps the tasks with one of the priorities; give at least one.
+func (q *TaskQuery) PriorityIn(priorities ...domain.TaskPriority) *TaskQuery {
+	args := make([]any, len(priorities))
+	for i, priority := range priorities {
+		args[i] = priority
+	}
+	return q.Where("priority IN ("+placeholders(len(args))+")", args...)
+}
+
+// DueFrom keeps the tasks due at or after t.
+func (q *TaskQuery) DueFrom(t time.Time) *TaskQuery {
+	return q.Where("due_at >= ?", t.UTC())
+}
+
+// DueBefore keeps the tasks due before t.
+func (q *TaskQuery) DueBefore(t time.Time) *TaskQuery {
+	return q.Where("due_at < ?", t.UTC())
+}
+
+// Matching keeps the tasks whose title or description contains text,
+// ignoring the case of ASCII letters.
+func (q *TaskQuery) Matching(text string) *TaskQuery {
+	pattern := "%" + escapeLike(text) + "%"
+	return q.Where(`title LIKE ? ESCAPE '\' OR description LIKE ? ESCAPE '\'`, pattern, pattern)
+}
+
+// OrderBy sets the sort order. Ties are always broken by task ID.
+func (q *TaskQuery) OrderBy(sort ...domain.TaskSort) *TaskQuery {
+	q.sort = sort
+	return q
+}
+
+// After continues a listing after the task with the given ID and sort keys,
+// as returned in its TaskQueryRow.
+func (q *TaskQuery) After(keys []string, id string) *TaskQuery {
+	q.afterKeys = keys
+	q.afterID = id
+	return q
+}
+
+// Limit caps the number of tasks returned; zero means no limit.
+func (q *TaskQuery) Limit(n int) *TaskQuery {
+	q.limit = n
+	return q
+}
+
+// where returns the conditions of the query, without the position of After,
+// and their arguments.
+func (q *TaskQuery) where() ([]string, []any, error) {
+	if q.err != nil {
+		return nil, nil, q.err
+	}
+
+	conditions := append([]string{"deleted_at IS NULL"}, q.conditions...)
+	return conditions, append([]any{}, q.args...), nil
+}
+
+// Build returns the SQL statement and its arguments.
+func (q *TaskQuery) Build() (string, []any, error) {
+	conditions, args, err := q.where()
+	if err != nil {
+		return "", nil, err
+	}
+
+	keys := make([]string, len(q.sort))
+	for i, sort := range q.sort {
+		expression, ok := taskSortExpressions[sort.Field]
+		if !ok {
+			return "", nil, fmt.Errorf("cannot sort tasks by %q", sort.Field)
+		}
+		keys[i] = expression
+	}
+
+	if q.afterID != "" {
+		if len(q.afterKeys) != len(keys) {
+			return "", nil, fmt.Errorf("cursor has %d sort keys, expected %d", len(q.afterKeys), len(keys))
+		}
+
+		// Rows after (k1, ..., kn, id): the first key that differs decides.
+		var alternatives []string
+		for i := 0; i <= len(keys); i++ {
+			var terms []string
+			for j := 0; j < i; j++ {
+				terms = append(terms, keys[j]+" = ?")
+				args = append(args, q.afterKeys[j])
+			}
+			if i < len(keys) {
+				terms = append(terms, keys[i]+" "+afterOperator(q.sort[i])+" ?")
+				args = append(args, q.afterKeys[i])
+			} else {
+				terms = append(terms, "id > ?")
+				args = append(args, q.afterID)
+			}
+			alternatives = append(alternatives, "("+strings.Join(terms, " AND ")+")")
+		}
+		conditions = append(conditions, "("+strings.Join(alternatives, " OR ")+")")
+	}
+
+	order := make([]string, 0, len(keys)+1)
+	for i, key := range keys {
+		if q.sort[i].Descending {
+			key += " DESC"
+		}
+		order = append(order, key)
+	}
+	order = append(order, "id")
+
+	var b strings.Builder
+	b.WriteString("SELECT id")
+	for _, key := range keys {
+		b.WriteString(", " + key)
+	}
+	b.WriteString("\nFROM tasks\nWHERE " + strings.Join(conditions, "\n    AND "))
+	b.WriteString("\nORDER BY " + strings.Join(order, ", "))
+	if q.limit > 0 {
+		b.WriteString("\nLIMIT ?")
+		args = append(args, q.limit)
+	}
+
+	return b.String(), args, nil
+}
+
+// QueryTasks runs a TaskQuery.
+func (d *Database) QueryTasks(ctx context.Context, query *TaskQuery) ([]TaskQueryRow, error) {
+	statement, args, err := query.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []TaskQueryRow{}
+	for rows.Next() {
+		row := TaskQueryRow{Keys: make([]string, len(query.sort))}
+
+		dest := make([]any, 0, len(row.Keys)+1)
+		dest = append(dest, &row.ID)
+		for i := range row.Keys {
+			dest = append(dest, &row.Keys[i])
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+
+	return result, rows.Err()
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern, for use with
+// ESCAPE '\'.
+func escapeLike(text string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(text)
+}
+
+func afterOperator(sort domain.TaskSort) string {
+	if sort.Descending {
+		return "<"
+	}
+	return ">"
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
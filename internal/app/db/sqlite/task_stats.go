@@ -0,0 +1,169 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+)
+
+// taskStatsGroups are the joins and keys task statistics are grouped by. A
+// task with several assignees or tags counts once for each of them, and
+// tasks with none under an empty key. An empty grouping puts every task in a
+// single group.
+var taskStatsGroups = map[domain.TaskStatsGroupBy]struct{ join, key string }{
+	"":                              {"", "''"},
+	domain.TaskStatsGroupByStatus:   {"", "m.status"},
+	domain.TaskStatsGroupByPriority: {"", "m.priority"},
+	domain.TaskStatsGroupByProject:  {"JOIN projects p ON p.id = m.project_id", "p.key"},
+	domain.TaskStatsGroupByAssignee: {"LEFT JOIN task_assignees a ON a.task_id = m.id", "COALESCE(a.assignee, '')"},
+	domain.TaskStatsGroupByTag:      {"LEFT JOIN task_tags g ON g.task_id = m.id", "COALESCE(g.tag, '')"},
+}
+
+// completedEvents selects the history events that moved a task to a done
+// status, with the placeholder for the done statuses as a JSON array.
+const completedEvents = `SELECT e.task_id, e.created_at
+    FROM task_events e, json_each(e.changes) c
+    WHERE json_extract(c.value, '$.Field') = 'status'
+        AND json_extract(c.value, '$.After') IN (SELECT value FROM json_each(?))`
+
+// isDone is the condition of a task status being one of the done statuses,
+// with the placeholder for them as a JSON array.
+const isDone = `IN (SELECT value FROM json_each(?))`
+
+// TaskStatsRow holds the statistics of one group of tasks.
+type TaskStatsRow struct {
+	Key     string
+	Count   int64
+	Done    int64
+	Overdue int64
+	// AverageSecondsToDone is the mean time from creation to the last move
+	// to done of the done tasks, or to their last update when the history
+	// does not record it.
+	AverageSecondsToDone sql.NullFloat64
+}
+
+// TaskStatsDay counts the tasks of a query created and completed on a day.
+type TaskStatsDay struct {
+	Date      string
+	Created   int64
+	Completed int64
+}
+
+// TaskStats returns the statistics of the tasks a query matches, per group.
+// Tasks in one of the done statuses are done; the others that were due
+// before now are overdue.
+func (d *Database) TaskStats(ctx context.Context, query *TaskQuery, groupBy domain.TaskStatsGroupBy, done []domain.TaskStatus, now time.Time) ([]TaskStatsRow, error) {
+	group, ok := taskStatsGroups[groupBy]
+	if !ok {
+		return nil, fmt.Errorf("cannot group tasks by %q", groupBy)
+	}
+
+	conditions, args, err := query.where()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := json.Marshal(done)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := `WITH matched AS (
+    SELECT id, status, priority, project_id, due_at, created_at, updated_at
+    FROM tasks
+    WHERE ` + strings.Join(conditions, "\n        AND ") + `
+),
+completed AS (
+    SELECT task_id, MAX(created_at) AS done_at
+    FROM (` + completedEvents + `)
+    GROUP BY task_id
+)
+SELECT CAST(` + group.key + ` AS TEXT) AS key,
+    COUNT(*),
+    COALESCE(SUM(m.status ` + isDone + `), 0),
+    COALESCE(SUM(m.status NOT ` + isDone + ` AND m.due_at < ?), 0),
+    AVG(CASE WHEN m.status ` + isDone + ` THEN (julianday(COALESCE(c.done_at, m.updated_at)) - julianday(m.created_at)) * 86400 END)
+FROM matched m
+LEFT JOIN completed c ON c.task_id = m.id
+` + group.join + `
+GROUP BY key
+ORDER BY key`
+
+	args = append(args, statuses, statuses, statuses, now.UTC(), statuses)
+
+	rows, err := d.db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []TaskStatsRow{}
+	for rows.Next() {
+		var row TaskStatsRow
+		if err := rows.Scan(&row.Key, &row.Count, &row.Done, &row.Overdue, &row.AverageSecondsToDone); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+
+	return result, rows.Err()
+}
+
+// TaskStatsPerDay counts the tasks a query matches that were created, and
+// those moved to one of the done statuses, on each day (UTC) from from up to
+// but excluding to. A task moved to done several times in a day counts once.
+// Days without either are left out.
+func (d *Database) TaskStatsPerDay(ctx context.Context, query *TaskQuery, done []domain.TaskStatus, from, to time.Time) ([]TaskStatsDay, error) {
+	conditions, args, err := query.where()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := json.Marshal(done)
+	if err != nil {
+		return nil, err
+	}
+
+	where := strings.Join(conditions, "\n        AND ")
+	statement := `SELECT day, COUNT(DISTINCT created), COUNT(DISTINCT completed)
+FROM (
+    SELECT CAST(substr(created_at, 1, 10) AS TEXT) AS day, id AS created, NULL AS completed
+    FROM tasks
+    WHERE ` + where + `
+        AND created_at >= ? AND created_at < ?
+    UNION ALL
+    SELECT CAST(substr(created_at, 1, 10) AS TEXT), NULL, task_id
+    FROM (` + completedEvents + `)
+    WHERE task_id IN (SELECT id FROM tasks WHERE ` + where + `)
+        AND created_at >= ? AND created_at < ?
+)
+GROUP BY day
+ORDER BY day`
+
+	all := append([]any{}, args...)
+	all = append(all, from.UTC(), to.UTC(), statuses)
+	all = append(all, args...)
+	all = append(all, from.UTC(), to.UTC())
+
+	rows, err := d.db.QueryContext(ctx, statement, all...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []TaskStatsDay{}
+	for rows.Next() {
+		var day TaskStatsDay
+		if err := rows.Scan(&day.Date, &day.Created, &day.Completed); err != nil {
+			return nil, err
+		}
+		result = append(result, day)
+	}
+
+	return result, rows.Err()
+}
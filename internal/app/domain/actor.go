@@ -0,0 +1,29 @@
+package domain
+
+import "context"
+
+type actorContextKey struct{}
+
+// WithActor returns a context carrying the ID of the client performing the request.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext returns the client ID stored by WithActor, or an empty string.
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorContextKey{}).(string)
+	return actor
+}
+
+type requestIDContextKey struct{}
+
+// WithRequestID returns a context carrying the ID of the HTTP request being served.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored by WithRequestID, or an empty string.
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+type AssignmentAction string
+
+const (
+	AssignmentActionAssigned   AssignmentAction = "assigned"
+	AssignmentActionUnassigned AssignmentAction = "unassigned"
+)
+
+// @Description Record of an assignee being added to or removed from a task
+type AssignmentEvent struct {
+	Assignee  string
+	Action    AssignmentAction
+	Actor     string
+	CreatedAt time.Time
+}
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// @Description Metadata of a file attached to a task
+type Attachment struct {
+	ID          uuid.UUID
+	TaskID      uuid.UUID
+	Filename    string
+	ContentType string
+	SizeBytes   int64
+	SHA256      string
+	UploadedBy  string
+	CreatedAt   time.Time
+}
@@ -0,0 +1,25 @@
+package domain
+
+// @Description Request body for moving a task on the board; with neither neighbour the task goes to the bottom of the column
+type MoveTaskRequest struct {
+	// Status is the column to move the task to; empty keeps its status.
+	Status TaskStatus
+	// AfterID is the task the moved task is placed right after (above it).
+	AfterID string
+	// BeforeID is the task the moved task is placed right before (below it).
+	BeforeID string
+	// Comment is added to the task's thread along with a status change; some
+	// workflow transitions require it.
+	Comment string
+}
+
+// @Description Tasks of one status, in rank order
+type BoardColumn struct {
+	Status TaskStatus
+	Tasks  []Task
+}
+
+// @Description Tasks grouped by status, one column per workflow status in workflow order
+type Board struct {
+	Columns []BoardColumn
+}
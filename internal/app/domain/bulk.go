@@ -0,0 +1,78 @@
+package domain
+
+// ErrInvalidBulkRequest is returned for bulk requests that are malformed as a
+// whole, as opposed to failures of single operations.
+var ErrInvalidBulkRequest = &Error{Kind: ErrValidation, Message: "invalid bulk request"}
+
+// BulkMode decides what happens to a bulk request when one of its operations
+// fails.
+type BulkMode string
+
+const (
+	// BulkModeAtomic applies all operations or none of them.
+	BulkModeAtomic BulkMode = "atomic"
+	// BulkModeBestEffort applies every operation that succeeds.
+	BulkModeBestEffort BulkMode = "best_effort"
+)
+
+func (m BulkMode) IsValid() bool {
+	return m == BulkModeAtomic || m == BulkModeBestEffort || m == ""
+}
+
+type BulkOperationType string
+
+const (
+	BulkOperationCreate BulkOperationType = "create"
+	BulkOperationUpdate BulkOperationType = "update"
+	BulkOperationDelete BulkOperationType = "delete"
+)
+
+// @Description A single operation of a bulk request
+type BulkOperation struct {
+	// Op is one of create, update or delete.
+	Op BulkOperationType
+	// ID of the task to update or delete; task keys are not accepted.
+	ID string
+	// Create is the task to create when Op is create.
+	Create *CreateTaskRequest
+	// Patch is the update to apply when Op is update.
+	Patch *UpdateTaskRequest
+}
+
+// @Description Request body for bulk task operations: either a list of operations, or a filter with a patch applied to every matching task
+type BulkRequest struct {
+	// Mode is atomic (default) or best_effort.
+	Mode       BulkMode
+	Operations []BulkOperation
+	Filter     *TaskFilter
+	Patch      *UpdateTaskRequest
+}
+
+type BulkItemStatus string
+
+const (
+	BulkItemSucceeded  BulkItemStatus = "succeeded"
+	BulkItemFailed     BulkItemStatus = "failed"
+	BulkItemRolledBack BulkItemStatus = "rolled_back"
+	BulkItemSkipped    BulkItemStatus = "skipped"
+)
+
+// @Description Outcome of one operation of a bulk request
+type BulkItemResult struct {
+	Index int
+	Op    BulkOperationType
+	// ID of the affected task; empty when a create failed.
+	ID     string
+	Status BulkItemStatus
+	Error  string
+}
+
+// @Description Outcome of a bulk request, with one result per operation
+type BulkResult struct {
+	Mode BulkMode
+	// Committed is false when an atomic request was rolled back.
+	Committed bool
+	Succeeded int
+	Failed    int
+	Results   []BulkItemResult
+}
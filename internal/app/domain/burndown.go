@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// BurndownFilter selects the tasks a burndown report follows and the days it
+// covers. From and To are UTC dates and both are included; empty ProjectID
+// and Tag match every task.
+type BurndownFilter struct {
+	ProjectID string
+	Tag       string
+	From      time.Time
+	To        time.Time
+}
+
+// @Description Scope and progress at the end of one day (UTC). Amounts are in the unit of the task estimates.
+type BurndownPoint struct {
+	// Date is the day, YYYY-MM-DD.
+	Date string
+	// Scope is the sum of the estimates of all tasks in the report.
+	Scope float64
+	// Completed is the sum of the estimates of done tasks (burnup).
+	Completed float64
+	// Remaining is the work left on open tasks (burndown): their remaining
+	// work, or their estimate when none is recorded.
+	Remaining float64
+	// Ideal falls evenly from the first day's Remaining to zero on the last day.
+	Ideal     float64
+	Tasks     int64
+	DoneTasks int64
+	// Unestimated counts the tasks that have no estimate.
+	Unestimated int64
+}
+
+// @Description Daily burndown and burnup series of a project or tag, built from task history
+type BurndownReport struct {
+	ProjectID string
+	Tag       string
+	From      string
+	To        string
+	Points    []BurndownPoint
+}
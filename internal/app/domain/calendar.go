@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// @Description Calendar feed of the tasks assigned to a client
+type CalendarFeed struct {
+	// Token authenticates the feed URL. It is only returned when the feed is
+	// created; creating the feed again replaces it.
+	Token string
+	// URL is the address to subscribe to in a calendar app. Like Token, it is
+	// only returned when the feed is created.
+	URL       string
+	CreatedAt time.Time
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// @Description Comment left on a task by an authenticated client
+type Comment struct {
+	ID        uuid.UUID
+	TaskID    uuid.UUID
+	Author    string
+	Body      string
+	Edited    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// @Description Previous body of a comment, recorded each time it is edited
+type CommentRevision struct {
+	Body     string
+	EditedBy string
+	EditedAt time.Time
+}
+
+// @Description Page of comments ordered from oldest to newest
+type CommentPage struct {
+	Comments []Comment
+	Total    int64
+	Limit    int
+	Offset   int
+}
+
+// @Description Request body for creating a comment
+type CreateCommentRequest struct {
+	Body string
+}
+
+// @Description Request body for editing a comment
+type UpdateCommentRequest struct {
+	Body string
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// @Description Edge stating that TaskID cannot start until BlockedByID is done
+type TaskDependency struct {
+	TaskID      uuid.UUID
+	BlockedByID uuid.UUID
+	CreatedAt   time.Time
+}
+
+// @Description Request body for adding a blocker to a task
+type AddDependencyRequest struct {
+	BlockedByID string
+}
+
+// @Description Dependency graph of a task, blockers ordered before the tasks they block
+type DependencyGraph struct {
+	TaskID       uuid.UUID
+	Tasks        []Task
+	Edges        []TaskDependency
+	CriticalPath []uuid.UUID
+}
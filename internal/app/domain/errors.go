@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Error kinds. Services classify their failures with NotFound, Invalid,
+// Conflict and Forbidden, and the transport layer maps each kind to a status
+// code with errors.Is, independently of the error message.
+var (
+	ErrNotFound   = errors.New("not found")
+	ErrValidation = errors.New("validation failed")
+	ErrConflict   = errors.New("conflict")
+	ErrForbidden  = errors.New("forbidden")
+)
+
+// Error is a failure of a known kind, with a message meant for the client.
+type Error struct {
+	Kind    error
+	Message string
+	// Err is the underlying error, if any.
+	Err error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// NotFound reports that a resource does not exist.
+func NotFound(format string, args ...any) error {
+	return &Error{Kind: ErrNotFound, Message: fmt.Sprintf(format, args...)}
+}
+
+// Conflict reports that a request cannot be applied to the current state of
+// a resource.
+func Conflict(format string, args ...any) error {
+	return &Error{Kind: ErrConflict, Message: fmt.Sprintf(format, args...)}
+}
+
+// Forbidden reports that the caller may not perform a request.
+func Forbidden(format string, args ...any) error {
+	return &Error{Kind: ErrForbidden, Message: fmt.Sprintf(format, args...)}
+}
+
+// IsKnown reports whether err is of one of the error kinds, whose messages
+// are meant for the client.
+func IsKnown(err error) bool {
+	for _, kind := range []error{ErrNotFound, ErrValidation, ErrConflict, ErrForbidden, ErrVersionMismatch} {
+		if errors.Is(err, kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// Classify gives err the given kind, keeping it in the error chain.
+func Classify(kind error, err error) error {
+	return &Error{Kind: kind, Message: err.Error(), Err: err}
+}
+
+// FieldError describes why one field of a request is invalid.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// ValidationError reports invalid input, field by field.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
+
+// Invalid reports that a field of a request is invalid.
+func Invalid(field, format string, args ...any) error {
+	return &ValidationError{Fields: []FieldError{{Field: field, Message: fmt.Sprintf(format, args...)}}}
+}
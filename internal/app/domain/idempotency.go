@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// StoredResponse is a response saved under an idempotency key and replayed
+// verbatim to retries.
+type StoredResponse struct {
+	StatusCode int
+	Header     map[string][]string
+	Body       []byte
+}
+
+// IdempotencyRecord is the state of an idempotency key. Response is nil while
+// the first request using the key is still being processed.
+type IdempotencyRecord struct {
+	RequestHash string
+	Response    *StoredResponse
+	ExpiresAt   time.Time
+}
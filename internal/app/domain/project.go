@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultProjectID is the project tasks are filed under when none is given.
+var DefaultProjectID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+var projectKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9]{1,9}$`)
+
+// @Description Project grouping related tasks under a short key such as OPS
+type Project struct {
+	ID          uuid.UUID
+	Key         string
+	Name        string
+	Description string
+	Archived    bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// @Description Request body for creating a project
+type CreateProjectRequest struct {
+	Key         string
+	Name        string
+	Description string
+}
+
+// @Description Request body for updating a project (all fields optional)
+type UpdateProjectRequest struct {
+	Name        *string
+	Description *string
+	Archived    *bool
+}
+
+// IsValidProjectKey reports whether key is 2-10 upper-case letters or digits
+// starting with a letter.
+func IsValidProjectKey(key string) bool {
+	return projectKeyPattern.MatchString(key)
+}
+
+// TaskKey formats the human-friendly reference of a task, e.g. OPS-42.
+func TaskKey(projectKey string, seq int64) string {
+	return fmt.Sprintf("%s-%d", projectKey, seq)
+}
+
+// ParseTaskKey splits a reference such as OPS-42 into its project key and
+// sequence number.
+func ParseTaskKey(ref string) (string, int64, bool) {
+	i := strings.LastIndex(ref, "-")
+	if i <= 0 {
+		return "", 0, false
+	}
+
+	projectKey := strings.ToUpper(ref[:i])
+	if !IsValidProjectKey(projectKey) {
+		return "", 0, false
+	}
+
+	seq, err := strconv.ParseInt(ref[i+1:], 10, 64)
+	if err != nil || seq <= 0 {
+		return "", 0, false
+	}
+
+	return projectKey, seq, true
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// @Description Reminder that notifies the task's assignees at a given time
+type Reminder struct {
+	ID       uuid.UUID
+	TaskID   uuid.UUID
+	RemindAt time.Time
+	// Before is set for reminders relative to the due date, e.g. "1h0m0s".
+	Before    string
+	CreatedBy string
+	FiredAt   *time.Time
+	Attempts  int64
+	LastError string
+	CreatedAt time.Time
+}
+
+// @Description Request body for creating a reminder; give either RemindAt or Before
+type CreateReminderRequest struct {
+	RemindAt *time.Time
+	// Before is a duration such as "1h" or "30m" before the task's due date.
+	Before string
+}
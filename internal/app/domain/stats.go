@@ -0,0 +1,65 @@
+package domain
+
+import "time"
+
+// TaskStatsGroupBy is the dimension task statistics are broken down by.
+type TaskStatsGroupBy string
+
+const (
+	TaskStatsGroupByStatus   TaskStatsGroupBy = "status"
+	TaskStatsGroupByPriority TaskStatsGroupBy = "priority"
+	TaskStatsGroupByAssignee TaskStatsGroupBy = "assignee"
+	TaskStatsGroupByTag      TaskStatsGroupBy = "tag"
+	TaskStatsGroupByProject  TaskStatsGroupBy = "project"
+)
+
+func (g TaskStatsGroupBy) IsValid() bool {
+	switch g {
+	case TaskStatsGroupByStatus, TaskStatsGroupByPriority, TaskStatsGroupByAssignee, TaskStatsGroupByTag, TaskStatsGroupByProject:
+		return true
+	}
+	return false
+}
+
+// TaskStatsFilter selects the tasks statistics are computed for, how they
+// are grouped and the days (UTC, both included) of the daily series.
+type TaskStatsFilter struct {
+	Tasks   TaskFilter
+	GroupBy TaskStatsGroupBy
+	From    time.Time
+	To      time.Time
+}
+
+// @Description Statistics of one group of tasks. AverageTimeToDone is empty when none of them is done.
+type TaskStatsGroup struct {
+	// Key is the status, priority, assignee, tag or project key; tasks with
+	// no assignee or tag are grouped under an empty key.
+	Key     string
+	Count   int64
+	Done    int64
+	Overdue int64
+	// AverageTimeToDone is the mean time from creation to completion of the
+	// done tasks, e.g. "26h30m0s"; AverageSecondsToDone is the same in
+	// whole seconds.
+	AverageTimeToDone    string
+	AverageSecondsToDone int64
+}
+
+// @Description Tasks created and completed on one day (UTC)
+type TaskStatsDay struct {
+	// Date is the day, YYYY-MM-DD.
+	Date      string
+	Created   int64
+	Completed int64
+}
+
+// @Description Task statistics, in total and per group, with a daily series of created and completed tasks
+type TaskStats struct {
+	GroupBy TaskStatsGroupBy
+	From    string
+	To      string
+	// Total counts every task once, even when tasks are in several groups.
+	Total  TaskStatsGroup
+	Groups []TaskStatsGroup
+	Days   []TaskStatsDay
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -30,23 +31,63 @@ func (tp TaskPriority) IsValid() bool {
 	return tp == TaskPriorityLow || tp == TaskPriorityMedium || tp == TaskPriorityHigh || tp == ""
 }
 
+// ErrVersionMismatch is returned when a task was changed since the version a
+// client based its request on.
+var ErrVersionMismatch = errors.New("version mismatch")
+
 // @Description Task object with all details
 type Task struct {
 	ID          uuid.UUID
+	Key         string
+	ProjectID   uuid.UUID
 	Title       string
 	Description string
 	Status      TaskStatus
 	Priority    TaskPriority
+	Assignees   []string
+	Tags        []string
+	DueAt       *time.Time
+	SeriesID    *uuid.UUID
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	DeletedAt   *time.Time
+	// Version increases with every write and is exposed as the ETag.
+	Version int64
+	// ExternalID identifies the task in the system it was imported from.
+	ExternalID string
+	// Estimate is the size of the task in story points or hours, whichever
+	// unit the team uses; Remaining is the part of it still to do.
+	Estimate  *float64
+	Remaining *float64
+	// Rank orders the tasks of a status on the board; ranks compare as plain
+	// strings.
+	Rank string
+	// ParentID is the task this one is a subtask of.
+	ParentID *uuid.UUID
+	// TemplateID is the template the task was created from.
+	TemplateID *uuid.UUID
 }
 
 // @Description Request body for creating a new task
 type CreateTaskRequest struct {
+	// ProjectID defaults to the default project when empty.
+	ProjectID   string
 	Title       string
 	Description string
 	Status      TaskStatus
 	Priority    TaskPriority
+	Assignees   []string
+	Tags        []string
+	DueAt       *time.Time
+	// Estimate is the size of the task in story points or hours. Remaining
+	// defaults to the estimate.
+	Estimate  *float64
+	Remaining *float64
+	// RRule makes the task recurring (RFC 5545, e.g. FREQ=WEEKLY;BYDAY=MO).
+	// The first occurrence is due at DueAt, or now when DueAt is empty.
+	RRule string
+	// ParentID makes the task a subtask of another task of the same project.
+	ParentID string
 }
 
 // @Description Request body for updating a task (all fields optional)
@@ -55,4 +96,63 @@ type UpdateTaskRequest struct {
 	Description *string
 	Status      *TaskStatus
 	Priority    *TaskPriority
+	Assignees   *[]string
+	Tags        *[]string
+	DueAt       *time.Time
+	Estimate    *float64
+	Remaining   *float64
+	// Comment is added to the task's thread; some workflow transitions require it.
+	Comment *string
+}
+
+// PatchFormat is the media type of a patch document for a task.
+type PatchFormat string
+
+const (
+	// PatchFormatMergePatch is a JSON Merge Patch (RFC 7396); null clears a field.
+	PatchFormatMergePatch PatchFormat = "application/merge-patch+json"
+	// PatchFormatJSONPatch is a JSON Patch (RFC 6902), including test operations.
+	PatchFormatJSONPatch PatchFormat = "application/json-patch+json"
+)
+
+// SeriesScope selects whether a change to a recurring task applies to one
+// occurrence or to the whole series.
+type SeriesScope string
+
+const (
+	SeriesScopeOccurrence SeriesScope = "occurrence"
+	SeriesScopeSeries     SeriesScope = "series"
+)
+
+func (s SeriesScope) IsValid() bool {
+	return s == SeriesScopeOccurrence || s == SeriesScopeSeries || s == ""
+}
+
+// @Description Recurrence series that spawns the occurrences of a repeating task
+type TaskSeries struct {
+	ID          uuid.UUID
+	ProjectID   uuid.UUID
+	RRule       string
+	Title       string
+	Description string
+	Priority    TaskPriority
+	Occurrences int64
+	NextAt      *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// TaskFilter narrows down task listings; zero-value fields are ignored.
+type TaskFilter struct {
+	Assignee  string
+	ProjectID string
+	Tag       string
+	// Expression is a filter expression such as
+	// `priority = high and status != done`; see the README for the syntax.
+	Expression string
+}
+
+// IsZero reports whether the filter matches every task.
+func (f TaskFilter) IsZero() bool {
+	return f == TaskFilter{}
 }
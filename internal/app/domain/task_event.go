@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TaskEventAction string
+
+const (
+	// TaskEventBaseline records the state of a task that existed before
+	// history was kept.
+	TaskEventBaseline TaskEventAction = "baseline"
+	TaskEventCreated  TaskEventAction = "created"
+	TaskEventUpdated  TaskEventAction = "updated"
+	TaskEventDeleted  TaskEventAction = "deleted"
+	TaskEventRestored TaskEventAction = "restored"
+	// TaskEventPurged records the permanent removal of a task.
+	TaskEventPurged TaskEventAction = "purged"
+)
+
+// @Description Change of a single task field; values are JSON, null when unset
+type FieldChange struct {
+	Field  string
+	Before json.RawMessage `swaggertype:"object"`
+	After  json.RawMessage `swaggertype:"object"`
+}
+
+// @Description Entry in the change history of a task
+type TaskEvent struct {
+	ID        uuid.UUID
+	TaskID    uuid.UUID
+	Action    TaskEventAction
+	Actor     string
+	RequestID string
+	Changes   []FieldChange
+	CreatedAt time.Time
+}
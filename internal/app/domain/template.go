@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// @Description Task of a template. Title, Description, Assignees and Tags may hold {{variable}} placeholders, filled in when the template is instantiated.
+type TemplateTask struct {
+	Title       string
+	Description string
+	// Status and Priority default as they do for new tasks.
+	Status    TaskStatus
+	Priority  TaskPriority
+	Assignees []string
+	Tags      []string
+	// DueOffset sets the due date relative to the start of the
+	// instantiation, as whole days ("3d") or a duration ("36h"). Empty
+	// leaves the task without a due date.
+	DueOffset string
+	Estimate  *float64
+	// Subtasks are created as subtasks of this task.
+	Subtasks []TemplateTask
+}
+
+// @Description Reusable set of tasks and subtasks
+type Template struct {
+	ID          uuid.UUID
+	Name        string
+	Description string
+	Tasks       []TemplateTask
+	// Variables are the names of the placeholders in the tasks, sorted. Every
+	// one of them needs a value to instantiate the template.
+	Variables []string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// @Description Request body for creating a template
+type CreateTemplateRequest struct {
+	Name        string
+	Description string
+	Tasks       []TemplateTask
+}
+
+// @Description Request body for updating a template (all fields optional; Tasks are replaced as a whole)
+type UpdateTemplateRequest struct {
+	Name        *string
+	Description *string
+	Tasks       *[]TemplateTask
+}
+
+// @Description Request body for creating the tasks of a template
+type InstantiateTemplateRequest struct {
+	// ProjectID defaults to the default project when empty.
+	ProjectID string
+	// Variables hold the value of every placeholder, e.g.
+	// {"partner_name": "Acme"} for {{partner_name}}.
+	Variables map[string]string
+	// StartAt is the time due offsets count from; it defaults to now.
+	StartAt *time.Time
+}
+
+// @Description Tasks created from a template
+type TemplateInstance struct {
+	TemplateID uuid.UUID
+	// TaskIDs are the created tasks in template order, each followed by its
+	// subtasks.
+	TaskIDs []uuid.UUID
+}
@@ -0,0 +1,77 @@
+package domain
+
+// ExportFormat is a file format tasks can be exported to.
+type ExportFormat string
+
+const (
+	ExportFormatCSV      ExportFormat = "csv"
+	ExportFormatJSONL    ExportFormat = "jsonl"
+	ExportFormatMarkdown ExportFormat = "md"
+	// ExportFormatICS renders tasks as iCalendar to-dos (VTODO).
+	ExportFormatICS ExportFormat = "ics"
+)
+
+func (f ExportFormat) IsValid() bool {
+	return f == ExportFormatCSV || f == ExportFormatJSONL || f == ExportFormatMarkdown || f == ExportFormatICS
+}
+
+// ImportFormat is a file format tasks can be imported from.
+type ImportFormat string
+
+const (
+	ImportFormatCSV   ImportFormat = "csv"
+	ImportFormatJSONL ImportFormat = "jsonl"
+	// ImportFormatICS reads the to-dos (VTODO) of an iCalendar file.
+	ImportFormatICS ImportFormat = "ics"
+)
+
+func (f ImportFormat) IsValid() bool {
+	return f == ImportFormatCSV || f == ImportFormatJSONL || f == ImportFormatICS
+}
+
+// ImportFields are the task fields an import reads, in the order they are
+// exported. Assignees and Tags are lists separated by semicolons, DueAt is an
+// RFC 3339 time or a date.
+var ImportFields = []string{
+	"ExternalID", "ProjectID", "Title", "Description", "Status", "Priority", "Assignees", "Tags", "DueAt",
+}
+
+// @Description Options of a task import
+type ImportRequest struct {
+	Format ImportFormat
+	// Mapping names the column (CSV) or member (JSON Lines) each task field is
+	// read from. Unmapped fields are read from the column named like the field.
+	// iCalendar files have fixed properties and take no mapping.
+	Mapping map[string]string
+	// DryRun validates every row without saving anything.
+	DryRun bool
+}
+
+type ImportAction string
+
+const (
+	ImportActionCreated ImportAction = "created"
+	ImportActionUpdated ImportAction = "updated"
+	ImportActionFailed  ImportAction = "failed"
+)
+
+// @Description Outcome of one row of an import
+type ImportRowResult struct {
+	// Line is the line of the row in the file, starting at 1.
+	Line       int
+	ExternalID string
+	// ID of the created or updated task; empty when the row failed.
+	ID     string
+	Action ImportAction
+	Error  string
+}
+
+// @Description Outcome of an import, with one result per row
+type ImportResult struct {
+	// DryRun is true when nothing was saved.
+	DryRun  bool
+	Created int
+	Updated int
+	Failed  int
+	Rows    []ImportRowResult
+}
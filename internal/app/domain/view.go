@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskSortField is a task field that task listings can be sorted by.
+type TaskSortField string
+
+const (
+	TaskSortCreatedAt TaskSortField = "CreatedAt"
+	TaskSortUpdatedAt TaskSortField = "UpdatedAt"
+	TaskSortDueAt     TaskSortField = "DueAt"
+	TaskSortPriority  TaskSortField = "Priority"
+	TaskSortStatus    TaskSortField = "Status"
+	TaskSortTitle     TaskSortField = "Title"
+	TaskSortRank      TaskSortField = "Rank"
+)
+
+func (f TaskSortField) IsValid() bool {
+	switch f {
+	case TaskSortCreatedAt, TaskSortUpdatedAt, TaskSortDueAt, TaskSortPriority, TaskSortStatus, TaskSortTitle, TaskSortRank:
+		return true
+	}
+	return false
+}
+
+// @Description Sort key of a task listing. Tasks without a due date sort after those with one; priorities sort low to high.
+type TaskSort struct {
+	Field      TaskSortField
+	Descending bool
+}
+
+// DefaultTaskSort is board order: by status, then by rank.
+var DefaultTaskSort = []TaskSort{{Field: TaskSortStatus}, {Field: TaskSortRank}}
+
+// ViewColumns are the task fields a view can select.
+var ViewColumns = []string{
+	"ID", "Key", "ProjectID", "Title", "Description", "Status", "Priority", "Assignees", "Tags", "DueAt",
+	"SeriesID", "CreatedAt", "UpdatedAt", "Version", "ExternalID", "Estimate", "Remaining", "Rank",
+	"ParentID", "TemplateID",
+}
+
+// @Description Tasks a view selects. Empty fields match every task; Statuses and Priorities match any of their values, Tags only tasks carrying all of them.
+type ViewFilter struct {
+	ProjectID string
+	// Assignee may be "me", the client running the view.
+	Assignee   string
+	Tags       []string
+	Statuses   []TaskStatus
+	Priorities []TaskPriority
+	// DueFrom is inclusive and DueBefore exclusive.
+	DueFrom   *time.Time
+	DueBefore *time.Time
+	// Search matches text in the title or description, ignoring case.
+	Search string
+}
+
+// @Description Saved task filter with an optional sort order and column selection
+type View struct {
+	ID    uuid.UUID
+	Owner string
+	Name  string
+	// Shared views can be listed and run by every client; only the owner can
+	// change them.
+	Shared bool
+	Filter ViewFilter
+	// Sort defaults to board order: Status, then Rank.
+	Sort []TaskSort
+	// Columns are the task fields returned; empty returns every field.
+	Columns   []string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// @Description Request body for saving a view
+type CreateViewRequest struct {
+	Name    string
+	Shared  bool
+	Filter  ViewFilter
+	Sort    []TaskSort
+	Columns []string
+}
+
+// @Description Request body for updating a view (all fields optional; Filter, Sort and Columns are replaced as a whole)
+type UpdateViewRequest struct {
+	Name    *string
+	Shared  *bool
+	Filter  *ViewFilter
+	Sort    *[]TaskSort
+	Columns *[]string
+}
+
+// @Description Page of the tasks a view selects
+type ViewPage struct {
+	// Tasks hold the selected columns of each task.
+	Tasks []map[string]any
+	// NextCursor fetches the next page and is empty on the last one. A cursor
+	// keeps the definition the first page ran with, so editing the view does
+	// not change pages already handed out.
+	NextCursor string
+}
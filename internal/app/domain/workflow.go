@@ -0,0 +1,192 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type WorkflowGuard string
+
+const (
+	// WorkflowGuardCommentRequired makes a transition require an explanatory comment.
+	WorkflowGuardCommentRequired WorkflowGuard = "comment_required"
+)
+
+func (g WorkflowGuard) IsValid() bool {
+	return g == WorkflowGuardCommentRequired
+}
+
+// @Description Allowed move between two statuses
+type WorkflowTransition struct {
+	From   TaskStatus
+	To     TaskStatus
+	Guards []WorkflowGuard
+}
+
+// @Description Statuses a task may have and the transitions allowed between them
+type Workflow struct {
+	InitialStatus TaskStatus
+	Statuses      []TaskStatus
+	// DoneStatuses are the statuses of finished tasks: they no longer block
+	// other tasks, count as completed and let a series move on.
+	DoneStatuses []TaskStatus
+	// StartedStatuses are the statuses a task can only enter once every task
+	// blocking it is done.
+	StartedStatuses []TaskStatus
+	Transitions     []WorkflowTransition
+}
+
+// DefaultWorkflow allows any move between the built-in statuses, but reopening
+// a finished task requires a comment.
+func DefaultWorkflow() Workflow {
+	return Workflow{
+		InitialStatus:   TaskStatusToDo,
+		Statuses:        []TaskStatus{TaskStatusToDo, TaskStatusInProgress, TaskStatusDone},
+		DoneStatuses:    []TaskStatus{TaskStatusDone},
+		StartedStatuses: []TaskStatus{TaskStatusInProgress},
+		Transitions: []WorkflowTransition{
+			{From: TaskStatusToDo, To: TaskStatusInProgress},
+			{From: TaskStatusToDo, To: TaskStatusDone},
+			{From: TaskStatusInProgress, To: TaskStatusToDo},
+			{From: TaskStatusInProgress, To: TaskStatusDone},
+			{From: TaskStatusDone, To: TaskStatusToDo, Guards: []WorkflowGuard{WorkflowGuardCommentRequired}},
+			{From: TaskStatusDone, To: TaskStatusInProgress, Guards: []WorkflowGuard{WorkflowGuardCommentRequired}},
+		},
+	}
+}
+
+// ParseWorkflow decodes and validates a JSON workflow definition.
+func ParseWorkflow(data []byte) (Workflow, error) {
+	var workflow Workflow
+	if err := json.Unmarshal(data, &workflow); err != nil {
+		return Workflow{}, fmt.Errorf("workflow: %w", err)
+	}
+
+	if err := workflow.Validate(); err != nil {
+		return Workflow{}, err
+	}
+
+	return workflow, nil
+}
+
+func (w Workflow) Validate() error {
+	if len(w.Statuses) == 0 {
+		return fmt.Errorf("workflow: at least one status is required")
+	}
+
+	seen := make(map[TaskStatus]bool, len(w.Statuses))
+	for _, status := range w.Statuses {
+		if status == "" {
+			return fmt.Errorf("workflow: status must not be empty")
+		}
+		if seen[status] {
+			return fmt.Errorf("workflow: duplicate status %q", status)
+		}
+		seen[status] = true
+	}
+
+	if !seen[w.InitialStatus] {
+		return fmt.Errorf("workflow: initial status %q is not a workflow status", w.InitialStatus)
+	}
+
+	if len(w.DoneStatuses) == 0 {
+		return fmt.Errorf("workflow: at least one done status is required")
+	}
+
+	done := make(map[TaskStatus]bool, len(w.DoneStatuses))
+	for _, status := range w.DoneStatuses {
+		if !seen[status] {
+			return fmt.Errorf("workflow: done status %q is not a workflow status", status)
+		}
+		done[status] = true
+	}
+
+	for _, status := range w.StartedStatuses {
+		if !seen[status] {
+			return fmt.Errorf("workflow: started status %q is not a workflow status", status)
+		}
+		if done[status] {
+			return fmt.Errorf("workflow: status %q cannot be both started and done", status)
+		}
+	}
+
+	for _, transition := range w.Transitions {
+		if !seen[transition.From] || !seen[transition.To] {
+			return fmt.Errorf("workflow: transition %s -> %s uses an unknown status", transition.From, transition.To)
+		}
+		for _, guard := range transition.Guards {
+			if !guard.IsValid() {
+				return fmt.Errorf("workflow: unknown guard %q", guard)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (w Workflow) HasStatus(status TaskStatus) bool {
+	for _, s := range w.Statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDone reports whether a task in the given status is finished.
+func (w Workflow) IsDone(status TaskStatus) bool {
+	return slices.Contains(w.DoneStatuses, status)
+}
+
+// IsStarted reports whether a task in the given status is being worked on,
+// which its blockers must allow.
+func (w Workflow) IsStarted(status TaskStatus) bool {
+	return slices.Contains(w.StartedStatuses, status)
+}
+
+func (w Workflow) Transition(from TaskStatus, to TaskStatus) (WorkflowTransition, bool) {
+	for _, transition := range w.Transitions {
+		if transition.From == from && transition.To == to {
+			return transition, true
+		}
+	}
+	return WorkflowTransition{}, false
+}
+
+// AllowedFrom lists the statuses a task in the given status may move to.
+func (w Workflow) AllowedFrom(from TaskStatus) []TaskStatus {
+	allowed := []TaskStatus{}
+	for _, transition := range w.Transitions {
+		if transition.From == from {
+			allowed = append(allowed, transition.To)
+		}
+	}
+	return allowed
+}
+
+// TransitionError reports a status change the workflow does not permit.
+type TransitionError struct {
+	From    TaskStatus
+	To      TaskStatus
+	Allowed []TaskStatus
+	Reason  string
+}
+
+func (e *TransitionError) Error() string {
+	allowed := make([]string, len(e.Allowed))
+	for i, status := range e.Allowed {
+		allowed[i] = string(status)
+	}
+
+	if len(allowed) == 0 {
+		allowed = append(allowed, "none")
+	}
+
+	return fmt.Sprintf("transition from %s to %s is not allowed: %s (allowed: %s)", e.From, e.To, e.Reason, strings.Join(allowed, ", "))
+}
+
+func (e *TransitionError) Unwrap() error {
+	return ErrConflict
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// @Description Time a client spent on a task. Running timers have no EndedAt and no Duration yet.
+type Worklog struct {
+	ID        uuid.UUID
+	TaskID    uuid.UUID
+	Author    string
+	StartedAt time.Time
+	EndedAt   *time.Time
+	// Duration is the time worked, e.g. "1h30m0s"; Seconds is the same in
+	// whole seconds.
+	Duration  string
+	Seconds   int64
+	Note      string
+	CreatedAt time.Time
+}
+
+// IsRunning reports whether the worklog is a timer that was not stopped yet.
+func (w Worklog) IsRunning() bool {
+	return w.EndedAt == nil
+}
+
+// @Description Request body for logging time; give StartedAt and either EndedAt or Duration
+type CreateWorklogRequest struct {
+	StartedAt *time.Time
+	EndedAt   *time.Time
+	// Duration is a duration such as "1h30m".
+	Duration string
+	Note     string
+}
+
+// @Description Request body for starting or stopping a timer
+type TimerRequest struct {
+	// Note describes the work. A note given when stopping replaces the one
+	// given when starting.
+	Note string
+}
+
+// WorklogGroup is the dimension a time report is broken down by.
+type WorklogGroup string
+
+const (
+	WorklogGroupTask     WorklogGroup = "task"
+	WorklogGroupAssignee WorklogGroup = "assignee"
+	WorklogGroupDate     WorklogGroup = "date"
+)
+
+func (g WorklogGroup) IsValid() bool {
+	return g == WorklogGroupTask || g == WorklogGroupAssignee || g == WorklogGroupDate
+}
+
+// WorklogFilter selects the worklogs a time report adds up. Empty fields
+// match every worklog; From is inclusive and To exclusive.
+type WorklogFilter struct {
+	TaskID   string
+	Assignee string
+	From     *time.Time
+	To       *time.Time
+	GroupBy  WorklogGroup
+}
+
+// @Description Time logged for one task, assignee or date (YYYY-MM-DD, UTC)
+type WorklogTotal struct {
+	Key      string
+	Duration string
+	Seconds  int64
+	Entries  int64
+}
+
+// @Description Time logged in a period, in total and per group. Running timers are not counted.
+type WorklogReport struct {
+	GroupBy  WorklogGroup
+	From     *time.Time
+	To       *time.Time
+	Duration string
+	Seconds  int64
+	Entries  int64
+	Groups   []WorklogTotal
+}
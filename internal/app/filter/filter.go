@@ -0,0 +1,760 @@
+// Package filter parses the filter expressions tasks are listed with, such as
+//
+//	(priority = high and status != done or tag = urgent) and updated > now-7d
+//
+// into a syntax tree, checked against a Schema of the fields that can be
+// filtered on. Compiling the tree into a query is left to the storage layer.
+//
+// The grammar, with keywords matched regardless of case:
+//
+//	expr       = and { "or" and }
+//	and        = not { "and" not }
+//	not        = "not" not | "(" expr ")" | comparison
+//	comparison = field ( op value | [ "not" ] "in" "(" value { "," value } ")" | "contains" value )
+//	op         = "=" | "!=" | "<" | "<=" | ">" | ">="
+//	value      = string | number | word | "null" | time
+//	time       = ( "now" | "today" ) [ ( "+" | "-" ) duration ]
+//
+// Strings are quoted with ' or " and may escape the quote or a backslash
+// with a backslash; a word is an unquoted string of letters, digits and
+// underscores that is not a keyword. Durations are a whole number of minutes (m), hours (h), days
+// (d) or weeks (w), e.g. 7d. "today" is the start of the current day in UTC.
+// Times can also be given as strings holding an RFC 3339 timestamp or a
+// YYYY-MM-DD date.
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxLength bounds the length of an expression, in characters.
+	MaxLength = 2000
+
+	maxDepth  = 32
+	maxValues = 100
+)
+
+// ErrInvalidFilter is returned for expressions that cannot be parsed or do
+// not fit the schema. The error is an *Error giving the position.
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// Error describes what is wrong with an expression and where.
+type Error struct {
+	// Pos is the position of the offending character, counting from 1. It
+	// is one past the last character when the expression ends too early.
+	Pos     int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Message, e.Pos)
+}
+
+func (e *Error) Unwrap() error {
+	return ErrInvalidFilter
+}
+
+func errorf(pos int, format string, args ...any) error {
+	return &Error{Pos: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+// Type is the kind of values a field holds, which decides the operators it
+// can be compared with.
+type Type int
+
+const (
+	// TypeText fields support =, !=, in and contains (a substring match
+	// ignoring case).
+	TypeText Type = iota
+	// TypeEnum fields hold one of a fixed list of values and support =, !=
+	// and in; ordered ones also support <, <=, > and >=.
+	TypeEnum
+	// TypeID fields reference another resource and support =, != and in.
+	TypeID
+	// TypeNumber fields support every comparison and in.
+	TypeNumber
+	// TypeTime fields support <, <=, > and >=, and = and != with null.
+	TypeTime
+	// TypeSet fields hold several values. = and contains match sets holding
+	// the value, in sets holding any of the values; = null matches empty sets.
+	TypeSet
+)
+
+// Field describes a field expressions can filter on.
+type Field struct {
+	Type Type
+	// Values are the values of an enum field, in order.
+	Values  []string
+	Ordered bool
+	// Nullable fields can be compared with null, which stands for an empty
+	// value.
+	Nullable bool
+	// Fold lower-cases the values a field is compared with.
+	Fold bool
+	// Aliases replace values, e.g. "me" with the client listing tasks.
+	Aliases map[string]string
+}
+
+// Schema holds the fields expressions can filter on, by name.
+type Schema map[string]Field
+
+// Op is a comparison operator.
+type Op string
+
+const (
+	OpEq       Op = "="
+	OpNe       Op = "!="
+	OpLt       Op = "<"
+	OpLe       Op = "<="
+	OpGt       Op = ">"
+	OpGe       Op = ">="
+	OpIn       Op = "in"
+	OpNotIn    Op = "not in"
+	OpContains Op = "contains"
+)
+
+// Expr is a node of the syntax tree: an *And, *Or, *Not or *Comparison.
+type Expr interface {
+	// Pos is the position the expression starts at.
+	Pos() int
+	String() string
+}
+
+// And matches tasks both sides match.
+type And struct {
+	Left, Right Expr
+}
+
+func (e *And) Pos() int       { return e.Left.Pos() }
+func (e *And) String() string { return "(" + e.Left.String() + " and " + e.Right.String() + ")" }
+
+// Or matches tasks either side matches.
+type Or struct {
+	Left, Right Expr
+}
+
+func (e *Or) Pos() int       { return e.Left.Pos() }
+func (e *Or) String() string { return "(" + e.Left.String() + " or " + e.Right.String() + ")" }
+
+// Not matches tasks the expression does not match.
+type Not struct {
+	At   int
+	Expr Expr
+}
+
+func (e *Not) Pos() int       { return e.At }
+func (e *Not) String() string { return "not " + e.Expr.String() }
+
+// Comparison compares a field with one value, or with a list of values for
+// in and not in. Comparisons of ordered enum fields with <, <=, > and >= are
+// turned into in and not in when they are parsed.
+type Comparison struct {
+	At     int
+	Field  string
+	Op     Op
+	Values []Value
+}
+
+func (e *Comparison) Pos() int { return e.At }
+
+func (e *Comparison) String() string {
+	values := make([]string, len(e.Values))
+	for i, value := range e.Values {
+		values[i] = value.String()
+	}
+
+	if e.Op == OpIn || e.Op == OpNotIn {
+		return e.Field + " " + string(e.Op) + " (" + strings.Join(values, ", ") + ")"
+	}
+	return e.Field + " " + string(e.Op) + " " + values[0]
+}
+
+// ValueKind is the kind of a value in an expression.
+type ValueKind int
+
+const (
+	KindString ValueKind = iota
+	KindNumber
+	KindTime
+	KindNull
+)
+
+// Value is a value a field is compared with.
+type Value struct {
+	Pos    int
+	Kind   ValueKind
+	Text   string
+	Number float64
+	// Time is an absolute time, used when Base is empty.
+	Time time.Time
+	// Base is "now" or "today" for times relative to when the expression is
+	// run, moved by Offset.
+	Base   string
+	Offset Offset
+}
+
+// Offset moves a relative time by calendar days and by a duration.
+type Offset struct {
+	Days     int
+	Duration time.Duration
+}
+
+// TimeAt returns the time a value stands for when the expression runs at now.
+func (v Value) TimeAt(now time.Time) time.Time {
+	var t time.Time
+	switch v.Base {
+	case "now":
+		t = now.UTC()
+	case "today":
+		t = now.UTC().Truncate(24 * time.Hour)
+	default:
+		return v.Time
+	}
+	return t.AddDate(0, 0, v.Offset.Days).Add(v.Offset.Duration)
+}
+
+func (v Value) String() string {
+	switch v.Kind {
+	case KindNumber:
+		return strconv.FormatFloat(v.Number, 'f', -1, 64)
+	case KindNull:
+		return "null"
+	case KindTime:
+		if v.Base == "" {
+			return strconv.Quote(v.Time.Format(time.RFC3339))
+		}
+		return v.Base + formatOffset(v.Offset)
+	}
+	return strconv.Quote(v.Text)
+}
+
+func formatOffset(offset Offset) string {
+	switch {
+	case offset.Days != 0:
+		return fmt.Sprintf("%+dd", offset.Days)
+	case offset.Duration%time.Hour == 0 && offset.Duration != 0:
+		return fmt.Sprintf("%+dh", offset.Duration/time.Hour)
+	case offset.Duration != 0:
+		return fmt.Sprintf("%+dm", offset.Duration/time.Minute)
+	}
+	return ""
+}
+
+// Parse parses an expression and checks it against the schema.
+func Parse(input string, schema Schema) (Expr, error) {
+	if n := utf8.RuneCountInString(input); n > MaxLength {
+		return nil, errorf(MaxLength+1, "filter is longer than %d characters", MaxLength)
+	}
+
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens, schema: schema}
+	if p.peek().kind == tokenEOF {
+		return nil, errorf(p.peek().pos, "filter is empty")
+	}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if next := p.peek(); next.kind != tokenEOF {
+		return nil, errorf(next.pos, "unexpected %s, expected \"and\" or \"or\"", next)
+	}
+
+	return expr, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenNumber
+	tokenDuration
+	tokenOperator
+	tokenLeftParen
+	tokenRightParen
+	tokenComma
+	tokenPlus
+	tokenMinus
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of filter"
+	}
+	return strconv.Quote(t.text)
+}
+
+// is reports whether the token is the given keyword.
+func (t token) is(keyword string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+var keywords = []string{"and", "or", "not", "in", "contains", "null", "now", "today"}
+
+func isKeyword(word string) bool {
+	return slices.Contains(keywords, strings.ToLower(word))
+}
+
+func lex(input string) ([]token, error) {
+	runes := []rune(input)
+	tokens := []token{}
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '(' || r == ')' || r == ',' || r == '+' || r == '-':
+			kinds := map[rune]tokenKind{'(': tokenLeftParen, ')': tokenRightParen, ',': tokenComma, '+': tokenPlus, '-': tokenMinus}
+			tokens = append(tokens, token{kind: kinds[r], text: string(r), pos: pos})
+			i++
+
+		case r == '=' || r == '!' || r == '<' || r == '>':
+			op := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				op += "="
+			}
+			if op == "!" {
+				return nil, errorf(pos, "unexpected \"!\", expected \"!=\"")
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: op, pos: pos})
+			i += len(op)
+
+		case r == '\'' || r == '"':
+			var text strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != r; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) && (runes[j+1] == r || runes[j+1] == '\\') {
+					j++
+				}
+				text.WriteRune(runes[j])
+			}
+			if j == len(runes) {
+				return nil, errorf(pos, "unterminated string")
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text.String(), pos: pos})
+			i = j + 1
+
+		case unicode.IsDigit(r):
+			j := i
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			kind := tokenNumber
+			for j < len(runes) && isWordRune(runes[j]) {
+				kind = tokenDuration
+				j++
+			}
+			tokens = append(tokens, token{kind: kind, text: string(runes[i:j]), pos: pos})
+			i = j
+
+		case isWordRune(r):
+			j := i
+			for j < len(runes) && isWordRune(runes[j]) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: string(runes[i:j]), pos: pos})
+			i = j
+
+		default:
+			return nil, errorf(pos, "unexpected %q", r)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(runes) + 1}), nil
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+type parser struct {
+	tokens []token
+	next   int
+	schema Schema
+	depth  int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *parser) advance() token {
+	t := p.tokens[p.next]
+	if t.kind != tokenEOF {
+		p.next++
+	}
+	return t
+}
+
+// nest guards against expressions nested too deeply to compile.
+func (p *parser) nest(pos int) error {
+	p.depth++
+	if p.depth > maxDepth {
+		return errorf(pos, "filter is nested more than %d levels deep", maxDepth)
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().is("or") {
+		p.advance()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Or{Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().is("and") {
+		p.advance()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &And{Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseNot() (Expr, error) {
+	t := p.peek()
+
+	switch {
+	case t.is("not"):
+		p.advance()
+		if err := p.nest(t.pos); err != nil {
+			return nil, err
+		}
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		p.depth--
+		return &Not{At: t.pos, Expr: expr}, nil
+
+	case t.kind == tokenLeftParen:
+		p.advance()
+		if err := p.nest(t.pos); err != nil {
+			return nil, err
+		}
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.advance(); closing.kind != tokenRightParen {
+			return nil, errorf(closing.pos, "unexpected %s, expected \")\"", closing)
+		}
+		p.depth--
+		return expr, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Expr, error) {
+	name := p.advance()
+	if name.kind != tokenWord || isKeyword(name.text) {
+		return nil, errorf(name.pos, "unexpected %s, expected a field", name)
+	}
+
+	field, ok := p.schema[strings.ToLower(name.text)]
+	if !ok {
+		return nil, errorf(name.pos, "unknown field %q, use %s", name.text, p.fieldNames())
+	}
+
+	comparison := &Comparison{At: name.pos, Field: strings.ToLower(name.text)}
+	opToken := p.advance()
+
+	switch {
+	case opToken.kind == tokenOperator:
+		comparison.Op = Op(opToken.text)
+	case opToken.is("contains"):
+		comparison.Op = OpContains
+	case opToken.is("in"):
+		comparison.Op = OpIn
+	case opToken.is("not") && p.peek().is("in"):
+		p.advance()
+		comparison.Op = OpNotIn
+	default:
+		return nil, errorf(opToken.pos, "unexpected %s, expected an operator after %q", opToken, name.text)
+	}
+
+	if comparison.Op == OpIn || comparison.Op == OpNotIn {
+		values, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		comparison.Values = values
+	} else {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		comparison.Values = []Value{value}
+	}
+
+	if err := check(comparison, field, opToken.pos); err != nil {
+		return nil, err
+	}
+
+	return comparison, nil
+}
+
+func (p *parser) parseList() ([]Value, error) {
+	if open := p.advance(); open.kind != tokenLeftParen {
+		return nil, errorf(open.pos, "unexpected %s, expected \"(\"", open)
+	}
+
+	values := []Value{}
+	for {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		if len(values) == maxValues {
+			return nil, errorf(value.Pos, "list has more than %d values", maxValues)
+		}
+		values = append(values, value)
+
+		switch t := p.advance(); t.kind {
+		case tokenComma:
+		case tokenRightParen:
+			return values, nil
+		default:
+			return nil, errorf(t.pos, "unexpected %s, expected \",\" or \")\"", t)
+		}
+	}
+}
+
+func (p *parser) parseValue() (Value, error) {
+	t := p.advance()
+
+	switch {
+	case t.kind == tokenString:
+		return Value{Pos: t.pos, Kind: KindString, Text: t.text}, nil
+
+	case t.kind == tokenNumber:
+		return parseNumber(t, t.pos, false)
+
+	case t.kind == tokenMinus && p.peek().kind == tokenNumber:
+		return parseNumber(p.advance(), t.pos, true)
+
+	case t.is("null"):
+		return Value{Pos: t.pos, Kind: KindNull}, nil
+
+	case t.is("now") || t.is("today"):
+		value := Value{Pos: t.pos, Kind: KindTime, Base: strings.ToLower(t.text)}
+		if sign := p.peek(); sign.kind == tokenPlus || sign.kind == tokenMinus {
+			p.advance()
+			offset, err := parseOffset(p.advance(), sign.kind == tokenMinus)
+			if err != nil {
+				return Value{}, err
+			}
+			value.Offset = offset
+		}
+		return value, nil
+
+	case t.kind == tokenWord && !isKeyword(t.text), t.kind == tokenDuration:
+		return Value{Pos: t.pos, Kind: KindString, Text: t.text}, nil
+	}
+
+	return Value{}, errorf(t.pos, "unexpected %s, expected a value", t)
+}
+
+func parseNumber(t token, pos int, negative bool) (Value, error) {
+	number, err := strconv.ParseFloat(t.text, 64)
+	if err != nil {
+		return Value{}, errorf(t.pos, "invalid number %q", t.text)
+	}
+	if negative {
+		number = -number
+	}
+	return Value{Pos: pos, Kind: KindNumber, Number: number, Text: t.text}, nil
+}
+
+func parseOffset(t token, negative bool) (Offset, error) {
+	invalid := errorf(t.pos, "unexpected %s, expected a duration such as 7d, 12h or 30m", t)
+	if t.kind != tokenDuration {
+		return Offset{}, invalid
+	}
+
+	n, err := strconv.Atoi(t.text[:len(t.text)-1])
+	if err != nil || n > 100000 {
+		return Offset{}, invalid
+	}
+	if negative {
+		n = -n
+	}
+
+	switch t.text[len(t.text)-1] {
+	case 'm':
+		return Offset{Duration: time.Duration(n) * time.Minute}, nil
+	case 'h':
+		return Offset{Duration: time.Duration(n) * time.Hour}, nil
+	case 'd':
+		return Offset{Days: n}, nil
+	case 'w':
+		return Offset{Days: 7 * n}, nil
+	}
+	return Offset{}, invalid
+}
+
+func (p *parser) fieldNames() string {
+	names := make([]string, 0, len(p.schema))
+	for name := range p.schema {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return strings.Join(names, ", ")
+}
+
+// operators are the operators each type of field supports, besides = and !=
+// with null on nullable fields.
+var operators = map[Type][]Op{
+	TypeText:   {OpEq, OpNe, OpIn, OpNotIn, OpContains},
+	TypeEnum:   {OpEq, OpNe, OpIn, OpNotIn},
+	TypeID:     {OpEq, OpNe, OpIn, OpNotIn},
+	TypeNumber: {OpEq, OpNe, OpLt, OpLe, OpGt, OpGe, OpIn, OpNotIn},
+	TypeTime:   {OpLt, OpLe, OpGt, OpGe},
+	TypeSet:    {OpEq, OpNe, OpIn, OpNotIn, OpContains},
+}
+
+var orderOperators = []Op{OpLt, OpLe, OpGt, OpGe}
+
+// check validates a comparison against its field and normalizes its values.
+func check(c *Comparison, field Field, opPos int) error {
+	isNull := len(c.Values) == 1 && c.Values[0].Kind == KindNull
+	if isNull {
+		if !field.Nullable {
+			return errorf(c.Values[0].Pos, "%s cannot be null", c.Field)
+		}
+		if c.Op != OpEq && c.Op != OpNe {
+			return errorf(opPos, "null can only be compared with = or !=")
+		}
+		return nil
+	}
+
+	allowed := operators[field.Type]
+	if field.Type == TypeEnum && field.Ordered {
+		allowed = append(slices.Clone(allowed), orderOperators...)
+	}
+	if !slices.Contains(allowed, c.Op) {
+		return errorf(opPos, "%s does not support %q", c.Field, c.Op)
+	}
+
+	for i := range c.Values {
+		value, err := checkValue(c.Values[i], c.Field, field)
+		if err != nil {
+			return err
+		}
+		c.Values[i] = value
+	}
+
+	if field.Type == TypeEnum && slices.Contains(orderOperators, c.Op) {
+		c.Op, c.Values = orderedSet(c.Op, c.Values[0], field.Values)
+	}
+
+	return nil
+}
+
+func checkValue(value Value, name string, field Field) (Value, error) {
+	if value.Kind == KindNull {
+		return Value{}, errorf(value.Pos, "null cannot be part of a list")
+	}
+
+	switch field.Type {
+	case TypeNumber:
+		if value.Kind != KindNumber {
+			return Value{}, errorf(value.Pos, "%s must be compared with a number", name)
+		}
+		return value, nil
+
+	case TypeTime:
+		if value.Kind == KindTime {
+			return value, nil
+		}
+		if value.Kind == KindString {
+			if t, err := time.Parse(time.RFC3339, value.Text); err == nil {
+				return Value{Pos: value.Pos, Kind: KindTime, Time: t.UTC()}, nil
+			}
+			if t, err := time.Parse(time.DateOnly, value.Text); err == nil {
+				return Value{Pos: value.Pos, Kind: KindTime, Time: t}, nil
+			}
+		}
+		return Value{}, errorf(value.Pos, "%s must be compared with a time such as now-7d, today, \"2025-03-01\" or \"2025-03-01T09:00:00Z\"", name)
+	}
+
+	if value.Kind == KindTime {
+		return Value{}, errorf(value.Pos, "%s cannot be compared with a time", name)
+	}
+
+	// Numbers stand for their text in fields of other types.
+	value.Kind = KindString
+	if field.Fold {
+		value.Text = strings.ToLower(value.Text)
+	}
+	if alias, ok := field.Aliases[value.Text]; ok {
+		value.Text = alias
+	}
+
+	if field.Type == TypeEnum && !slices.Contains(field.Values, value.Text) {
+		return Value{}, errorf(value.Pos, "invalid %s %q, use %s", name, value.Text, strings.Join(field.Values, ", "))
+	}
+
+	return value, nil
+}
+
+// orderedSet turns a comparison with an ordered enum value into the set of
+// values it matches.
+func orderedSet(op Op, value Value, values []string) (Op, []Value) {
+	index := slices.Index(values, value.Text)
+
+	matched := []Value{}
+	for i, v := range values {
+		if (op == OpLt && i < index) || (op == OpLe && i <= index) || (op == OpGt && i > index) || (op == OpGe && i >= index) {
+			matched = append(matched, Value{Pos: value.Pos, Kind: KindString, Text: v})
+		}
+	}
+
+	return OpIn, matched
+}
@@ -0,0 +1,153 @@
+package filter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testSchema = Schema{
+	"status":      {Type: TypeEnum, Values: []string{"to_do", "in_progress", "done"}, Fold: true},
+	"priority":    {Type: TypeEnum, Values: []string{"low", "medium", "high"}, Ordered: true, Fold: true},
+	"title":       {Type: TypeText},
+	"description": {Type: TypeText, Nullable: true},
+	"tag":         {Type: TypeSet, Nullable: true, Fold: true},
+	"assignee":    {Type: TypeSet, Nullable: true, Aliases: map[string]string{"me": "client-1"}},
+	"project":     {Type: TypeID},
+	"estimate":    {Type: TypeNumber, Nullable: true},
+	"due":         {Type: TypeTime, Nullable: true},
+	"updated":     {Type: TypeTime},
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input, expected string
+	}{
+		{`status = done`, `status = "done"`},
+		{`Status = DONE`, `status = "done"`},
+		{`title contains 'it\'s'`, `title contains "it's"`},
+		{`title = "say \"hi\""`, `title = "say \"hi\""`},
+		{`priority = high and status != done or tag = urgent`, `((priority = "high" and status != "done") or tag = "urgent")`},
+		{`priority = high and (status != done or tag = urgent)`, `(priority = "high" and (status != "done" or tag = "urgent"))`},
+		{`not tag = urgent and title contains x`, `(not tag = "urgent" and title contains "x")`},
+		{`priority >= medium`, `priority in ("medium", "high")`},
+		{`priority < low`, `priority in ()`},
+		{`status not in (to_do, DONE)`, `status not in ("to_do", "done")`},
+		{`assignee = me`, `assignee = "client-1"`},
+		{`tag = 2fa`, `tag = "2fa"`},
+		{`estimate >= -1.5`, `estimate >= -1.5`},
+		{`estimate = null`, `estimate = null`},
+		{`updated > now-7d`, `updated > now-7d`},
+		{`updated >= today+2w`, `updated >= today+14d`},
+		{`updated < now - 90m`, `updated < now-90m`},
+		{`due < "2025-03-01"`, `due < "2025-03-01T00:00:00Z"`},
+		{`due <= '2025-03-01T09:30:00+02:00'`, `due <= "2025-03-01T07:30:00Z"`},
+	}
+
+	for _, tt := range tests {
+		expr, err := Parse(tt.input, testSchema)
+		if err != nil {
+			t.Fatalf("Expected %q to parse, got %v", tt.input, err)
+		}
+		if expr.String() != tt.expected {
+			t.Errorf("Expected %q to parse as %s, got %s", tt.input, tt.expected, expr)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input   string
+		pos     int
+		message string
+	}{
+		{``, 1, "filter is empty"},
+		{`status`, 7, `unexpected end of filter, expected an operator after "status"`},
+		{`status =`, 9, "unexpected end of filter, expected a value"},
+		{`status = done and`, 18, "expected a field"},
+		{`state = done`, 1, `unknown field "state"`},
+		{`status = archived`, 10, `invalid status "archived"`},
+		{`status = done done`, 15, `unexpected "done", expected "and" or "or"`},
+		{`(status = done`, 15, `expected ")"`},
+		{`status in (done,`, 17, "expected a value"},
+		{`status in done`, 11, `expected "("`},
+		{`title = 'open`, 9, "unterminated string"},
+		{`title ! x`, 7, `expected "!="`},
+		{`title = x & y`, 11, `unexpected '&'`},
+		{`status > done`, 8, `status does not support ">"`},
+		{`updated = now`, 9, `updated does not support "="`},
+		{`updated > 3`, 11, "must be compared with a time"},
+		{`updated > "yesterday"`, 11, "must be compared with a time"},
+		{`updated > now-7x`, 15, "expected a duration"},
+		{`updated = null`, 11, "updated cannot be null"},
+		{`due > null`, 5, "null can only be compared with = or !="},
+		{`estimate = high`, 12, "must be compared with a number"},
+		{`title = now`, 9, "cannot be compared with a time"},
+		{`tag in (a, null)`, 12, "null cannot be part of a list"},
+		{`and = 1`, 1, "expected a field"},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(tt.input, testSchema)
+
+		var filterErr *Error
+		if !errors.As(err, &filterErr) || !errors.Is(err, ErrInvalidFilter) {
+			t.Errorf("Expected %q to be refused, got %v", tt.input, err)
+			continue
+		}
+		if filterErr.Pos != tt.pos || !strings.Contains(filterErr.Message, tt.message) {
+			t.Errorf("Expected %q to fail at %d with %q, got %v", tt.input, tt.pos, tt.message, err)
+		}
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	long := "title = '" + strings.Repeat("x", MaxLength) + "'"
+	if _, err := Parse(long, testSchema); !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("Expected a long filter to be refused, got %v", err)
+	}
+
+	deep := strings.Repeat("(", maxDepth+1) + "status = done" + strings.Repeat(")", maxDepth+1)
+	if _, err := Parse(deep, testSchema); !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("Expected a deeply nested filter to be refused, got %v", err)
+	}
+
+	nested := strings.Repeat("(", maxDepth) + "status = done" + strings.Repeat(")", maxDepth)
+	if _, err := Parse(nested, testSchema); err != nil {
+		t.Errorf("Expected %d levels to be allowed, got %v", maxDepth, err)
+	}
+
+	values := strings.TrimSuffix(strings.Repeat("x, ", maxValues+1), ", ")
+	if _, err := Parse("tag in ("+values+")", testSchema); !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("Expected a long list to be refused, got %v", err)
+	}
+}
+
+func TestValueTimeAt(t *testing.T) {
+	now := time.Date(2025, 3, 10, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		input    string
+		expected time.Time
+	}{
+		{`updated > now`, now},
+		{`updated > now-7d`, time.Date(2025, 3, 3, 15, 30, 0, 0, time.UTC)},
+		{`updated > now+90m`, time.Date(2025, 3, 10, 17, 0, 0, 0, time.UTC)},
+		{`updated > today`, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)},
+		{`updated > today-1w`, time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{`updated > "2025-01-02"`, time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		expr, err := Parse(tt.input, testSchema)
+		if err != nil {
+			t.Fatalf("Expected %q to parse, got %v", tt.input, err)
+		}
+
+		value := expr.(*Comparison).Values[0]
+		if got := value.TimeAt(now); !got.Equal(tt.expected) {
+			t.Errorf("Expected %q to compare with %v, got %v", tt.input, tt.expected, got)
+		}
+	}
+}
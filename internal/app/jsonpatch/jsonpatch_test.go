@@ -0,0 +1,118 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func assertJSON(t *testing.T, expected string, actual []byte) {
+	t.Helper()
+
+	var want, got any
+	if err := json.Unmarshal([]byte(expected), &want); err != nil {
+		t.Fatalf("Invalid expected JSON %s: %v", expected, err)
+	}
+	if err := json.Unmarshal(actual, &got); err != nil {
+		t.Fatalf("Invalid result JSON %s: %v", actual, err)
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Expected %s, got %s", expected, actual)
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	// Examples from RFC 7396, appendix A.
+	tests := []struct {
+		doc, patch, expected string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, tt := range tests {
+		result, err := MergePatch([]byte(tt.doc), []byte(tt.patch))
+		if err != nil {
+			t.Fatalf("Expected %s to apply to %s, got %v", tt.patch, tt.doc, err)
+		}
+		assertJSON(t, tt.expected, result)
+	}
+
+	if _, err := MergePatch([]byte(`{}`), []byte(`{"a":`)); !errors.Is(err, ErrInvalidPatch) {
+		t.Errorf("Expected malformed patch to be rejected, got %v", err)
+	}
+}
+
+func TestApply(t *testing.T) {
+	t.Run("operations", func(t *testing.T) {
+		// Examples from RFC 6902, appendix A.
+		tests := []struct {
+			doc, patch, expected string
+		}{
+			{`{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"baz":"qux","foo":"bar"}`},
+			{`{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+			{`{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+			{`{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+			{`{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`},
+			{`{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`,
+				`[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`,
+				`{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+			{`{"foo":["all","grass","cows","eat"]}`, `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`},
+			{`{"baz":"qux","foo":["a",2,"c"]}`,
+				`[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2}]`,
+				`{"baz":"qux","foo":["a",2,"c"]}`},
+			{`{"foo":"bar"}`, `[{"op":"add","path":"/child","value":{"grandchild":{}}}]`, `{"foo":"bar","child":{"grandchild":{}}}`},
+			{`{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":["abc","def"]}]`, `{"foo":["bar",["abc","def"]]}`},
+			{`{"/":9,"~1":10}`, `[{"op":"test","path":"/~01","value":10}]`, `{"/":9,"~1":10}`},
+			{`{"foo":null}`, `[{"op":"replace","path":"/foo","value":"x"}]`, `{"foo":"x"}`},
+			{`{"foo":["a"]}`, `[{"op":"copy","from":"/foo","path":"/bar"},{"op":"add","path":"/bar/-","value":"b"}]`, `{"foo":["a"],"bar":["a","b"]}`},
+		}
+
+		for _, tt := range tests {
+			result, err := Apply([]byte(tt.doc), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("Expected %s to apply to %s, got %v", tt.patch, tt.doc, err)
+			}
+			assertJSON(t, tt.expected, result)
+		}
+	})
+
+	t.Run("failed tests", func(t *testing.T) {
+		_, err := Apply([]byte(`{"baz":"qux"}`), []byte(`[{"op":"test","path":"/baz","value":"bar"}]`))
+		if !errors.Is(err, ErrTestFailed) {
+			t.Errorf("Expected a failed test, got %v", err)
+		}
+	})
+
+	t.Run("invalid patches", func(t *testing.T) {
+		for _, patch := range []string{
+			`{"op":"add"}`,
+			`[{"op":"add","path":"/baz/bat","value":"qux"}]`,
+			`[{"op":"remove","path":"/missing"}]`,
+			`[{"op":"add","path":"/foo/5","value":1}]`,
+			`[{"op":"add","path":"/foo/01","value":1}]`,
+			`[{"op":"add","path":"foo","value":1}]`,
+			`[{"op":"add","path":"/foo"}]`,
+			`[{"op":"move","from":"/foo","path":"/foo/0"}]`,
+			`[{"op":"frobnicate","path":"/foo"}]`,
+		} {
+			if _, err := Apply([]byte(`{"foo":["bar"]}`), []byte(patch)); !errors.Is(err, ErrInvalidPatch) {
+				t.Errorf("Expected %s to be rejected, got %v", patch, err)
+			}
+		}
+	})
+}
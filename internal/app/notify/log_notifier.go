@@ -0,0 +1,23 @@
+package notify
+
+import (
+	"context"
+	"log"
+	"strings"
+)
+
+// LogNotifier writes notifications to the application log.
+type LogNotifier struct {
+	logger *log.Logger
+}
+
+func NewLogNotifier(logger *log.Logger) *LogNotifier {
+	return &LogNotifier{
+		logger: logger,
+	}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, notification Notification) error {
+	n.logger.Printf("Reminder for %s %q (recipients: %s)", notification.TaskKey, notification.Title, strings.Join(notification.Recipients, ", "))
+	return nil
+}
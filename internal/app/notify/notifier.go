@@ -0,0 +1,22 @@
+// Package notify delivers reminder notifications through pluggable channels.
+package notify
+
+import (
+	"context"
+	"time"
+)
+
+// Notification describes a reminder that has come due.
+type Notification struct {
+	ReminderID string     `json:"reminder_id"`
+	TaskID     string     `json:"task_id"`
+	TaskKey    string     `json:"task_key"`
+	Title      string     `json:"title"`
+	DueAt      *time.Time `json:"due_at,omitempty"`
+	RemindAt   time.Time  `json:"remind_at"`
+	Recipients []string   `json:"recipients"`
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
@@ -0,0 +1,109 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+const smtpTimeout = 10 * time.Second
+
+// SMTPNotifier sends notifications as plain-text mail through a relay that
+// accepts unauthenticated submissions, such as a local MTA.
+type SMTPNotifier struct {
+	addr string
+	from string
+	to   []string
+}
+
+// NewSMTPNotifier creates a notifier that mails the given fallback recipients
+// as well as every task recipient that looks like an e-mail address.
+func NewSMTPNotifier(addr string, from string, to []string) *SMTPNotifier {
+	return &SMTPNotifier{
+		addr: addr,
+		from: from,
+		to:   to,
+	}
+}
+
+func (n *SMTPNotifier) Notify(ctx context.Context, notification Notification) error {
+	recipients := append([]string{}, n.to...)
+	for _, recipient := range notification.Recipients {
+		if strings.Contains(recipient, "@") {
+			recipients = append(recipients, recipient)
+		}
+	}
+
+	if len(recipients) == 0 {
+		return fmt.Errorf("smtp: no recipients for reminder %s", notification.ReminderID)
+	}
+
+	deadline := time.Now().Add(smtpTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+
+	dialer := net.Dialer{Deadline: deadline}
+	conn, err := dialer.DialContext(ctx, "tcp", n.addr)
+	if err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp: %w", err)
+	}
+
+	host, _, _ := net.SplitHostPort(n.addr)
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp: %w", err)
+	}
+	defer client.Close()
+
+	if err := client.Mail(n.from); err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+	for _, recipient := range recipients {
+		if err := client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("smtp: %w", err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+	if _, err := w.Write(n.message(notification, recipients)); err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+
+	return client.Quit()
+}
+
+func (n *SMTPNotifier) message(notification Notification, recipients []string) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", n.from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(recipients, ", "))
+	fmt.Fprintf(&b, "Subject: Reminder: %s %s\r\n", notification.TaskKey, sanitizeHeader(notification.Title))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().UTC().Format(time.RFC1123Z))
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+
+	fmt.Fprintf(&b, "Reminder for task %s: %s\r\n", notification.TaskKey, notification.Title)
+	if notification.DueAt != nil {
+		fmt.Fprintf(&b, "Due: %s\r\n", notification.DueAt.UTC().Format(time.RFC3339))
+	}
+
+	return []byte(b.String())
+}
+
+// sanitizeHeader keeps user-provided text from injecting extra headers.
+func sanitizeHeader(value string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+}
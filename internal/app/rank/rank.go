@@ -0,0 +1,240 @@
+// Package rank generates fractional, lexicographically ordered keys, so that
+// an item can be placed between two others by writing only its own key.
+//
+// A key is an integer part followed by an optional fraction, both in base-62
+// digits. The first character of the integer part gives its length: "a" to
+// "z" start integers of 1 to 26 digits, "A" to "Z" negative ones of 26 to 1
+// digits. Appending and prepending step the integer, which keeps keys short;
+// only insertions between two neighbours extend the fraction.
+package rank
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// digits are the base-62 digits of a key, in byte order.
+const digits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// First is the key Between returns when there is no other key.
+const First = "a0"
+
+// smallestInteger cannot be decremented; keys equal to it have no room before
+// them.
+var smallestInteger = "A" + strings.Repeat(digits[:1], 26)
+
+// ErrInvalidKey is returned for keys that are malformed, and for bounds that
+// are not in order.
+var ErrInvalidKey = errors.New("invalid rank key")
+
+// ErrExhausted is returned when no key exists beyond the given bound, which
+// takes around 62^26 insertions at one end.
+var ErrExhausted = errors.New("rank keys exhausted")
+
+// Between returns a key that sorts after a and before b. An empty a means no
+// lower bound and an empty b no upper bound.
+func Between(a, b string) (string, error) {
+	if a != "" {
+		if err := Validate(a); err != nil {
+			return "", err
+		}
+	}
+	if b != "" {
+		if err := Validate(b); err != nil {
+			return "", err
+		}
+	}
+	if a != "" && b != "" && a >= b {
+		return "", fmt.Errorf("%w: %q does not sort before %q", ErrInvalidKey, a, b)
+	}
+
+	switch {
+	case a == "" && b == "":
+		return First, nil
+
+	case a == "":
+		ib := integerPart(b)
+		if ib == smallestInteger {
+			return ib + midpoint("", b[len(ib):]), nil
+		}
+		if ib < b {
+			return ib, nil
+		}
+		key, ok := decrement(ib)
+		if !ok {
+			return "", ErrExhausted
+		}
+		return key, nil
+
+	case b == "":
+		ia := integerPart(a)
+		if key, ok := increment(ia); ok {
+			return key, nil
+		}
+		return ia + midpoint(a[len(ia):], ""), nil
+	}
+
+	ia, ib := integerPart(a), integerPart(b)
+	if ia == ib {
+		return ia + midpoint(a[len(ia):], b[len(ib):]), nil
+	}
+
+	key, ok := increment(ia)
+	if !ok {
+		return "", ErrExhausted
+	}
+	if key < b {
+		return key, nil
+	}
+	return ia + midpoint(a[len(ia):], ""), nil
+}
+
+// Validate checks that key could have been returned by Between.
+func Validate(key string) error {
+	if key == "" {
+		return fmt.Errorf("%w: empty key", ErrInvalidKey)
+	}
+
+	length, ok := integerLength(key[0])
+	if !ok || len(key) < length {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	for i := 1; i < len(key); i++ {
+		if strings.IndexByte(digits, key[i]) < 0 {
+			return fmt.Errorf("%w: %q contains %q", ErrInvalidKey, key, key[i])
+		}
+	}
+	if key == smallestInteger {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	if len(key) > length && key[len(key)-1] == digits[0] {
+		// A fraction ending in zero would leave no room before the key.
+		return fmt.Errorf("%w: %q ends in %q", ErrInvalidKey, key, digits[0])
+	}
+	return nil
+}
+
+// integerLength returns the length of an integer part starting with head.
+func integerLength(head byte) (int, bool) {
+	switch {
+	case head >= 'a' && head <= 'z':
+		return int(head-'a') + 2, true
+	case head >= 'A' && head <= 'Z':
+		return int('Z'-head) + 2, true
+	}
+	return 0, false
+}
+
+// integerPart returns the integer part of a valid key.
+func integerPart(key string) string {
+	length, _ := integerLength(key[0])
+	return key[:length]
+}
+
+// increment returns the next integer, or false after the largest one.
+func increment(integer string) (string, bool) {
+	head, digs := integer[0], []byte(integer[1:])
+	for i := len(digs) - 1; i >= 0; i-- {
+		d := strings.IndexByte(digits, digs[i]) + 1
+		if d < len(digits) {
+			digs[i] = digits[d]
+			return string(head) + string(digs), true
+		}
+		digs[i] = digits[0]
+	}
+
+	switch head {
+	case 'Z':
+		return "a" + digits[:1], true
+	case 'z':
+		return "", false
+	}
+
+	head++
+	if head > 'a' {
+		digs = append(digs, digits[0])
+	} else {
+		digs = digs[:len(digs)-1]
+	}
+	return string(head) + string(digs), true
+}
+
+// decrement returns the previous integer, or false before the smallest one.
+func decrement(integer string) (string, bool) {
+	last := digits[len(digits)-1]
+
+	head, digs := integer[0], []byte(integer[1:])
+	for i := len(digs) - 1; i >= 0; i-- {
+		d := strings.IndexByte(digits, digs[i]) - 1
+		if d >= 0 {
+			digs[i] = digits[d]
+			return string(head) + string(digs), true
+		}
+		digs[i] = last
+	}
+
+	switch head {
+	case 'a':
+		return "Z" + string(last), true
+	case 'A':
+		return "", false
+	}
+
+	head--
+	if head < 'Z' {
+		digs = append(digs, last)
+	} else {
+		digs = digs[:len(digs)-1]
+	}
+	return string(head) + string(digs), true
+}
+
+// midpoint returns a fraction between the fractions a and b, where an empty
+// b is unbounded. Fractions never end in zero, so there is always room.
+func midpoint(a, b string) string {
+	if b != "" {
+		// Keep the common prefix, reading missing digits of a as zeros.
+		n := 0
+		for n < len(b) && digitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			return b[:n] + midpoint(suffix(a, n), b[n:])
+		}
+	}
+
+	low := 0
+	if a != "" {
+		low = strings.IndexByte(digits, a[0])
+	}
+	high := len(digits)
+	if b != "" {
+		high = strings.IndexByte(digits, b[0])
+	}
+
+	if high-low > 1 {
+		return string(digits[(low+high+1)/2])
+	}
+
+	// The first digits are consecutive. A longer b has its first digit alone
+	// in between; otherwise continue after the first digit of a.
+	if len(b) > 1 {
+		return b[:1]
+	}
+	return string(digits[low]) + midpoint(suffix(a, 1), "")
+}
+
+func digitAt(key string, i int) byte {
+	if i < len(key) {
+		return key[i]
+	}
+	return digits[0]
+}
+
+func suffix(key string, n int) string {
+	if n >= len(key) {
+		return ""
+	}
+	return key[n:]
+}
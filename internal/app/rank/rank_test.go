@@ -0,0 +1,102 @@
+package rank
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		a, b, expected string
+	}{
+		{"", "", "a0"},
+		{"a0", "", "a1"},
+		{"", "a0", "Zz"},
+		{"az", "", "b00"},
+		{"Zz", "", "a0"},
+		{"a0", "a1", "a0V"},
+		{"a0", "a0V", "a0G"},
+		{"a0V", "a1", "a0l"},
+		{"", "a0V", "a0"},
+		{"a0", "a2", "a1"},
+	}
+
+	for _, tt := range tests {
+		key, err := Between(tt.a, tt.b)
+		if err != nil {
+			t.Fatalf("Expected a key between %q and %q, got %v", tt.a, tt.b, err)
+		}
+		if key != tt.expected {
+			t.Errorf("Expected %q between %q and %q, got %q", tt.expected, tt.a, tt.b, key)
+		}
+	}
+}
+
+func TestBetweenKeepsOrder(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	keys := []string{}
+
+	for i := 0; i < 500; i++ {
+		at := random.Intn(len(keys) + 1)
+
+		var a, b string
+		if at > 0 {
+			a = keys[at-1]
+		}
+		if at < len(keys) {
+			b = keys[at]
+		}
+
+		key, err := Between(a, b)
+		if err != nil {
+			t.Fatalf("Expected a key between %q and %q, got %v", a, b, err)
+		}
+		if (a != "" && key <= a) || (b != "" && key >= b) {
+			t.Fatalf("Expected %q to sort between %q and %q", key, a, b)
+		}
+		if err := Validate(key); err != nil {
+			t.Fatalf("Expected %q to be valid, got %v", key, err)
+		}
+
+		keys = append(keys[:at], append([]string{key}, keys[at:]...)...)
+	}
+
+	if !sort.StringsAreSorted(keys) {
+		t.Errorf("Expected the keys to stay sorted, got %v", keys)
+	}
+}
+
+func TestBetweenAppendsShortKeys(t *testing.T) {
+	key := ""
+	for i := 0; i < 1000; i++ {
+		next, err := Between(key, "")
+		if err != nil {
+			t.Fatalf("Failed to append: %v", err)
+		}
+		key = next
+	}
+
+	if len(key) > 4 {
+		t.Errorf("Expected appending to keep keys short, got %d digits", len(key))
+	}
+}
+
+func TestBetweenRejectsInvalidKeys(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"a00", ""},
+		{"", "a-"},
+		{"", "c0"},
+		{"a1", "a0"},
+		{"a1", "a1"},
+	}
+
+	for _, tt := range tests {
+		if _, err := Between(tt.a, tt.b); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Expected %q and %q to be refused, got %v", tt.a, tt.b, err)
+		}
+	}
+}
@@ -0,0 +1,321 @@
+// Package recurrence implements the subset of RFC 5545 recurrence rules used
+// for repeating tasks: DAILY, WEEKLY and MONTHLY frequencies with INTERVAL,
+// BYDAY, COUNT and UNTIL.
+package recurrence
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "DAILY"
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+)
+
+// maxPeriods bounds the search for the next occurrence so that rules which
+// rarely match (e.g. the 5th Friday every 12 months) cannot loop forever.
+const maxPeriods = 1000
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// WeekdayNum is a BYDAY entry. Ordinal is 0 for "every such weekday", or the
+// position within the month (1 for the first, -1 for the last, ...).
+type WeekdayNum struct {
+	Ordinal int
+	Weekday time.Weekday
+}
+
+type Rule struct {
+	Frequency Frequency
+	Interval  int
+	ByDay     []WeekdayNum
+	// Count limits the total number of occurrences; 0 means unlimited.
+	Count int
+	// Until is the last instant an occurrence may start at; zero means unlimited.
+	Until time.Time
+}
+
+// Parse reads a rule such as "FREQ=WEEKLY;BYDAY=MO,TH;COUNT=10". A leading
+// "RRULE:" is accepted.
+func Parse(value string) (Rule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return Rule{}, fmt.Errorf("rrule: rule is empty")
+	}
+
+	rule := Rule{Interval: 1}
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(value, ";") {
+		name, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return Rule{}, fmt.Errorf("rrule: malformed part %q", part)
+		}
+
+		name = strings.ToUpper(name)
+		if seen[name] {
+			return Rule{}, fmt.Errorf("rrule: %s given more than once", name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			rule.Frequency = Frequency(strings.ToUpper(val))
+			if rule.Frequency != FrequencyDaily && rule.Frequency != FrequencyWeekly && rule.Frequency != FrequencyMonthly {
+				return Rule{}, fmt.Errorf("rrule: unsupported frequency %s", val)
+			}
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(val)
+			if err != nil || rule.Interval < 1 {
+				return Rule{}, fmt.Errorf("rrule: INTERVAL must be a positive number")
+			}
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(val)
+			if err != nil || rule.Count < 1 {
+				return Rule{}, fmt.Errorf("rrule: COUNT must be a positive number")
+			}
+		case "UNTIL":
+			rule.Until, err = parseUntil(val)
+			if err != nil {
+				return Rule{}, err
+			}
+		case "BYDAY":
+			rule.ByDay, err = parseByDay(val)
+			if err != nil {
+				return Rule{}, err
+			}
+		case "WKST":
+			if strings.ToUpper(val) != "MO" {
+				return Rule{}, fmt.Errorf("rrule: only WKST=MO is supported")
+			}
+		default:
+			return Rule{}, fmt.Errorf("rrule: unsupported part %s", name)
+		}
+	}
+
+	if rule.Frequency == "" {
+		return Rule{}, fmt.Errorf("rrule: FREQ is required")
+	}
+
+	if rule.Count > 0 && !rule.Until.IsZero() {
+		return Rule{}, fmt.Errorf("rrule: COUNT and UNTIL cannot be combined")
+	}
+
+	if rule.Frequency != FrequencyMonthly {
+		for _, day := range rule.ByDay {
+			if day.Ordinal != 0 {
+				return Rule{}, fmt.Errorf("rrule: numbered BYDAY values require FREQ=MONTHLY")
+			}
+		}
+	}
+
+	return rule, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		if until, err := time.ParseInLocation(layout, value, time.UTC); err == nil {
+			if layout == "20060102" {
+				// A date-only UNTIL includes the whole day.
+				until = until.Add(24*time.Hour - time.Second)
+			}
+			return until, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("rrule: invalid UNTIL %q", value)
+}
+
+func parseByDay(value string) ([]WeekdayNum, error) {
+	var days []WeekdayNum
+	for _, entry := range strings.Split(strings.ToUpper(value), ",") {
+		if len(entry) < 2 {
+			return nil, fmt.Errorf("rrule: invalid BYDAY value %q", entry)
+		}
+
+		weekday, ok := weekdays[entry[len(entry)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("rrule: invalid BYDAY value %q", entry)
+		}
+
+		ordinal := 0
+		if prefix := entry[:len(entry)-2]; prefix != "" {
+			n, err := strconv.Atoi(prefix)
+			if err != nil || n == 0 || n < -5 || n > 5 {
+				return nil, fmt.Errorf("rrule: invalid BYDAY value %q", entry)
+			}
+			ordinal = n
+		}
+
+		days = append(days, WeekdayNum{Ordinal: ordinal, Weekday: weekday})
+	}
+
+	return days, nil
+}
+
+// String formats the rule in canonical RRULE form, without the "RRULE:" prefix.
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Frequency)}
+
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			code := strings.ToUpper(day.Weekday.String()[:2])
+			if day.Ordinal != 0 {
+				code = strconv.Itoa(day.Ordinal) + code
+			}
+			days[i] = code
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	if r.Count > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", r.Count))
+	}
+
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Next returns the first occurrence of a series starting at dtstart that falls
+// strictly after the given instant. generated is the number of occurrences
+// already produced and is checked against COUNT. The second result is false
+// once the series is exhausted.
+func (r Rule) Next(dtstart time.Time, after time.Time, generated int) (time.Time, bool) {
+	if r.Count > 0 && generated >= r.Count {
+		return time.Time{}, false
+	}
+
+	start := r.firstPeriod(dtstart, after)
+	for period := start; period < start+maxPeriods; period++ {
+		for _, candidate := range r.candidates(dtstart, period) {
+			if candidate.Before(dtstart) || !candidate.After(after) {
+				continue
+			}
+
+			if !r.Until.IsZero() && candidate.After(r.Until) {
+				return time.Time{}, false
+			}
+
+			return candidate, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// firstPeriod skips the periods that end before the given instant, so that
+// long-running series do not have to be replayed from the start.
+func (r Rule) firstPeriod(dtstart time.Time, after time.Time) int {
+	if !after.After(dtstart) {
+		return 0
+	}
+
+	var elapsed int
+	switch r.Frequency {
+	case FrequencyDaily:
+		elapsed = int(after.Sub(dtstart).Hours() / 24)
+	case FrequencyWeekly:
+		elapsed = int(after.Sub(dtstart).Hours() / (24 * 7))
+	case FrequencyMonthly:
+		elapsed = (after.Year()-dtstart.Year())*12 + int(after.Month()) - int(dtstart.Month())
+	}
+
+	if period := elapsed/r.Interval - 1; period > 0 {
+		return period
+	}
+	return 0
+}
+
+// candidates lists the occurrences of the given period in chronological order.
+func (r Rule) candidates(dtstart time.Time, period int) []time.Time {
+	year, month, day := dtstart.Date()
+	hour, min, sec := dtstart.Clock()
+	at := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, hour, min, sec, 0, dtstart.Location())
+	}
+
+	var result []time.Time
+	switch r.Frequency {
+	case FrequencyDaily:
+		date := at(year, month, day+period*r.Interval)
+		if len(r.ByDay) == 0 || r.matchesWeekday(date.Weekday()) {
+			result = append(result, date)
+		}
+
+	case FrequencyWeekly:
+		// Weeks start on Monday (WKST=MO).
+		monday := day - (int(dtstart.Weekday())+6)%7 + period*r.Interval*7
+		if len(r.ByDay) == 0 {
+			result = append(result, at(year, month, monday+(int(dtstart.Weekday())+6)%7))
+		}
+		for _, byDay := range r.ByDay {
+			result = append(result, at(year, month, monday+(int(byDay.Weekday)+6)%7))
+		}
+
+	case FrequencyMonthly:
+		first := time.Date(year, month+time.Month(period*r.Interval), 1, 0, 0, 0, 0, dtstart.Location())
+		daysInMonth := first.AddDate(0, 1, -1).Day()
+
+		if len(r.ByDay) == 0 {
+			// Months without the start day (e.g. the 31st) are skipped, as in RFC 5545.
+			if day <= daysInMonth {
+				result = append(result, at(first.Year(), first.Month(), day))
+			}
+		}
+
+		for _, byDay := range r.ByDay {
+			offset := (int(byDay.Weekday) - int(first.Weekday()) + 7) % 7
+			var matches []int
+			for d := 1 + offset; d <= daysInMonth; d += 7 {
+				matches = append(matches, d)
+			}
+
+			switch {
+			case byDay.Ordinal == 0:
+				for _, d := range matches {
+					result = append(result, at(first.Year(), first.Month(), d))
+				}
+			case byDay.Ordinal > 0 && byDay.Ordinal <= len(matches):
+				result = append(result, at(first.Year(), first.Month(), matches[byDay.Ordinal-1]))
+			case byDay.Ordinal < 0 && -byDay.Ordinal <= len(matches):
+				result = append(result, at(first.Year(), first.Month(), matches[len(matches)+byDay.Ordinal]))
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Before(result[j]) })
+	return result
+}
+
+func (r Rule) matchesWeekday(weekday time.Weekday) bool {
+	for _, byDay := range r.ByDay {
+		if byDay.Weekday == weekday {
+			return true
+		}
+	}
+	return false
+}
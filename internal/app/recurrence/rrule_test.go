@@ -0,0 +1,132 @@
+package recurrence
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("valid rules", func(t *testing.T) {
+		tests := map[string]string{
+			"RRULE:FREQ=DAILY":                   "FREQ=DAILY",
+			"freq=weekly;byday=mo,th;interval=2": "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH",
+			"FREQ=MONTHLY;BYDAY=-1FR;COUNT=3":    "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+			"FREQ=WEEKLY;UNTIL=20250131T090000Z": "FREQ=WEEKLY;UNTIL=20250131T090000Z",
+		}
+
+		for input, expected := range tests {
+			rule, err := Parse(input)
+			if err != nil {
+				t.Fatalf("Expected %q to parse, got %v", input, err)
+			}
+
+			if rule.String() != expected {
+				t.Errorf("Expected %q to format as %q, got %q", input, expected, rule.String())
+			}
+		}
+	})
+
+	t.Run("invalid rules", func(t *testing.T) {
+		for _, input := range []string{
+			"",
+			"BYDAY=MO",
+			"FREQ=YEARLY",
+			"FREQ=DAILY;INTERVAL=0",
+			"FREQ=DAILY;COUNT=2;UNTIL=20250101",
+			"FREQ=WEEKLY;BYDAY=1MO",
+			"FREQ=MONTHLY;BYDAY=XX",
+			"FREQ=DAILY;BYHOUR=9",
+		} {
+			if _, err := Parse(input); err == nil {
+				t.Errorf("Expected %q to be rejected", input)
+			}
+		}
+	})
+}
+
+func TestRule_Next(t *testing.T) {
+	// Monday, 6 January 2025.
+	dtstart := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+
+	occurrences := func(t *testing.T, value string, n int) []string {
+		rule, err := Parse(value)
+		if err != nil {
+			t.Fatalf("Failed to parse rule: %v", err)
+		}
+
+		var result []string
+		after := dtstart.Add(-time.Second)
+		for len(result) < n {
+			next, ok := rule.Next(dtstart, after, len(result))
+			if !ok {
+				break
+			}
+			result = append(result, next.Format("2006-01-02"))
+			after = next
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		rule     string
+		expected []string
+		// ends marks rules whose series must stop after the expected occurrences.
+		ends bool
+	}{
+		{"daily with interval", "FREQ=DAILY;INTERVAL=3", []string{"2025-01-06", "2025-01-09", "2025-01-12"}, false},
+		{"daily on weekdays", "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR", []string{"2025-01-06", "2025-01-07", "2025-01-08", "2025-01-09"}, false},
+		{"weekly by day", "FREQ=WEEKLY;BYDAY=MO,FR", []string{"2025-01-06", "2025-01-10", "2025-01-13", "2025-01-17"}, false},
+		{"biweekly", "FREQ=WEEKLY;INTERVAL=2", []string{"2025-01-06", "2025-01-20", "2025-02-03"}, false},
+		{"monthly on start day", "FREQ=MONTHLY", []string{"2025-01-06", "2025-02-06", "2025-03-06"}, false},
+		{"last friday of the month", "FREQ=MONTHLY;BYDAY=-1FR", []string{"2025-01-31", "2025-02-28", "2025-03-28"}, false},
+		{"count limits occurrences", "FREQ=DAILY;COUNT=2", []string{"2025-01-06", "2025-01-07"}, true},
+		{"until is inclusive", "FREQ=WEEKLY;UNTIL=20250120", []string{"2025-01-06", "2025-01-13", "2025-01-20"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := occurrences(t, tt.rule, 4)
+			if len(got) < len(tt.expected) {
+				t.Fatalf("Expected %v, got %v", tt.expected, got)
+			}
+
+			for i := range tt.expected {
+				if got[i] != tt.expected[i] {
+					t.Errorf("Expected %v, got %v", tt.expected, got)
+					break
+				}
+			}
+
+			if tt.ends && len(got) != len(tt.expected) {
+				t.Errorf("Expected the series to end after %d occurrences, got %v", len(tt.expected), got)
+			}
+		})
+	}
+
+	t.Run("monthly skips months without the start day", func(t *testing.T) {
+		rule, err := Parse("FREQ=MONTHLY")
+		if err != nil {
+			t.Fatalf("Failed to parse rule: %v", err)
+		}
+
+		start := time.Date(2025, time.January, 31, 9, 0, 0, 0, time.UTC)
+		next, ok := rule.Next(start, start, 1)
+		if !ok || next.Month() != time.March || next.Day() != 31 {
+			t.Errorf("Expected 31 March, got %v", next)
+		}
+	})
+
+	t.Run("skips ahead for long-running series", func(t *testing.T) {
+		rule, err := Parse("FREQ=DAILY")
+		if err != nil {
+			t.Fatalf("Failed to parse rule: %v", err)
+		}
+
+		after := dtstart.AddDate(5, 0, 0)
+		next, ok := rule.Next(dtstart, after, 0)
+		if !ok || !next.Equal(after.AddDate(0, 0, 1)) {
+			t.Errorf("Expected %v, got %v", after.AddDate(0, 0, 1), next)
+		}
+	})
+}
@@ -0,0 +1,95 @@
+// Package scheduler runs background jobs at fixed intervals inside the
+// application process.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Job does one unit of background work. It must return promptly once ctx is
+// cancelled.
+type Job func(ctx context.Context) error
+
+type entry struct {
+	name     string
+	interval time.Duration
+	job      Job
+}
+
+type Scheduler struct {
+	logger  *log.Logger
+	entries []entry
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func New(logger *log.Logger) *Scheduler {
+	return &Scheduler{
+		logger: logger,
+	}
+}
+
+// Every registers a job that runs once at start and then every interval. A
+// run that takes longer than the interval delays the next one; runs of the
+// same job never overlap. Jobs must be registered before Start.
+func (s *Scheduler) Every(name string, interval time.Duration, job Job) {
+	s.entries = append(s.entries, entry{name: name, interval: interval, job: job})
+}
+
+// Start launches every registered job in its own goroutine.
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for _, e := range s.entries {
+		s.wg.Add(1)
+		go func(e entry) {
+			defer s.wg.Done()
+			s.run(ctx, e)
+		}(e)
+	}
+}
+
+// Stop cancels the running jobs and waits for them to return, giving up when
+// ctx expires.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("scheduler: jobs did not stop in time: %w", ctx.Err())
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, e entry) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := e.job(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Printf("Scheduled job %s failed: %v", e.name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"context"
+	"io"
+	"log"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScheduler(t *testing.T) {
+	logger := log.New(io.Discard, "", 0)
+
+	t.Run("runs jobs until stopped", func(t *testing.T) {
+		var runs atomic.Int32
+		s := New(logger)
+		s.Every("count", 10*time.Millisecond, func(ctx context.Context) error {
+			runs.Add(1)
+			return nil
+		})
+
+		s.Start()
+		time.Sleep(35 * time.Millisecond)
+
+		if err := s.Stop(context.Background()); err != nil {
+			t.Fatalf("Expected clean stop, got %v", err)
+		}
+
+		stopped := runs.Load()
+		if stopped < 2 {
+			t.Errorf("Expected the job to run at least twice, got %d", stopped)
+		}
+
+		time.Sleep(25 * time.Millisecond)
+		if runs.Load() != stopped {
+			t.Errorf("Expected no runs after stop, got %d more", runs.Load()-stopped)
+		}
+	})
+
+	t.Run("stop gives up when a job ignores cancellation", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+
+		s := New(logger)
+		s.Every("stuck", time.Hour, func(ctx context.Context) error {
+			<-release
+			return nil
+		})
+
+		s.Start()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		if err := s.Stop(ctx); err == nil {
+			t.Error("Expected stop to time out, got nil")
+		}
+	})
+}
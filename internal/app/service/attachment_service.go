@@ -0,0 +1,222 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite/sqlc"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/storage"
+	"github.com/google/uuid"
+)
+
+// sniffLen is the number of leading bytes http.DetectContentType looks at.
+const sniffLen = 512
+
+type AttachmentService struct {
+	logger       *log.Logger
+	db           *sqlite.Database
+	store        storage.BlobStore
+	maxSize      int64
+	allowedTypes map[string]bool
+}
+
+func NewAttachmentService(logger *log.Logger, db *sqlite.Database, store storage.BlobStore, maxSize int64, allowedTypes []string) *AttachmentService {
+	allowed := make(map[string]bool, len(allowedTypes))
+	for _, contentType := range allowedTypes {
+		if contentType = strings.TrimSpace(strings.ToLower(contentType)); contentType != "" {
+			allowed[contentType] = true
+		}
+	}
+
+	return &AttachmentService{
+		logger:       logger,
+		db:           db,
+		store:        store,
+		maxSize:      maxSize,
+		allowedTypes: allowed,
+	}
+}
+
+// UploadAttachment streams content into the blob store while hashing it. The
+// content type is sniffed from the data rather than trusted from the client.
+func (s *AttachmentService) UploadAttachment(ctx context.Context, taskID string, uploader string, filename string, content io.Reader) (domain.Attachment, error) {
+	if taskID == "" {
+		return domain.Attachment{}, fmt.Errorf("upload attachment: %w", domain.Invalid("id", "task id is required"))
+	}
+
+	filename = filepath.Base(filepath.Clean("/" + strings.ReplaceAll(filename, "\\", "/")))
+	if filename == "/" || filename == "." {
+		return domain.Attachment{}, fmt.Errorf("upload attachment: %w", domain.Invalid("file", "filename is required"))
+	}
+
+	if _, err := s.db.Queries.GetTask(ctx, taskID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Attachment{}, fmt.Errorf("upload attachment: %w", domain.NotFound("task not found"))
+		}
+		return domain.Attachment{}, fmt.Errorf("upload attachment: %w", err)
+	}
+
+	buffered := bufio.NewReaderSize(content, sniffLen)
+	head, err := buffered.Peek(sniffLen)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return domain.Attachment{}, fmt.Errorf("upload attachment: %w", err)
+	}
+
+	if len(head) == 0 {
+		return domain.Attachment{}, fmt.Errorf("upload attachment: %w", domain.Invalid("file", "file is empty"))
+	}
+
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil {
+		return domain.Attachment{}, fmt.Errorf("upload attachment: %w", err)
+	}
+
+	if !s.allowedTypes[contentType] {
+		return domain.Attachment{}, fmt.Errorf("upload attachment: %w", domain.Invalid("file", "content type %s is not allowed", contentType))
+	}
+
+	id := uuid.New()
+	key := taskID + "/" + id.String()
+	hash := sha256.New()
+
+	// Read one byte past the limit so oversized files can be told apart from
+	// files of exactly the maximum size.
+	limited := io.TeeReader(io.LimitReader(buffered, s.maxSize+1), hash)
+
+	size, err := s.store.Put(ctx, key, limited)
+	if err != nil {
+		return domain.Attachment{}, fmt.Errorf("upload attachment: %w", err)
+	}
+
+	if size > s.maxSize {
+		s.deleteBlob(ctx, key)
+		return domain.Attachment{}, fmt.Errorf("upload attachment: %w", domain.Invalid("file", "file exceeds maximum size of %d bytes", s.maxSize))
+	}
+
+	attachment := sqlc.TaskAttachment{
+		ID:          id.String(),
+		TaskID:      taskID,
+		Filename:    filename,
+		ContentType: contentType,
+		SizeBytes:   size,
+		Sha256:      hex.EncodeToString(hash.Sum(nil)),
+		StorageKey:  key,
+		UploadedBy:  uploader,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	err = s.db.Queries.CreateTaskAttachment(ctx, sqlc.CreateTaskAttachmentParams(attachment))
+	if err != nil {
+		s.deleteBlob(ctx, key)
+		return domain.Attachment{}, fmt.Errorf("upload attachment: %w", err)
+	}
+
+	return attachmentToDomain(attachment), nil
+}
+
+func (s *AttachmentService) ListAttachments(ctx context.Context, taskID string) ([]domain.Attachment, error) {
+	if taskID == "" {
+		return nil, fmt.Errorf("list attachments: %w", domain.Invalid("id", "task id is required"))
+	}
+
+	if _, err := s.db.Queries.GetTask(ctx, taskID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("list attachments: %w", domain.NotFound("task not found"))
+		}
+		return nil, fmt.Errorf("list attachments: %w", err)
+	}
+
+	attachments, err := s.db.Queries.ListTaskAttachments(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("list attachments: %w", err)
+	}
+
+	domainAttachments := make([]domain.Attachment, len(attachments))
+	for i, attachment := range attachments {
+		domainAttachments[i] = attachmentToDomain(attachment)
+	}
+
+	return domainAttachments, nil
+}
+
+// OpenAttachment returns the attachment metadata with a reader over its
+// content. The caller must close the reader.
+func (s *AttachmentService) OpenAttachment(ctx context.Context, taskID string, attachmentID string) (domain.Attachment, io.ReadCloser, error) {
+	if taskID == "" || attachmentID == "" {
+		return domain.Attachment{}, nil, fmt.Errorf("open attachment: %w", domain.Invalid("id", "task id and attachment id are required"))
+	}
+
+	attachment, err := s.db.Queries.GetTaskAttachment(ctx, sqlc.GetTaskAttachmentParams{ID: attachmentID, TaskID: taskID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Attachment{}, nil, fmt.Errorf("open attachment: %w", domain.NotFound("attachment not found"))
+		}
+		return domain.Attachment{}, nil, fmt.Errorf("open attachment: %w", err)
+	}
+
+	content, err := s.store.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrBlobNotFound) {
+			return domain.Attachment{}, nil, fmt.Errorf("open attachment: %w", domain.NotFound("attachment content not found"))
+		}
+		return domain.Attachment{}, nil, fmt.Errorf("open attachment: %w", err)
+	}
+
+	return attachmentToDomain(attachment), content, nil
+}
+
+func (s *AttachmentService) DeleteAttachment(ctx context.Context, taskID string, attachmentID string) error {
+	if taskID == "" || attachmentID == "" {
+		return fmt.Errorf("delete attachment: %w", domain.Invalid("id", "task id and attachment id are required"))
+	}
+
+	attachment, err := s.db.Queries.GetTaskAttachment(ctx, sqlc.GetTaskAttachmentParams{ID: attachmentID, TaskID: taskID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("delete attachment: %w", domain.NotFound("attachment not found"))
+		}
+		return fmt.Errorf("delete attachment: %w", err)
+	}
+
+	if _, err := s.db.Queries.DeleteTaskAttachment(ctx, sqlc.DeleteTaskAttachmentParams{ID: attachmentID, TaskID: taskID}); err != nil {
+		return fmt.Errorf("delete attachment: %w", err)
+	}
+
+	s.deleteBlob(ctx, attachment.StorageKey)
+	return nil
+}
+
+// deleteBlob removes content whose metadata is already gone. A failure only
+// leaves an unreferenced file behind, so it is logged rather than returned.
+func (s *AttachmentService) deleteBlob(ctx context.Context, key string) {
+	if err := s.store.Delete(ctx, key); err != nil {
+		s.logger.Printf("failed to delete attachment blob %s: %v", key, err)
+	}
+}
+
+func attachmentToDomain(attachment sqlc.TaskAttachment) domain.Attachment {
+	return domain.Attachment{
+		ID:          uuid.MustParse(attachment.ID),
+		TaskID:      uuid.MustParse(attachment.TaskID),
+		Filename:    attachment.Filename,
+		ContentType: attachment.ContentType,
+		SizeBytes:   attachment.SizeBytes,
+		SHA256:      attachment.Sha256,
+		UploadedBy:  attachment.UploadedBy,
+		CreatedAt:   attachment.CreatedAt,
+	}
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/storage"
+)
+
+func TestAttachmentService_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, err := sqlite.NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	blobDir := t.TempDir()
+	blobs, err := storage.NewLocalBlobStore(blobDir)
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+
+	logger := log.New(os.Stderr, "INTEGRATION_TEST: ", log.LstdFlags)
+	taskService := NewTaskService(logger, db, blobs, domain.DefaultWorkflow())
+	attachmentService := NewAttachmentService(logger, db, blobs, 64, []string{"text/plain"})
+	ctx := context.Background()
+
+	taskID, err := taskService.CreateTask(ctx, &domain.CreateTaskRequest{Title: "Task with logs"})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	content := []byte("panic: runtime error\n")
+	attachment, err := attachmentService.UploadAttachment(ctx, taskID.String(), "client-a", "../../server.log", bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("Failed to upload attachment: %v", err)
+	}
+
+	t.Run("metadata is recorded", func(t *testing.T) {
+		sum := sha256.Sum256(content)
+
+		if attachment.SHA256 != hex.EncodeToString(sum[:]) {
+			t.Errorf("Expected SHA-256 %x, got %s", sum, attachment.SHA256)
+		}
+
+		if attachment.Filename != "server.log" {
+			t.Errorf("Expected sanitized filename server.log, got %q", attachment.Filename)
+		}
+
+		if attachment.ContentType != "text/plain" {
+			t.Errorf("Expected content type text/plain, got %q", attachment.ContentType)
+		}
+
+		if attachment.SizeBytes != int64(len(content)) {
+			t.Errorf("Expected size %d, got %d", len(content), attachment.SizeBytes)
+		}
+	})
+
+	t.Run("download returns content", func(t *testing.T) {
+		_, reader, err := attachmentService.OpenAttachment(ctx, taskID.String(), attachment.ID.String())
+		if err != nil {
+			t.Fatalf("Failed to open attachment: %v", err)
+		}
+		defer reader.Close()
+
+		downloaded, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("Failed to read attachment: %v", err)
+		}
+
+		if !bytes.Equal(downloaded, content) {
+			t.Errorf("Expected content %q, got %q", content, downloaded)
+		}
+	})
+
+	t.Run("disallowed content type is rejected", func(t *testing.T) {
+		png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR")
+
+		_, err := attachmentService.UploadAttachment(ctx, taskID.String(), "client-a", "shot.png", bytes.NewReader(png))
+		if err == nil {
+			t.Fatal("Expected error for disallowed content type, got nil")
+		}
+
+		expectedError := "upload attachment: content type image/png is not allowed"
+		if err.Error() != expectedError {
+			t.Errorf("Expected error %q, got %q", expectedError, err.Error())
+		}
+	})
+
+	t.Run("oversized file is rejected", func(t *testing.T) {
+		_, err := attachmentService.UploadAttachment(ctx, taskID.String(), "client-a", "big.log", strings.NewReader(strings.Repeat("x", 65)))
+		if err == nil {
+			t.Fatal("Expected error for oversized file, got nil")
+		}
+
+		attachments, err := attachmentService.ListAttachments(ctx, taskID.String())
+		if err != nil {
+			t.Fatalf("Failed to list attachments: %v", err)
+		}
+
+		if len(attachments) != 1 {
+			t.Errorf("Expected only the first attachment to be stored, got %d", len(attachments))
+		}
+	})
+
+	t.Run("purging the task removes attachments", func(t *testing.T) {
+		if err := taskService.PurgeTask(ctx, taskID.String()); err != nil {
+			t.Fatalf("Failed to purge task: %v", err)
+		}
+
+		if _, err := os.Stat(filepath.Join(blobDir, taskID.String(), attachment.ID.String())); !os.IsNotExist(err) {
+			t.Errorf("Expected attachment blob to be deleted, got %v", err)
+		}
+
+		if _, _, err := attachmentService.OpenAttachment(ctx, taskID.String(), attachment.ID.String()); err == nil {
+			t.Error("Expected attachment metadata to be deleted")
+		}
+	})
+}
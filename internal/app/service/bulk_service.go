@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite/sqlc"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+)
+
+// errBulkRolledBack aborts the transaction of an atomic bulk request after
+// one of its operations failed.
+var errBulkRolledBack = errors.New("bulk request rolled back")
+
+// BulkService applies many task changes in a single transaction.
+type BulkService struct {
+	logger        *log.Logger
+	db            *sqlite.Database
+	tasks         *TaskService
+	maxOperations int
+}
+
+func NewBulkService(logger *log.Logger, db *sqlite.Database, tasks *TaskService, maxOperations int) *BulkService {
+	return &BulkService{
+		logger:        logger,
+		db:            db,
+		tasks:         tasks,
+		maxOperations: maxOperations,
+	}
+}
+
+// Apply runs the operations of a bulk request, or its patch on every task
+// matching its filter, in one transaction. In atomic mode the first failure
+// rolls everything back; in best-effort mode each operation runs in its own
+// savepoint and failures only undo that operation.
+func (s *BulkService) Apply(ctx context.Context, req *domain.BulkRequest) (domain.BulkResult, error) {
+	mode := req.Mode
+	if mode == "" {
+		mode = domain.BulkModeAtomic
+	}
+
+	if !mode.IsValid() {
+		return domain.BulkResult{}, fmt.Errorf("bulk: %w: invalid mode %q", domain.ErrInvalidBulkRequest, req.Mode)
+	}
+
+	byFilter := req.Filter != nil || req.Patch != nil
+	switch {
+	case byFilter && len(req.Operations) > 0:
+		return domain.BulkResult{}, fmt.Errorf("bulk: %w: operations cannot be combined with a filter", domain.ErrInvalidBulkRequest)
+	case byFilter && (req.Filter == nil || req.Patch == nil):
+		return domain.BulkResult{}, fmt.Errorf("bulk: %w: filter and patch must be given together", domain.ErrInvalidBulkRequest)
+	case byFilter && req.Filter.Expression != "":
+		return domain.BulkResult{}, fmt.Errorf("bulk: %w: filter expressions are not supported, list the tasks with GET /tasks?filter= and send operations", domain.ErrInvalidBulkRequest)
+	case byFilter && req.Filter.IsZero():
+		return domain.BulkResult{}, fmt.Errorf("bulk: %w: filter must match on at least one field", domain.ErrInvalidBulkRequest)
+	case !byFilter && len(req.Operations) == 0:
+		return domain.BulkResult{}, fmt.Errorf("bulk: %w: operations are required", domain.ErrInvalidBulkRequest)
+	}
+
+	if len(req.Operations) > s.maxOperations {
+		return domain.BulkResult{}, fmt.Errorf("bulk: %w: at most %d operations are allowed, got %d",
+			domain.ErrInvalidBulkRequest, s.maxOperations, len(req.Operations))
+	}
+
+	result := domain.BulkResult{Mode: mode}
+
+	err := s.db.WithSavepoints(ctx, func(q *sqlc.Queries, savepoint sqlite.Savepoint) error {
+		operations := req.Operations
+		if byFilter {
+			var err error
+			operations, err = s.matchFilter(ctx, q, *req.Filter, req.Patch)
+			if err != nil {
+				return err
+			}
+		}
+
+		result.Results = make([]domain.BulkItemResult, len(operations))
+		for i, op := range operations {
+			item := &result.Results[i]
+			item.Index = i
+			item.Op = op.Op
+			item.ID = op.ID
+
+			step := func() error {
+				id, err := s.apply(ctx, q, op)
+				item.ID = id
+				return err
+			}
+
+			var err error
+			if mode == domain.BulkModeBestEffort {
+				err = savepoint(step)
+			} else {
+				err = step()
+			}
+
+			if err == nil {
+				item.Status = domain.BulkItemSucceeded
+				result.Succeeded++
+				continue
+			}
+
+			item.Status = domain.BulkItemFailed
+			item.Error = s.itemError(ctx, i, err)
+			result.Failed++
+
+			if mode == domain.BulkModeAtomic {
+				for j := range operations {
+					switch {
+					case j < i:
+						result.Results[j].Status = domain.BulkItemRolledBack
+					case j > i:
+						result.Results[j] = domain.BulkItemResult{
+							Index:  j,
+							Op:     operations[j].Op,
+							ID:     operations[j].ID,
+							Status: domain.BulkItemSkipped,
+						}
+					}
+				}
+				result.Succeeded = 0
+				return errBulkRolledBack
+			}
+		}
+
+		return nil
+	})
+
+	switch {
+	case errors.Is(err, errBulkRolledBack):
+		return result, nil
+	case err != nil:
+		return domain.BulkResult{}, fmt.Errorf("bulk: %w", err)
+	}
+
+	result.Committed = true
+	return result, nil
+}
+
+// itemError returns the message reported for a failed operation. Errors of
+// no known kind are internal; they are logged under the request ID instead of
+// being sent to the client.
+func (s *BulkService) itemError(ctx context.Context, index int, err error) string {
+	if domain.IsKnown(err) {
+		return err.Error()
+	}
+
+	requestID := domain.RequestIDFromContext(ctx)
+	s.logger.Printf("request %s: bulk operation %d: %v", requestID, index, err)
+	if requestID == "" {
+		return "internal error"
+	}
+	return fmt.Sprintf("internal error, see request %s", requestID)
+}
+
+// matchFilter turns a filter and patch into an update operation for every
+// matching task.
+func (s *BulkService) matchFilter(ctx context.Context, q *sqlc.Queries, filter domain.TaskFilter, patch *domain.UpdateTaskRequest) ([]domain.BulkOperation, error) {
+	params, err := getTasksParams(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := q.GetTasks(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tasks) > s.maxOperations {
+		return nil, fmt.Errorf("%w: filter matches %d tasks, at most %d are allowed",
+			domain.ErrInvalidBulkRequest, len(tasks), s.maxOperations)
+	}
+
+	operations := make([]domain.BulkOperation, len(tasks))
+	for i, task := range tasks {
+		operations[i] = domain.BulkOperation{Op: domain.BulkOperationUpdate, ID: task.ID, Patch: patch}
+	}
+
+	return operations, nil
+}
+
+// apply runs a single operation and returns the ID of the task it affected.
+func (s *BulkService) apply(ctx context.Context, q *sqlc.Queries, op domain.BulkOperation) (string, error) {
+	switch op.Op {
+	case domain.BulkOperationCreate:
+		if op.Create == nil {
+			return "", domain.Invalid("Create", "create is required")
+		}
+
+		id, err := s.tasks.createTask(ctx, q, op.Create)
+		if err != nil {
+			return "", err
+		}
+		return id.String(), nil
+
+	case domain.BulkOperationUpdate:
+		if op.Patch == nil {
+			return op.ID, domain.Invalid("Patch", "patch is required")
+		}
+		return op.ID, s.tasks.updateTask(ctx, q, op.ID, op.Patch, nil)
+
+	case domain.BulkOperationDelete:
+		return op.ID, s.tasks.deleteTask(ctx, q, op.ID, nil)
+
+	default:
+		return op.ID, domain.Invalid("Op", "unknown operation %q", op.Op)
+	}
+}
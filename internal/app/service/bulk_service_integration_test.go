@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/storage"
+)
+
+func TestBulk_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, err := sqlite.NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+
+	logger := log.New(os.Stderr, "INTEGRATION_TEST: ", log.LstdFlags)
+	taskService := NewTaskService(logger, db, blobs, domain.DefaultWorkflow())
+	bulkService := NewBulkService(logger, db, taskService, 3)
+	ctx := context.Background()
+
+	existing, err := taskService.CreateTask(ctx, &domain.CreateTaskRequest{Title: "Write changelog", Tags: []string{"Release-1.4"}})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	t.Run("atomic requests apply every operation", func(t *testing.T) {
+		title := "Write release notes"
+		result, err := bulkService.Apply(ctx, &domain.BulkRequest{
+			Operations: []domain.BulkOperation{
+				{Op: domain.BulkOperationCreate, Create: &domain.CreateTaskRequest{Title: "Tag release", Tags: []string{"release-1.4"}}},
+				{Op: domain.BulkOperationUpdate, ID: existing.String(), Patch: &domain.UpdateTaskRequest{Title: &title}},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Failed to apply bulk request: %v", err)
+		}
+
+		if !result.Committed || result.Mode != domain.BulkModeAtomic || result.Succeeded != 2 || result.Failed != 0 {
+			t.Fatalf("Expected a committed atomic request with 2 successes, got %+v", result)
+		}
+
+		if result.Results[0].ID == "" || result.Results[1].ID != existing.String() {
+			t.Errorf("Expected the IDs of the affected tasks, got %+v", result.Results)
+		}
+
+		task, err := taskService.GetTask(ctx, existing.String())
+		if err != nil || task.Title != title {
+			t.Errorf("Expected the update to be applied, got %+v (%v)", task, err)
+		}
+	})
+
+	t.Run("a failing atomic operation rolls back the request", func(t *testing.T) {
+		result, err := bulkService.Apply(ctx, &domain.BulkRequest{
+			Mode: domain.BulkModeAtomic,
+			Operations: []domain.BulkOperation{
+				{Op: domain.BulkOperationCreate, Create: &domain.CreateTaskRequest{Title: "Announce release"}},
+				{Op: domain.BulkOperationDelete, ID: "missing"},
+				{Op: domain.BulkOperationDelete, ID: existing.String()},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Failed to apply bulk request: %v", err)
+		}
+
+		if result.Committed || result.Failed != 1 || result.Succeeded != 0 {
+			t.Fatalf("Expected a rolled back request, got %+v", result)
+		}
+
+		statuses := []domain.BulkItemStatus{domain.BulkItemRolledBack, domain.BulkItemFailed, domain.BulkItemSkipped}
+		for i, status := range statuses {
+			if result.Results[i].Status != status {
+				t.Errorf("Expected operation %d to be %s, got %s", i, status, result.Results[i].Status)
+			}
+		}
+
+		if result.Results[1].Error == "" {
+			t.Error("Expected an error for the failed operation, got none")
+		}
+
+		tasks, err := taskService.GetTasks(ctx, domain.TaskFilter{})
+		if err != nil {
+			t.Fatalf("Failed to get tasks: %v", err)
+		}
+		if len(tasks) != 2 {
+			t.Errorf("Expected 2 tasks after rollback, got %d", len(tasks))
+		}
+	})
+
+	t.Run("best-effort requests keep the operations that succeed", func(t *testing.T) {
+		invalid := domain.TaskPriority("urgent")
+		result, err := bulkService.Apply(ctx, &domain.BulkRequest{
+			Mode: domain.BulkModeBestEffort,
+			Operations: []domain.BulkOperation{
+				{Op: domain.BulkOperationCreate, Create: &domain.CreateTaskRequest{Title: "Announce release"}},
+				{Op: domain.BulkOperationUpdate, ID: existing.String(), Patch: &domain.UpdateTaskRequest{Priority: &invalid}},
+				{Op: domain.BulkOperationCreate, Create: &domain.CreateTaskRequest{}},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Failed to apply bulk request: %v", err)
+		}
+
+		if !result.Committed || result.Succeeded != 1 || result.Failed != 2 {
+			t.Fatalf("Expected 1 success and 2 failures, got %+v", result)
+		}
+		if !strings.Contains(result.Results[1].Error, "priority") {
+			t.Errorf("Expected the validation message to be reported, got %q", result.Results[1].Error)
+		}
+
+		if _, err := taskService.GetTask(ctx, result.Results[0].ID); err != nil {
+			t.Errorf("Expected the created task to exist, got %v", err)
+		}
+	})
+
+	t.Run("a filter applies the patch to every matching task", func(t *testing.T) {
+		done := domain.TaskStatusDone
+		result, err := bulkService.Apply(ctx, &domain.BulkRequest{
+			Filter: &domain.TaskFilter{Tag: "Release-1.4"},
+			Patch:  &domain.UpdateTaskRequest{Status: &done},
+		})
+		if err != nil {
+			t.Fatalf("Failed to apply bulk request: %v", err)
+		}
+
+		if !result.Committed || result.Succeeded != 2 {
+			t.Fatalf("Expected 2 updated tasks, got %+v", result)
+		}
+
+		tasks, err := taskService.GetTasks(ctx, domain.TaskFilter{Tag: "Release-1.4"})
+		if err != nil {
+			t.Fatalf("Failed to get tasks: %v", err)
+		}
+		if len(tasks) != result.Succeeded {
+			t.Errorf("Expected listing to match the %d tasks the bulk filter matched, got %d", result.Succeeded, len(tasks))
+		}
+
+		for _, task := range tasks {
+			if task.Status != domain.TaskStatusDone {
+				t.Errorf("Expected %s to be done, got %s", task.Key, task.Status)
+			}
+			if len(task.Tags) != 1 || task.Tags[0] != "release-1.4" {
+				t.Errorf("Expected the normalized tag, got %v", task.Tags)
+			}
+		}
+	})
+
+	t.Run("malformed and oversized requests are refused", func(t *testing.T) {
+		requests := map[string]*domain.BulkRequest{
+			"empty":        {},
+			"invalid mode": {Mode: "sometimes", Operations: []domain.BulkOperation{{Op: domain.BulkOperationDelete, ID: existing.String()}}},
+			"empty filter": {Filter: &domain.TaskFilter{}, Patch: &domain.UpdateTaskRequest{}},
+			"filter only":  {Filter: &domain.TaskFilter{Tag: "release-1.4"}},
+			"too many":     {Operations: make([]domain.BulkOperation, 4)},
+		}
+
+		for name, req := range requests {
+			if _, err := bulkService.Apply(ctx, req); !errors.Is(err, domain.ErrInvalidBulkRequest) {
+				t.Errorf("Expected an invalid bulk request error for %s, got %v", name, err)
+			}
+		}
+
+		small := NewBulkService(logger, db, taskService, 2)
+		_, err := small.Apply(ctx, &domain.BulkRequest{
+			Filter: &domain.TaskFilter{ProjectID: domain.DefaultProjectID.String()},
+			Patch:  &domain.UpdateTaskRequest{},
+		})
+		if !errors.Is(err, domain.ErrInvalidBulkRequest) {
+			t.Errorf("Expected a filter matching 3 tasks to exceed the limit of 2, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite/sqlc"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+)
+
+// feedTokenBytes is the amount of randomness in a feed token.
+const feedTokenBytes = 32
+
+// CalendarService manages the calendar feeds clients subscribe to. Calendar
+// apps cannot send bearer tokens, so a feed is authenticated by an unguessable
+// token in its URL. Only a hash of the token is stored.
+type CalendarService struct {
+	logger *log.Logger
+	db     *sqlite.Database
+	tasks  *TaskService
+}
+
+func NewCalendarService(logger *log.Logger, db *sqlite.Database, tasks *TaskService) *CalendarService {
+	return &CalendarService{
+		logger: logger,
+		db:     db,
+		tasks:  tasks,
+	}
+}
+
+// GetWorkflow returns the workflow whose statuses the feeds map to to-do
+// statuses.
+func (s *CalendarService) GetWorkflow() domain.Workflow {
+	return s.tasks.workflow
+}
+
+// CreateFeed issues a new feed token for a client. A previous token of the
+// client stops working.
+func (s *CalendarService) CreateFeed(ctx context.Context, clientID string) (domain.CalendarFeed, error) {
+	secret := make([]byte, feedTokenBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return domain.CalendarFeed{}, fmt.Errorf("create calendar feed: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(secret)
+	now := time.Now().UTC()
+
+	err := s.db.Queries.UpsertCalendarFeed(ctx, sqlc.UpsertCalendarFeedParams{
+		ClientID:  clientID,
+		TokenHash: hashFeedToken(token),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return domain.CalendarFeed{}, fmt.Errorf("create calendar feed: %w", err)
+	}
+
+	return domain.CalendarFeed{Token: token, CreatedAt: now}, nil
+}
+
+// GetFeed returns the feed of a client, without its token.
+func (s *CalendarService) GetFeed(ctx context.Context, clientID string) (domain.CalendarFeed, error) {
+	feed, err := s.db.Queries.GetCalendarFeed(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.CalendarFeed{}, fmt.Errorf("get calendar feed: %w", domain.NotFound("calendar feed not found"))
+		}
+		return domain.CalendarFeed{}, fmt.Errorf("get calendar feed: %w", err)
+	}
+
+	return domain.CalendarFeed{CreatedAt: feed.CreatedAt}, nil
+}
+
+// DeleteFeed revokes the feed token of a client.
+func (s *CalendarService) DeleteFeed(ctx context.Context, clientID string) error {
+	deleted, err := s.db.Queries.DeleteCalendarFeed(ctx, clientID)
+	if err != nil {
+		return fmt.Errorf("delete calendar feed: %w", err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("delete calendar feed: %w", domain.NotFound("calendar feed not found"))
+	}
+	return nil
+}
+
+// FeedTasks passes the tasks of the feed a token belongs to to fn, one at a
+// time. A feed lists the tasks assigned to its client.
+func (s *CalendarService) FeedTasks(ctx context.Context, token string, fn func(task domain.Task) error) error {
+	feed, err := s.db.Queries.GetCalendarFeedByTokenHash(ctx, hashFeedToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("calendar feed: %w", domain.NotFound("calendar feed not found"))
+		}
+		return fmt.Errorf("calendar feed: %w", err)
+	}
+
+	return s.tasks.ExportTasks(ctx, domain.TaskFilter{Assignee: feed.ClientID}, fn)
+}
+
+func hashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/storage"
+)
+
+func TestCalendarService_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, err := sqlite.NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+
+	logger := log.New(os.Stderr, "INTEGRATION_TEST: ", log.LstdFlags)
+	taskService := NewTaskService(logger, db, blobs, domain.DefaultWorkflow())
+	calendarService := NewCalendarService(logger, db, taskService)
+	importService := NewImportService(logger, db, taskService, 100)
+	ctx := context.Background()
+
+	for _, req := range []*domain.CreateTaskRequest{
+		{Title: "Renew certificate", Assignees: []string{"alice"}},
+		{Title: "Review budget", Assignees: []string{"bob"}},
+	} {
+		if _, err := taskService.CreateTask(ctx, req); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+
+	feedTitles := func(token string) ([]string, error) {
+		var titles []string
+		err := calendarService.FeedTasks(ctx, token, func(task domain.Task) error {
+			titles = append(titles, task.Title)
+			return nil
+		})
+		return titles, err
+	}
+
+	t.Run("feeds list the tasks assigned to their client", func(t *testing.T) {
+		feed, err := calendarService.CreateFeed(ctx, "alice")
+		if err != nil {
+			t.Fatalf("Failed to create feed: %v", err)
+		}
+		if len(feed.Token) < 40 {
+			t.Errorf("Expected an unguessable token, got %q", feed.Token)
+		}
+
+		titles, err := feedTitles(feed.Token)
+		if err != nil {
+			t.Fatalf("Failed to read feed: %v", err)
+		}
+		if len(titles) != 1 || titles[0] != "Renew certificate" {
+			t.Errorf("Expected alice's task only, got %v", titles)
+		}
+
+		stored, err := calendarService.GetFeed(ctx, "alice")
+		if err != nil {
+			t.Fatalf("Failed to get feed: %v", err)
+		}
+		if stored.Token != "" || !stored.CreatedAt.Equal(feed.CreatedAt) {
+			t.Errorf("Expected the feed without its token, got %+v", stored)
+		}
+	})
+
+	t.Run("creating a feed again replaces the token", func(t *testing.T) {
+		first, err := calendarService.CreateFeed(ctx, "bob")
+		if err != nil {
+			t.Fatalf("Failed to create feed: %v", err)
+		}
+		second, err := calendarService.CreateFeed(ctx, "bob")
+		if err != nil {
+			t.Fatalf("Failed to create feed: %v", err)
+		}
+
+		if _, err := feedTitles(first.Token); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Expected the old token to stop working, got %v", err)
+		}
+		if titles, err := feedTitles(second.Token); err != nil || len(titles) != 1 {
+			t.Errorf("Expected the new token to work, got %v, %v", titles, err)
+		}
+	})
+
+	t.Run("deleted feeds stop working", func(t *testing.T) {
+		feed, err := calendarService.CreateFeed(ctx, "carol")
+		if err != nil {
+			t.Fatalf("Failed to create feed: %v", err)
+		}
+		if err := calendarService.DeleteFeed(ctx, "carol"); err != nil {
+			t.Fatalf("Failed to delete feed: %v", err)
+		}
+
+		if _, err := feedTitles(feed.Token); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Expected a revoked token to be unknown, got %v", err)
+		}
+		if err := calendarService.DeleteFeed(ctx, "carol"); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Expected deleting a missing feed to fail, got %v", err)
+		}
+		if _, err := calendarService.GetFeed(ctx, "carol"); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Expected no feed, got %v", err)
+		}
+	})
+
+	t.Run("to-dos are imported", func(t *testing.T) {
+		data := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"VERSION:2.0",
+			"BEGIN:VTODO",
+			"UID:todo-1@example.com",
+			"SUMMARY:Book flights",
+			"STATUS:COMPLETED",
+			"PRIORITY:2",
+			"CATEGORIES:travel",
+			"DUE;VALUE=DATE:20250410",
+			"END:VTODO",
+			"END:VCALENDAR",
+		}, "\r\n")
+
+		result, err := importService.Import(ctx, &domain.ImportRequest{Format: domain.ImportFormatICS}, strings.NewReader(data))
+		if err != nil {
+			t.Fatalf("Failed to import: %v", err)
+		}
+		if result.Created != 1 {
+			t.Fatalf("Expected 1 created task, got %+v", result)
+		}
+
+		task, err := taskService.GetTask(ctx, result.Rows[0].ID)
+		if err != nil {
+			t.Fatalf("Failed to get task: %v", err)
+		}
+		if task.ExternalID != "todo-1@example.com" || task.Status != domain.TaskStatusDone || task.Priority != domain.TaskPriorityHigh {
+			t.Errorf("Expected the to-do to be imported, got %+v", task)
+		}
+		if len(task.Tags) != 1 || task.DueAt == nil || task.DueAt.Format("2006-01-02") != "2025-04-10" {
+			t.Errorf("Expected the tag and due date to be imported, got %v %v", task.Tags, task.DueAt)
+		}
+	})
+}
@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite/sqlc"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultCommentPageSize = 50
+	maxCommentPageSize     = 200
+)
+
+type CommentService struct {
+	logger *log.Logger
+	db     *sqlite.Database
+}
+
+func NewCommentService(logger *log.Logger, db *sqlite.Database) *CommentService {
+	return &CommentService{
+		logger: logger,
+		db:     db,
+	}
+}
+
+func (s *CommentService) CreateComment(ctx context.Context, taskID string, author string, req *domain.CreateCommentRequest) (domain.Comment, error) {
+	if taskID == "" {
+		return domain.Comment{}, fmt.Errorf("create comment: %w", domain.Invalid("id", "task id is required"))
+	}
+
+	if author == "" {
+		return domain.Comment{}, fmt.Errorf("create comment: %w", domain.Invalid("author", "author is required"))
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return domain.Comment{}, fmt.Errorf("create comment: %w", domain.Invalid("Body", "body is required"))
+	}
+
+	if _, err := s.db.Queries.GetTask(ctx, taskID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Comment{}, fmt.Errorf("create comment: %w", domain.NotFound("task not found"))
+		}
+		return domain.Comment{}, fmt.Errorf("create comment: %w", err)
+	}
+
+	now := time.Now().UTC()
+	comment := sqlc.TaskComment{
+		ID:        uuid.New().String(),
+		TaskID:    taskID,
+		Author:    author,
+		Body:      body,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	err := s.db.Queries.CreateTaskComment(ctx, sqlc.CreateTaskCommentParams{
+		ID:        comment.ID,
+		TaskID:    comment.TaskID,
+		Author:    comment.Author,
+		Body:      comment.Body,
+		CreatedAt: comment.CreatedAt,
+		UpdatedAt: comment.UpdatedAt,
+	})
+	if err != nil {
+		return domain.Comment{}, fmt.Errorf("create comment: %w", err)
+	}
+
+	return commentToDomain(comment), nil
+}
+
+func (s *CommentService) ListComments(ctx context.Context, taskID string, limit int, offset int) (domain.CommentPage, error) {
+	if taskID == "" {
+		return domain.CommentPage{}, fmt.Errorf("list comments: %w", domain.Invalid("id", "task id is required"))
+	}
+
+	if limit <= 0 {
+		limit = defaultCommentPageSize
+	}
+
+	if limit > maxCommentPageSize {
+		limit = maxCommentPageSize
+	}
+
+	if offset < 0 {
+		return domain.CommentPage{}, fmt.Errorf("list comments: %w", domain.Invalid("offset", "offset must not be negative"))
+	}
+
+	if _, err := s.db.Queries.GetTask(ctx, taskID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.CommentPage{}, fmt.Errorf("list comments: %w", domain.NotFound("task not found"))
+		}
+		return domain.CommentPage{}, fmt.Errorf("list comments: %w", err)
+	}
+
+	total, err := s.db.Queries.CountTaskComments(ctx, taskID)
+	if err != nil {
+		return domain.CommentPage{}, fmt.Errorf("list comments: %w", err)
+	}
+
+	comments, err := s.db.Queries.ListTaskComments(ctx, sqlc.ListTaskCommentsParams{
+		TaskID: taskID,
+		Limit:  int64(limit),
+		Offset: int64(offset),
+	})
+	if err != nil {
+		return domain.CommentPage{}, fmt.Errorf("list comments: %w", err)
+	}
+
+	domainComments := make([]domain.Comment, len(comments))
+	for i, comment := range comments {
+		domainComments[i] = commentToDomain(comment)
+	}
+
+	return domain.CommentPage{
+		Comments: domainComments,
+		Total:    total,
+		Limit:    limit,
+		Offset:   offset,
+	}, nil
+}
+
+func (s *CommentService) GetComment(ctx context.Context, taskID string, commentID string) (domain.Comment, error) {
+	if taskID == "" || commentID == "" {
+		return domain.Comment{}, fmt.Errorf("get comment: %w", domain.Invalid("id", "task id and comment id are required"))
+	}
+
+	comment, err := s.db.Queries.GetTaskComment(ctx, sqlc.GetTaskCommentParams{ID: commentID, TaskID: taskID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Comment{}, fmt.Errorf("get comment: %w", domain.NotFound("comment not found"))
+		}
+		return domain.Comment{}, fmt.Errorf("get comment: %w", err)
+	}
+
+	return commentToDomain(comment), nil
+}
+
+// UpdateComment replaces the body of a comment, keeping the previous body as
+// a revision. Only the original author may edit a comment.
+func (s *CommentService) UpdateComment(ctx context.Context, taskID string, commentID string, editor string, req *domain.UpdateCommentRequest) (domain.Comment, error) {
+	if taskID == "" || commentID == "" {
+		return domain.Comment{}, fmt.Errorf("update comment: %w", domain.Invalid("id", "task id and comment id are required"))
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return domain.Comment{}, fmt.Errorf("update comment: %w", domain.Invalid("Body", "body is required"))
+	}
+
+	var updated sqlc.TaskComment
+	err := s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		comment, err := getOwnComment(ctx, q, taskID, commentID, editor)
+		if err != nil {
+			return fmt.Errorf("update comment: %w", err)
+		}
+
+		updated = comment
+		if comment.Body == body {
+			return nil
+		}
+
+		now := time.Now().UTC()
+		err = q.CreateTaskCommentRevision(ctx, sqlc.CreateTaskCommentRevisionParams{
+			CommentID: comment.ID,
+			Body:      comment.Body,
+			EditedBy:  editor,
+			EditedAt:  now,
+		})
+		if err != nil {
+			return fmt.Errorf("update comment: %w", err)
+		}
+
+		_, err = q.UpdateTaskCommentBody(ctx, sqlc.UpdateTaskCommentBodyParams{
+			Body:      body,
+			UpdatedAt: now,
+			ID:        comment.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("update comment: %w", err)
+		}
+
+		updated.Body = body
+		updated.UpdatedAt = now
+		return nil
+	})
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	return commentToDomain(updated), nil
+}
+
+// DeleteComment hides a comment from listings while keeping the row and its
+// edit history. Only the original author may delete a comment.
+func (s *CommentService) DeleteComment(ctx context.Context, taskID string, commentID string, actor string) error {
+	if taskID == "" || commentID == "" {
+		return fmt.Errorf("delete comment: %w", domain.Invalid("id", "task id and comment id are required"))
+	}
+
+	return s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		if _, err := getOwnComment(ctx, q, taskID, commentID, actor); err != nil {
+			return fmt.Errorf("delete comment: %w", err)
+		}
+
+		_, err := q.SoftDeleteTaskComment(ctx, sqlc.SoftDeleteTaskCommentParams{
+			DeletedAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+			ID:        commentID,
+			TaskID:    taskID,
+		})
+		if err != nil {
+			return fmt.Errorf("delete comment: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (s *CommentService) GetCommentHistory(ctx context.Context, taskID string, commentID string) ([]domain.CommentRevision, error) {
+	if taskID == "" || commentID == "" {
+		return nil, fmt.Errorf("get comment history: %w", domain.Invalid("id", "task id and comment id are required"))
+	}
+
+	if _, err := s.db.Queries.GetTaskComment(ctx, sqlc.GetTaskCommentParams{ID: commentID, TaskID: taskID}); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("get comment history: %w", domain.NotFound("comment not found"))
+		}
+		return nil, fmt.Errorf("get comment history: %w", err)
+	}
+
+	revisions, err := s.db.Queries.ListTaskCommentRevisions(ctx, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("get comment history: %w", err)
+	}
+
+	domainRevisions := make([]domain.CommentRevision, len(revisions))
+	for i, revision := range revisions {
+		domainRevisions[i] = domain.CommentRevision{
+			Body:     revision.Body,
+			EditedBy: revision.EditedBy,
+			EditedAt: revision.EditedAt,
+		}
+	}
+
+	return domainRevisions, nil
+}
+
+func getOwnComment(ctx context.Context, q *sqlc.Queries, taskID string, commentID string, actor string) (sqlc.TaskComment, error) {
+	comment, err := q.GetTaskComment(ctx, sqlc.GetTaskCommentParams{ID: commentID, TaskID: taskID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sqlc.TaskComment{}, domain.NotFound("comment not found")
+		}
+		return sqlc.TaskComment{}, err
+	}
+
+	if comment.Author != actor {
+		return sqlc.TaskComment{}, domain.Forbidden("only the author can change a comment")
+	}
+
+	return comment, nil
+}
+
+func commentToDomain(comment sqlc.TaskComment) domain.Comment {
+	return domain.Comment{
+		ID:        uuid.MustParse(comment.ID),
+		TaskID:    uuid.MustParse(comment.TaskID),
+		Author:    comment.Author,
+		Body:      comment.Body,
+		Edited:    comment.UpdatedAt.After(comment.CreatedAt),
+		CreatedAt: comment.CreatedAt,
+		UpdatedAt: comment.UpdatedAt,
+	}
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/storage"
+	"github.com/google/uuid"
+)
+
+func TestCommentService_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, err := sqlite.NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	logger := log.New(os.Stderr, "INTEGRATION_TEST: ", log.LstdFlags)
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+
+	taskService := NewTaskService(logger, db, blobs, domain.DefaultWorkflow())
+	commentService := NewCommentService(logger, db)
+	ctx := context.Background()
+
+	taskID, err := taskService.CreateTask(ctx, &domain.CreateTaskRequest{Title: "Discussed task"})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	var first domain.Comment
+	for i, body := range []string{"first", "second", "third"} {
+		comment, err := commentService.CreateComment(ctx, taskID.String(), "client-a", &domain.CreateCommentRequest{Body: body})
+		if err != nil {
+			t.Fatalf("Failed to create comment: %v", err)
+		}
+		if i == 0 {
+			first = comment
+		}
+	}
+
+	t.Run("list is paginated in time order", func(t *testing.T) {
+		page, err := commentService.ListComments(ctx, taskID.String(), 2, 1)
+		if err != nil {
+			t.Fatalf("Failed to list comments: %v", err)
+		}
+
+		if page.Total != 3 {
+			t.Errorf("Expected total 3, got %d", page.Total)
+		}
+
+		if len(page.Comments) != 2 || page.Comments[0].Body != "second" || page.Comments[1].Body != "third" {
+			t.Errorf("Expected [second third], got %+v", page.Comments)
+		}
+	})
+
+	t.Run("edit keeps history", func(t *testing.T) {
+		updated, err := commentService.UpdateComment(ctx, taskID.String(), first.ID.String(), "client-a", &domain.UpdateCommentRequest{Body: "first, edited"})
+		if err != nil {
+			t.Fatalf("Failed to update comment: %v", err)
+		}
+
+		if updated.Body != "first, edited" || !updated.Edited {
+			t.Errorf("Expected edited comment, got %+v", updated)
+		}
+
+		history, err := commentService.GetCommentHistory(ctx, taskID.String(), first.ID.String())
+		if err != nil {
+			t.Fatalf("Failed to get history: %v", err)
+		}
+
+		if len(history) != 1 || history[0].Body != "first" || history[0].EditedBy != "client-a" {
+			t.Errorf("Expected one revision with the original body, got %+v", history)
+		}
+	})
+
+	t.Run("only the author can edit", func(t *testing.T) {
+		_, err := commentService.UpdateComment(ctx, taskID.String(), first.ID.String(), "client-b", &domain.UpdateCommentRequest{Body: "hijacked"})
+
+		if err == nil {
+			t.Fatal("Expected error for non-author edit, got nil")
+		}
+
+		expectedError := "update comment: only the author can change a comment"
+		if err.Error() != expectedError {
+			t.Errorf("Expected error %q, got %q", expectedError, err.Error())
+		}
+	})
+
+	t.Run("comments are fetched by ID", func(t *testing.T) {
+		comment, err := commentService.GetComment(ctx, taskID.String(), first.ID.String())
+		if err != nil {
+			t.Fatalf("Failed to get comment: %v", err)
+		}
+		if comment.ID != first.ID || comment.TaskID != taskID {
+			t.Errorf("Expected comment %s, got %+v", first.ID, comment)
+		}
+
+		if _, err := commentService.GetComment(ctx, uuid.NewString(), first.ID.String()); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Expected a comment of another task to be not found, got %v", err)
+		}
+	})
+
+	t.Run("deleted comments are hidden", func(t *testing.T) {
+		if err := commentService.DeleteComment(ctx, taskID.String(), first.ID.String(), "client-a"); err != nil {
+			t.Fatalf("Failed to delete comment: %v", err)
+		}
+
+		page, err := commentService.ListComments(ctx, taskID.String(), 0, 0)
+		if err != nil {
+			t.Fatalf("Failed to list comments: %v", err)
+		}
+
+		if page.Total != 2 {
+			t.Errorf("Expected 2 remaining comments, got %d", page.Total)
+		}
+
+		if _, err := commentService.GetComment(ctx, taskID.String(), first.ID.String()); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Expected the deleted comment to be not found, got %v", err)
+		}
+	})
+
+	t.Run("comments are removed when the task is purged", func(t *testing.T) {
+		if err := taskService.PurgeTask(ctx, taskID.String()); err != nil {
+			t.Fatalf("Failed to purge task: %v", err)
+		}
+
+		count, err := db.Queries.CountTaskComments(ctx, taskID.String())
+		if err != nil {
+			t.Fatalf("Failed to count comments: %v", err)
+		}
+
+		if count != 0 {
+			t.Errorf("Expected comments to be deleted with the task, got %d", count)
+		}
+	})
+}
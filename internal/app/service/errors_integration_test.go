@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/storage"
+	"github.com/google/uuid"
+)
+
+func TestErrorKinds_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, err := sqlite.NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+
+	logger := log.New(os.Stderr, "INTEGRATION_TEST: ", log.LstdFlags)
+	taskService := NewTaskService(logger, db, blobs, domain.DefaultWorkflow())
+	projectService := NewProjectService(logger, db)
+	commentService := NewCommentService(logger, db)
+	ctx := context.Background()
+
+	id, err := taskService.CreateTask(ctx, &domain.CreateTaskRequest{Title: "Write release notes"})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	t.Run("unknown tasks are not found", func(t *testing.T) {
+		if _, err := taskService.GetTask(ctx, uuid.New().String()); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Expected not found by ID, got %v", err)
+		}
+		if _, err := taskService.GetTask(ctx, "TASK-999"); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Expected not found by key, got %v", err)
+		}
+		if err := taskService.DeleteTask(ctx, uuid.New().String(), nil); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Expected not found on delete, got %v", err)
+		}
+	})
+
+	t.Run("invalid input names the field", func(t *testing.T) {
+		_, err := taskService.CreateTask(ctx, &domain.CreateTaskRequest{Title: "Plan", Priority: "urgent"})
+		if !errors.Is(err, domain.ErrValidation) {
+			t.Fatalf("Expected a validation error, got %v", err)
+		}
+
+		var validationErr *domain.ValidationError
+		if !errors.As(err, &validationErr) || len(validationErr.Fields) != 1 || validationErr.Fields[0].Field != "Priority" {
+			t.Errorf("Expected the Priority field to be reported, got %v", err)
+		}
+
+		_, err = taskService.CreateTask(ctx, &domain.CreateTaskRequest{Title: "Plan", RRule: "FREQ=HOURLY"})
+		if !errors.As(err, &validationErr) || validationErr.Fields[0].Field != "RRule" {
+			t.Errorf("Expected the RRule field to be reported, got %v", err)
+		}
+	})
+
+	t.Run("state conflicts are conflicts", func(t *testing.T) {
+		if err := projectService.DeleteProject(ctx, domain.DefaultProjectID.String()); !errors.Is(err, domain.ErrConflict) {
+			t.Errorf("Expected deleting the default project to conflict, got %v", err)
+		}
+
+		_, err := taskService.RestoreTask(ctx, id.String())
+		if !errors.Is(err, domain.ErrConflict) {
+			t.Errorf("Expected restoring a live task to conflict, got %v", err)
+		}
+
+		done, toDo := domain.TaskStatusDone, domain.TaskStatusToDo
+		if err := taskService.UpdateTask(ctx, id.String(), &domain.UpdateTaskRequest{Status: &done}, nil); err != nil {
+			t.Fatalf("Failed to complete task: %v", err)
+		}
+
+		err = taskService.UpdateTask(ctx, id.String(), &domain.UpdateTaskRequest{Status: &toDo}, nil)
+		var transitionErr *domain.TransitionError
+		if !errors.Is(err, domain.ErrConflict) || !errors.As(err, &transitionErr) {
+			t.Errorf("Expected a refused transition to be a conflict, got %v", err)
+		}
+	})
+
+	t.Run("changing another client's comment is forbidden", func(t *testing.T) {
+		comment, err := commentService.CreateComment(ctx, id.String(), "alice", &domain.CreateCommentRequest{Body: "Draft is ready"})
+		if err != nil {
+			t.Fatalf("Failed to create comment: %v", err)
+		}
+
+		_, err = commentService.UpdateComment(ctx, id.String(), comment.ID.String(), "bob", &domain.UpdateCommentRequest{Body: "Not quite"})
+		if !errors.Is(err, domain.ErrForbidden) {
+			t.Errorf("Expected a forbidden error, got %v", err)
+		}
+	})
+}
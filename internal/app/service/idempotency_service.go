@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite/sqlc"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+)
+
+// IdempotencyService remembers the responses to requests sent with an
+// Idempotency-Key, so that retries get the same response instead of repeating
+// the operation.
+type IdempotencyService struct {
+	logger *log.Logger
+	db     *sqlite.Database
+	ttl    time.Duration
+}
+
+func NewIdempotencyService(logger *log.Logger, db *sqlite.Database, ttl time.Duration) *IdempotencyService {
+	return &IdempotencyService{
+		logger: logger,
+		db:     db,
+		ttl:    ttl,
+	}
+}
+
+// Reserve claims a key for a request. It returns true when the caller should
+// process the request and Complete or Release the key afterwards; otherwise
+// it returns the record of the earlier request that holds the key. Expired
+// keys are reused.
+func (s *IdempotencyService) Reserve(ctx context.Context, clientID, key, requestHash string) (domain.IdempotencyRecord, bool, error) {
+	var existing domain.IdempotencyRecord
+	reserved := false
+
+	err := s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		now := time.Now().UTC()
+
+		record, err := q.GetIdempotencyKey(ctx, sqlc.GetIdempotencyKeyParams{ClientID: clientID, Key: key})
+		switch {
+		case err == nil && record.ExpiresAt.After(now):
+			existing, err = idempotencyRecordToDomain(record)
+			return err
+		case err == nil:
+			if err := q.DeleteIdempotencyKey(ctx, sqlc.DeleteIdempotencyKeyParams{ClientID: clientID, Key: key}); err != nil {
+				return err
+			}
+		case !errors.Is(err, sql.ErrNoRows):
+			return err
+		}
+
+		err = q.CreateIdempotencyKey(ctx, sqlc.CreateIdempotencyKeyParams{
+			ClientID:    clientID,
+			Key:         key,
+			RequestHash: requestHash,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(s.ttl),
+		})
+		if err != nil {
+			return err
+		}
+
+		reserved = true
+		return nil
+	})
+	if err != nil {
+		return domain.IdempotencyRecord{}, false, fmt.Errorf("reserve idempotency key: %w", err)
+	}
+
+	return existing, reserved, nil
+}
+
+// Complete stores the response to a reserved key.
+func (s *IdempotencyService) Complete(ctx context.Context, clientID, key string, response domain.StoredResponse) error {
+	header, err := json.Marshal(response.Header)
+	if err != nil {
+		return fmt.Errorf("complete idempotency key: %w", err)
+	}
+
+	err = s.db.Queries.CompleteIdempotencyKey(ctx, sqlc.CompleteIdempotencyKeyParams{
+		StatusCode: sql.NullInt64{Int64: int64(response.StatusCode), Valid: true},
+		Headers:    sql.NullString{String: string(header), Valid: true},
+		Body:       response.Body,
+		ClientID:   clientID,
+		Key:        key,
+	})
+	if err != nil {
+		return fmt.Errorf("complete idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+// Release frees a reserved key without storing a response, so that the
+// request can be retried.
+func (s *IdempotencyService) Release(ctx context.Context, clientID, key string) error {
+	if err := s.db.Queries.DeleteIdempotencyKey(ctx, sqlc.DeleteIdempotencyKeyParams{ClientID: clientID, Key: key}); err != nil {
+		return fmt.Errorf("release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// PurgeExpired deletes the keys whose TTL has passed and returns how many were
+// deleted. It is run periodically by the application.
+func (s *IdempotencyService) PurgeExpired(ctx context.Context, now time.Time) (int, error) {
+	deleted, err := s.db.Queries.DeleteExpiredIdempotencyKeys(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("purge idempotency keys: %w", err)
+	}
+	return int(deleted), nil
+}
+
+func idempotencyRecordToDomain(record sqlc.IdempotencyKey) (domain.IdempotencyRecord, error) {
+	result := domain.IdempotencyRecord{
+		RequestHash: record.RequestHash,
+		ExpiresAt:   record.ExpiresAt,
+	}
+
+	if record.StatusCode.Valid {
+		response := &domain.StoredResponse{
+			StatusCode: int(record.StatusCode.Int64),
+			Body:       record.Body,
+		}
+		if record.Headers.Valid {
+			if err := json.Unmarshal([]byte(record.Headers.String), &response.Header); err != nil {
+				return domain.IdempotencyRecord{}, err
+			}
+		}
+		result.Response = response
+	}
+
+	return result, nil
+}
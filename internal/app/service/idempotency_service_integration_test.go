@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+)
+
+func TestIdempotencyService_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, err := sqlite.NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	logger := log.New(os.Stderr, "INTEGRATION_TEST: ", log.LstdFlags)
+	idempotencyService := NewIdempotencyService(logger, db, time.Hour)
+	ctx := context.Background()
+
+	t.Run("a key is reserved once per client", func(t *testing.T) {
+		if _, reserved, err := idempotencyService.Reserve(ctx, "client-a", "key-1", "hash-1"); err != nil || !reserved {
+			t.Fatalf("Expected the key to be reserved, got %v (%v)", reserved, err)
+		}
+
+		record, reserved, err := idempotencyService.Reserve(ctx, "client-a", "key-1", "hash-1")
+		if err != nil {
+			t.Fatalf("Failed to reserve key: %v", err)
+		}
+		if reserved || record.Response != nil {
+			t.Errorf("Expected the key to be held by a request in progress, got %+v", record)
+		}
+
+		if _, reserved, err := idempotencyService.Reserve(ctx, "client-b", "key-1", "hash-2"); err != nil || !reserved {
+			t.Errorf("Expected keys to be scoped per client, got %v (%v)", reserved, err)
+		}
+	})
+
+	t.Run("completed responses are returned to retries", func(t *testing.T) {
+		err := idempotencyService.Complete(ctx, "client-a", "key-1", domain.StoredResponse{
+			StatusCode: 201,
+			Header:     map[string][]string{"Content-Type": {"application/json"}},
+			Body:       []byte(`{"id":"1"}`),
+		})
+		if err != nil {
+			t.Fatalf("Failed to complete key: %v", err)
+		}
+
+		record, reserved, err := idempotencyService.Reserve(ctx, "client-a", "key-1", "hash-1")
+		if err != nil {
+			t.Fatalf("Failed to reserve key: %v", err)
+		}
+		if reserved || record.Response == nil {
+			t.Fatalf("Expected the stored response, got %+v", record)
+		}
+		if record.RequestHash != "hash-1" || record.Response.StatusCode != 201 || string(record.Response.Body) != `{"id":"1"}` {
+			t.Errorf("Expected the stored 201 response, got %+v", record.Response)
+		}
+		if record.Response.Header["Content-Type"][0] != "application/json" {
+			t.Errorf("Expected stored headers, got %v", record.Response.Header)
+		}
+	})
+
+	t.Run("released keys can be reused", func(t *testing.T) {
+		if err := idempotencyService.Release(ctx, "client-b", "key-1"); err != nil {
+			t.Fatalf("Failed to release key: %v", err)
+		}
+
+		if _, reserved, err := idempotencyService.Reserve(ctx, "client-b", "key-1", "hash-3"); err != nil || !reserved {
+			t.Errorf("Expected the released key to be reserved again, got %v (%v)", reserved, err)
+		}
+	})
+
+	t.Run("expired keys are purged and reusable", func(t *testing.T) {
+		purged, err := idempotencyService.PurgeExpired(ctx, time.Now().Add(2*time.Hour))
+		if err != nil {
+			t.Fatalf("Failed to purge keys: %v", err)
+		}
+		if purged != 2 {
+			t.Errorf("Expected 2 purged keys, got %d", purged)
+		}
+
+		if _, reserved, err := idempotencyService.Reserve(ctx, "client-a", "key-1", "hash-4"); err != nil || !reserved {
+			t.Errorf("Expected the expired key to be reserved again, got %v (%v)", reserved, err)
+		}
+	})
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite/sqlc"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/taskio"
+)
+
+// errImportDryRun rolls back the transaction of a dry run once every row has
+// been validated.
+var errImportDryRun = errors.New("import dry run")
+
+// ImportService creates and updates tasks from CSV and JSON Lines files.
+type ImportService struct {
+	logger  *log.Logger
+	db      *sqlite.Database
+	tasks   *TaskService
+	maxRows int
+}
+
+func NewImportService(logger *log.Logger, db *sqlite.Database, tasks *TaskService, maxRows int) *ImportService {
+	return &ImportService{
+		logger:  logger,
+		db:      db,
+		tasks:   tasks,
+		maxRows: maxRows,
+	}
+}
+
+// Import reads a file and applies every row in one transaction. A row whose
+// ExternalID matches a previously imported task updates that task, or fails
+// as a conflict while that task is in the trash; any other row creates a task. Each row runs in its own savepoint, so failing rows are
+// reported and skipped without affecting the others. A dry run applies the
+// rows the same way and then rolls everything back. The whole file is read
+// before the transaction starts, so a slow upload does not hold the database.
+func (s *ImportService) Import(ctx context.Context, req *domain.ImportRequest, data io.Reader) (domain.ImportResult, error) {
+	rows, err := s.readRows(req, data)
+	if err != nil {
+		return domain.ImportResult{}, fmt.Errorf("import tasks: %w", err)
+	}
+
+	result := domain.ImportResult{DryRun: req.DryRun, Rows: []domain.ImportRowResult{}}
+
+	err = s.db.WithSavepoints(ctx, func(q *sqlc.Queries, savepoint sqlite.Savepoint) error {
+		for _, row := range rows {
+			item := domain.ImportRowResult{Line: row.Line, ExternalID: row.Fields["ExternalID"]}
+
+			err := row.Err
+			if err == nil {
+				err = savepoint(func() error {
+					return s.importRow(ctx, q, row.Fields, &item)
+				})
+			}
+
+			if err != nil {
+				item.ID = ""
+				item.Action = domain.ImportActionFailed
+				item.Error = err.Error()
+				result.Failed++
+			} else if item.Action == domain.ImportActionCreated {
+				result.Created++
+			} else {
+				result.Updated++
+			}
+			result.Rows = append(result.Rows, item)
+		}
+
+		if req.DryRun {
+			return errImportDryRun
+		}
+		return nil
+	})
+
+	if err != nil && !errors.Is(err, errImportDryRun) {
+		return domain.ImportResult{}, fmt.Errorf("import tasks: %w", err)
+	}
+
+	return result, nil
+}
+
+// readRows reads every row of a file, refusing files with more than maxRows
+// rows.
+func (s *ImportService) readRows(req *domain.ImportRequest, data io.Reader) ([]taskio.Row, error) {
+	reader, err := taskio.NewReader(req.Format, data, req.Mapping, s.tasks.workflow)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []taskio.Row{}
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, domain.Invalid("file", "read file: %v", err)
+		}
+
+		if len(rows) == s.maxRows {
+			return nil, domain.Invalid("file", "at most %d rows can be imported at once", s.maxRows)
+		}
+		rows = append(rows, row)
+	}
+}
+
+// importRow creates or updates the task described by a row.
+func (s *ImportService) importRow(ctx context.Context, q *sqlc.Queries, fields map[string]string, item *domain.ImportRowResult) error {
+	create, update, err := parseImportRow(fields)
+	if err != nil {
+		return err
+	}
+
+	externalID := sql.NullString{String: item.ExternalID, Valid: item.ExternalID != ""}
+	if externalID.Valid {
+		existing, err := q.GetTaskByExternalID(ctx, externalID)
+		switch {
+		case err == nil:
+			if existing.DeletedAt.Valid {
+				return domain.Conflict("task with external ID %q is in the trash", item.ExternalID)
+			}
+			item.ID = existing.ID
+			item.Action = domain.ImportActionUpdated
+			return s.tasks.updateTask(ctx, q, existing.ID, update, nil)
+		case !errors.Is(err, sql.ErrNoRows):
+			return err
+		}
+	}
+
+	id, err := s.tasks.createTask(ctx, q, create)
+	if err != nil {
+		return err
+	}
+
+	if externalID.Valid {
+		if err := q.SetTaskExternalID(ctx, sqlc.SetTaskExternalIDParams{ExternalID: externalID, ID: id.String()}); err != nil {
+			return err
+		}
+	}
+
+	item.ID = id.String()
+	item.Action = domain.ImportActionCreated
+	return nil
+}
+
+// parseImportRow turns the raw fields of a row into a request creating the
+// task and one updating it. Empty fields are left out of the update, so
+// blank cells never clear a field of an existing task. ProjectID only
+// applies to new tasks.
+func parseImportRow(fields map[string]string) (*domain.CreateTaskRequest, *domain.UpdateTaskRequest, error) {
+	create := &domain.CreateTaskRequest{
+		ProjectID:   fields["ProjectID"],
+		Title:       fields["Title"],
+		Description: fields["Description"],
+		Status:      domain.TaskStatus(fields["Status"]),
+		Priority:    domain.TaskPriority(fields["Priority"]),
+		Assignees:   splitList(fields["Assignees"]),
+		Tags:        splitList(fields["Tags"]),
+	}
+	update := &domain.UpdateTaskRequest{}
+
+	if value := fields["DueAt"]; value != "" {
+		dueAt, err := parseImportTime(value)
+		if err != nil {
+			return nil, nil, domain.Invalid("DueAt", "invalid due date %q: use RFC 3339 or YYYY-MM-DD", value)
+		}
+		create.DueAt = &dueAt
+		update.DueAt = &dueAt
+	}
+
+	if create.Title != "" {
+		update.Title = &create.Title
+	}
+	if create.Description != "" {
+		update.Description = &create.Description
+	}
+	if create.Status != "" {
+		update.Status = &create.Status
+	}
+	if create.Priority != "" {
+		update.Priority = &create.Priority
+	}
+	if create.Assignees != nil {
+		update.Assignees = &create.Assignees
+	}
+	if create.Tags != nil {
+		update.Tags = &create.Tags
+	}
+
+	return create, update, nil
+}
+
+// splitList splits a list field, dropping empty items. An empty field is nil.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, taskio.ListSeparator) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseImportTime accepts an RFC 3339 time or a date, taken as midnight UTC.
+func parseImportTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
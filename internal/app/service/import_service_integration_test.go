@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/storage"
+)
+
+func TestImportService_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, err := sqlite.NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+
+	logger := log.New(os.Stderr, "INTEGRATION_TEST: ", log.LstdFlags)
+	taskService := NewTaskService(logger, db, blobs, domain.DefaultWorkflow())
+	importService := NewImportService(logger, db, taskService, 3)
+	ctx := context.Background()
+
+	csvImport := &domain.ImportRequest{Format: domain.ImportFormatCSV}
+
+	t.Run("csv rows create tasks", func(t *testing.T) {
+		data := "ExternalID,Title,Priority,Assignees,Tags,DueAt\n" +
+			"ROW-1,Order laptops,high,alice;bob,hardware,2025-04-01\n" +
+			"ROW-2,Book venue,,carol,,\n"
+
+		result, err := importService.Import(ctx, csvImport, strings.NewReader(data))
+		if err != nil {
+			t.Fatalf("Failed to import: %v", err)
+		}
+		if result.Created != 2 || result.Updated != 0 || result.Failed != 0 {
+			t.Fatalf("Expected 2 created tasks, got %+v", result)
+		}
+
+		task, err := taskService.GetTask(ctx, result.Rows[0].ID)
+		if err != nil {
+			t.Fatalf("Failed to get task: %v", err)
+		}
+		if task.ExternalID != "ROW-1" || task.Priority != domain.TaskPriorityHigh || len(task.Assignees) != 2 {
+			t.Errorf("Expected the row to be imported, got %+v", task)
+		}
+		if task.DueAt == nil || task.DueAt.Format("2006-01-02") != "2025-04-01" {
+			t.Errorf("Expected due date 2025-04-01, got %v", task.DueAt)
+		}
+	})
+
+	t.Run("rows with a known external ID update the task", func(t *testing.T) {
+		data := "ExternalID,Title,Status\nROW-1,,in_progress\n"
+
+		result, err := importService.Import(ctx, csvImport, strings.NewReader(data))
+		if err != nil {
+			t.Fatalf("Failed to import: %v", err)
+		}
+		if result.Updated != 1 || result.Rows[0].Action != domain.ImportActionUpdated {
+			t.Fatalf("Expected 1 updated task, got %+v", result)
+		}
+
+		task, err := taskService.GetTask(ctx, result.Rows[0].ID)
+		if err != nil {
+			t.Fatalf("Failed to get task: %v", err)
+		}
+		if task.Status != domain.TaskStatusInProgress || task.Title != "Order laptops" {
+			t.Errorf("Expected only the status to change, got %q %q", task.Status, task.Title)
+		}
+	})
+
+	t.Run("rows of trashed tasks are reported as conflicts", func(t *testing.T) {
+		created, err := importService.Import(ctx, csvImport, strings.NewReader("ExternalID,Title\nROW-5,Plan offsite\n"))
+		if err != nil || created.Created != 1 {
+			t.Fatalf("Failed to import: %+v (%v)", created, err)
+		}
+		id := created.Rows[0].ID
+
+		task, err := taskService.GetTask(ctx, id)
+		if err != nil {
+			t.Fatalf("Failed to get task: %v", err)
+		}
+		if task.Version != 2 {
+			t.Errorf("Expected setting the external ID to bump the version to 2, got %d", task.Version)
+		}
+
+		if err := taskService.DeleteTask(ctx, id, nil); err != nil {
+			t.Fatalf("Failed to delete task: %v", err)
+		}
+
+		result, err := importService.Import(ctx, csvImport, strings.NewReader("ExternalID,Title\nROW-5,Plan retreat\n"))
+		if err != nil {
+			t.Fatalf("Failed to import: %v", err)
+		}
+		if result.Failed != 1 || result.Rows[0].Action != domain.ImportActionFailed || !strings.Contains(result.Rows[0].Error, "trash") {
+			t.Fatalf("Expected the row to fail because its task is in the trash, got %+v", result)
+		}
+
+		trashed, err := taskService.GetTrashedTask(ctx, id)
+		if err != nil {
+			t.Fatalf("Failed to get trashed task: %v", err)
+		}
+		if trashed.Title != "Plan offsite" {
+			t.Errorf("Expected the trashed task to be left alone, got %q", trashed.Title)
+		}
+	})
+
+	t.Run("failing rows are reported and skipped", func(t *testing.T) {
+		data := "Title,Priority,DueAt\nFile taxes,,\n,low,\nRenew domain,,next week\n"
+
+		result, err := importService.Import(ctx, csvImport, strings.NewReader(data))
+		if err != nil {
+			t.Fatalf("Failed to import: %v", err)
+		}
+		if result.Created != 1 || result.Failed != 2 {
+			t.Fatalf("Expected 1 created and 2 failed rows, got %+v", result)
+		}
+		if result.Rows[1].Line != 3 || result.Rows[1].Error == "" || result.Rows[2].Line != 4 {
+			t.Errorf("Expected errors on lines 3 and 4, got %+v", result.Rows)
+		}
+	})
+
+	t.Run("dry runs save nothing", func(t *testing.T) {
+		before, err := taskService.GetTasks(ctx, domain.TaskFilter{})
+		if err != nil {
+			t.Fatalf("Failed to get tasks: %v", err)
+		}
+
+		data := "ExternalID,Title\nROW-9,Hire designer\nROW-1,Order monitors\n"
+		result, err := importService.Import(ctx, &domain.ImportRequest{Format: domain.ImportFormatCSV, DryRun: true}, strings.NewReader(data))
+		if err != nil {
+			t.Fatalf("Failed to import: %v", err)
+		}
+		if !result.DryRun || result.Created != 1 || result.Updated != 1 {
+			t.Errorf("Expected a dry run creating and updating one task, got %+v", result)
+		}
+
+		after, err := taskService.GetTasks(ctx, domain.TaskFilter{})
+		if err != nil {
+			t.Fatalf("Failed to get tasks: %v", err)
+		}
+		if len(after) != len(before) {
+			t.Errorf("Expected %d tasks after a dry run, got %d", len(before), len(after))
+		}
+
+		task, err := taskService.GetTask(ctx, result.Rows[1].ID)
+		if err != nil {
+			t.Fatalf("Failed to get task: %v", err)
+		}
+		if task.Title != "Order laptops" {
+			t.Errorf("Expected the dry run to leave the title alone, got %q", task.Title)
+		}
+	})
+
+	t.Run("jsonl members are mapped", func(t *testing.T) {
+		data := `{"id": "J-1", "name": "Migrate CI", "labels": ["infra", "ci"]}` + "\n"
+		req := &domain.ImportRequest{
+			Format:  domain.ImportFormatJSONL,
+			Mapping: map[string]string{"ExternalID": "id", "Title": "name", "Tags": "labels"},
+		}
+
+		result, err := importService.Import(ctx, req, strings.NewReader(data))
+		if err != nil {
+			t.Fatalf("Failed to import: %v", err)
+		}
+		if result.Created != 1 {
+			t.Fatalf("Expected 1 created task, got %+v", result)
+		}
+
+		task, err := taskService.GetTask(ctx, result.Rows[0].ID)
+		if err != nil {
+			t.Fatalf("Failed to get task: %v", err)
+		}
+		if task.ExternalID != "J-1" || task.Title != "Migrate CI" || len(task.Tags) != 2 {
+			t.Errorf("Expected the mapped members to be imported, got %+v", task)
+		}
+	})
+
+	t.Run("files over the row limit are refused", func(t *testing.T) {
+		data := "Title\nOne\nTwo\nThree\nFour\n"
+
+		_, err := importService.Import(ctx, csvImport, strings.NewReader(data))
+		if !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("Expected a validation error, got %v", err)
+		}
+	})
+
+	t.Run("uploads are read before the transaction", func(t *testing.T) {
+		upload, writer := io.Pipe()
+		done := make(chan error, 1)
+		go func() {
+			_, err := importService.Import(ctx, csvImport, upload)
+			done <- err
+		}()
+
+		// Each write returns once the import has read it.
+		for _, line := range []string{"Title\n", "Slow upload\n"} {
+			if _, err := io.WriteString(writer, line); err != nil {
+				t.Fatalf("Failed to write upload: %v", err)
+			}
+		}
+
+		// The only database connection stays free while the file arrives.
+		timeout, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		if _, err := taskService.CreateTask(timeout, &domain.CreateTaskRequest{Title: "Meanwhile"}); err != nil {
+			t.Errorf("Expected other writes during the upload, got %v", err)
+		}
+
+		writer.Close()
+		if err := <-done; err != nil {
+			t.Errorf("Failed to import: %v", err)
+		}
+	})
+
+	t.Run("exports honour the filter", func(t *testing.T) {
+		var titles []string
+		err := taskService.ExportTasks(ctx, domain.TaskFilter{Assignee: "alice"}, func(task domain.Task) error {
+			titles = append(titles, task.Title)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Failed to export tasks: %v", err)
+		}
+		if len(titles) != 1 || titles[0] != "Order laptops" {
+			t.Errorf("Expected only alice's task, got %v", titles)
+		}
+	})
+}
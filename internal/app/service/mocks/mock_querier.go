@@ -6,7 +6,9 @@ package mocks
 
 import (
 	context "context"
+	sql "database/sql"
 	reflect "reflect"
+	time "time"
 
 	sqlc "github.com/alexgolang/ishare-task/internal/app/db/sqlite/sqlc"
 	gomock "github.com/golang/mock/gomock"
@@ -35,6 +37,136 @@ func (m *MockQuerier) EXPECT() *MockQuerierMockRecorder {
 	return m.recorder
 }
 
+// AdvanceTaskSeries mocks base method.
+func (m *MockQuerier) AdvanceTaskSeries(ctx context.Context, arg sqlc.AdvanceTaskSeriesParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdvanceTaskSeries", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdvanceTaskSeries indicates an expected call of AdvanceTaskSeries.
+func (mr *MockQuerierMockRecorder) AdvanceTaskSeries(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdvanceTaskSeries", reflect.TypeOf((*MockQuerier)(nil).AdvanceTaskSeries), ctx, arg)
+}
+
+// ClaimTaskReminder mocks base method.
+func (m *MockQuerier) ClaimTaskReminder(ctx context.Context, arg sqlc.ClaimTaskReminderParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimTaskReminder", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimTaskReminder indicates an expected call of ClaimTaskReminder.
+func (mr *MockQuerierMockRecorder) ClaimTaskReminder(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimTaskReminder", reflect.TypeOf((*MockQuerier)(nil).ClaimTaskReminder), ctx, arg)
+}
+
+// CompleteIdempotencyKey mocks base method.
+func (m *MockQuerier) CompleteIdempotencyKey(ctx context.Context, arg sqlc.CompleteIdempotencyKeyParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteIdempotencyKey", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteIdempotencyKey indicates an expected call of CompleteIdempotencyKey.
+func (mr *MockQuerierMockRecorder) CompleteIdempotencyKey(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteIdempotencyKey", reflect.TypeOf((*MockQuerier)(nil).CompleteIdempotencyKey), ctx, arg)
+}
+
+// CountOpenBlockers mocks base method.
+func (m *MockQuerier) CountOpenBlockers(ctx context.Context, arg sqlc.CountOpenBlockersParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOpenBlockers", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOpenBlockers indicates an expected call of CountOpenBlockers.
+func (mr *MockQuerierMockRecorder) CountOpenBlockers(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOpenBlockers", reflect.TypeOf((*MockQuerier)(nil).CountOpenBlockers), ctx, arg)
+}
+
+// CountProjectTasks mocks base method.
+func (m *MockQuerier) CountProjectTasks(ctx context.Context, projectID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountProjectTasks", ctx, projectID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountProjectTasks indicates an expected call of CountProjectTasks.
+func (mr *MockQuerierMockRecorder) CountProjectTasks(ctx, projectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProjectTasks", reflect.TypeOf((*MockQuerier)(nil).CountProjectTasks), ctx, projectID)
+}
+
+// CountTaskComments mocks base method.
+func (m *MockQuerier) CountTaskComments(ctx context.Context, taskID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTaskComments", ctx, taskID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTaskComments indicates an expected call of CountTaskComments.
+func (mr *MockQuerierMockRecorder) CountTaskComments(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTaskComments", reflect.TypeOf((*MockQuerier)(nil).CountTaskComments), ctx, taskID)
+}
+
+// CreateIdempotencyKey mocks base method.
+func (m *MockQuerier) CreateIdempotencyKey(ctx context.Context, arg sqlc.CreateIdempotencyKeyParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIdempotencyKey", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateIdempotencyKey indicates an expected call of CreateIdempotencyKey.
+func (mr *MockQuerierMockRecorder) CreateIdempotencyKey(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIdempotencyKey", reflect.TypeOf((*MockQuerier)(nil).CreateIdempotencyKey), ctx, arg)
+}
+
+// CreateProject mocks base method.
+func (m *MockQuerier) CreateProject(ctx context.Context, arg sqlc.CreateProjectParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProject", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateProject indicates an expected call of CreateProject.
+func (mr *MockQuerierMockRecorder) CreateProject(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*MockQuerier)(nil).CreateProject), ctx, arg)
+}
+
+// CreateSavedView mocks base method.
+func (m *MockQuerier) CreateSavedView(ctx context.Context, arg sqlc.CreateSavedViewParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSavedView", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSavedView indicates an expected call of CreateSavedView.
+func (mr *MockQuerierMockRecorder) CreateSavedView(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSavedView", reflect.TypeOf((*MockQuerier)(nil).CreateSavedView), ctx, arg)
+}
+
 // CreateTask mocks base method.
 func (m *MockQuerier) CreateTask(ctx context.Context, arg sqlc.CreateTaskParams) error {
 	m.ctrl.T.Helper()
@@ -49,6 +181,248 @@ func (mr *MockQuerierMockRecorder) CreateTask(ctx, arg interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockQuerier)(nil).CreateTask), ctx, arg)
 }
 
+// CreateTaskAssignee mocks base method.
+func (m *MockQuerier) CreateTaskAssignee(ctx context.Context, arg sqlc.CreateTaskAssigneeParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskAssignee", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTaskAssignee indicates an expected call of CreateTaskAssignee.
+func (mr *MockQuerierMockRecorder) CreateTaskAssignee(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskAssignee", reflect.TypeOf((*MockQuerier)(nil).CreateTaskAssignee), ctx, arg)
+}
+
+// CreateTaskAssignmentEvent mocks base method.
+func (m *MockQuerier) CreateTaskAssignmentEvent(ctx context.Context, arg sqlc.CreateTaskAssignmentEventParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskAssignmentEvent", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTaskAssignmentEvent indicates an expected call of CreateTaskAssignmentEvent.
+func (mr *MockQuerierMockRecorder) CreateTaskAssignmentEvent(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskAssignmentEvent", reflect.TypeOf((*MockQuerier)(nil).CreateTaskAssignmentEvent), ctx, arg)
+}
+
+// CreateTaskAttachment mocks base method.
+func (m *MockQuerier) CreateTaskAttachment(ctx context.Context, arg sqlc.CreateTaskAttachmentParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskAttachment", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTaskAttachment indicates an expected call of CreateTaskAttachment.
+func (mr *MockQuerierMockRecorder) CreateTaskAttachment(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskAttachment", reflect.TypeOf((*MockQuerier)(nil).CreateTaskAttachment), ctx, arg)
+}
+
+// CreateTaskComment mocks base method.
+func (m *MockQuerier) CreateTaskComment(ctx context.Context, arg sqlc.CreateTaskCommentParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskComment", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTaskComment indicates an expected call of CreateTaskComment.
+func (mr *MockQuerierMockRecorder) CreateTaskComment(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskComment", reflect.TypeOf((*MockQuerier)(nil).CreateTaskComment), ctx, arg)
+}
+
+// CreateTaskCommentRevision mocks base method.
+func (m *MockQuerier) CreateTaskCommentRevision(ctx context.Context, arg sqlc.CreateTaskCommentRevisionParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskCommentRevision", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTaskCommentRevision indicates an expected call of CreateTaskCommentRevision.
+func (mr *MockQuerierMockRecorder) CreateTaskCommentRevision(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskCommentRevision", reflect.TypeOf((*MockQuerier)(nil).CreateTaskCommentRevision), ctx, arg)
+}
+
+// CreateTaskDependency mocks base method.
+func (m *MockQuerier) CreateTaskDependency(ctx context.Context, arg sqlc.CreateTaskDependencyParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskDependency", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTaskDependency indicates an expected call of CreateTaskDependency.
+func (mr *MockQuerierMockRecorder) CreateTaskDependency(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskDependency", reflect.TypeOf((*MockQuerier)(nil).CreateTaskDependency), ctx, arg)
+}
+
+// CreateTaskEvent mocks base method.
+func (m *MockQuerier) CreateTaskEvent(ctx context.Context, arg sqlc.CreateTaskEventParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskEvent", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTaskEvent indicates an expected call of CreateTaskEvent.
+func (mr *MockQuerierMockRecorder) CreateTaskEvent(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskEvent", reflect.TypeOf((*MockQuerier)(nil).CreateTaskEvent), ctx, arg)
+}
+
+// CreateTaskReminder mocks base method.
+func (m *MockQuerier) CreateTaskReminder(ctx context.Context, arg sqlc.CreateTaskReminderParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskReminder", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTaskReminder indicates an expected call of CreateTaskReminder.
+func (mr *MockQuerierMockRecorder) CreateTaskReminder(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskReminder", reflect.TypeOf((*MockQuerier)(nil).CreateTaskReminder), ctx, arg)
+}
+
+// CreateTaskSeries mocks base method.
+func (m *MockQuerier) CreateTaskSeries(ctx context.Context, arg sqlc.CreateTaskSeriesParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskSeries", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTaskSeries indicates an expected call of CreateTaskSeries.
+func (mr *MockQuerierMockRecorder) CreateTaskSeries(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskSeries", reflect.TypeOf((*MockQuerier)(nil).CreateTaskSeries), ctx, arg)
+}
+
+// CreateTaskTag mocks base method.
+func (m *MockQuerier) CreateTaskTag(ctx context.Context, arg sqlc.CreateTaskTagParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskTag", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTaskTag indicates an expected call of CreateTaskTag.
+func (mr *MockQuerierMockRecorder) CreateTaskTag(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskTag", reflect.TypeOf((*MockQuerier)(nil).CreateTaskTag), ctx, arg)
+}
+
+// CreateTaskTemplate mocks base method.
+func (m *MockQuerier) CreateTaskTemplate(ctx context.Context, arg sqlc.CreateTaskTemplateParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskTemplate", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTaskTemplate indicates an expected call of CreateTaskTemplate.
+func (mr *MockQuerierMockRecorder) CreateTaskTemplate(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskTemplate", reflect.TypeOf((*MockQuerier)(nil).CreateTaskTemplate), ctx, arg)
+}
+
+// CreateTaskWorklog mocks base method.
+func (m *MockQuerier) CreateTaskWorklog(ctx context.Context, arg sqlc.CreateTaskWorklogParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskWorklog", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTaskWorklog indicates an expected call of CreateTaskWorklog.
+func (mr *MockQuerierMockRecorder) CreateTaskWorklog(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskWorklog", reflect.TypeOf((*MockQuerier)(nil).CreateTaskWorklog), ctx, arg)
+}
+
+// DeleteCalendarFeed mocks base method.
+func (m *MockQuerier) DeleteCalendarFeed(ctx context.Context, clientID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCalendarFeed", ctx, clientID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCalendarFeed indicates an expected call of DeleteCalendarFeed.
+func (mr *MockQuerierMockRecorder) DeleteCalendarFeed(ctx, clientID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCalendarFeed", reflect.TypeOf((*MockQuerier)(nil).DeleteCalendarFeed), ctx, clientID)
+}
+
+// DeleteExpiredIdempotencyKeys mocks base method.
+func (m *MockQuerier) DeleteExpiredIdempotencyKeys(ctx context.Context, expiresAt time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredIdempotencyKeys", ctx, expiresAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredIdempotencyKeys indicates an expected call of DeleteExpiredIdempotencyKeys.
+func (mr *MockQuerierMockRecorder) DeleteExpiredIdempotencyKeys(ctx, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredIdempotencyKeys", reflect.TypeOf((*MockQuerier)(nil).DeleteExpiredIdempotencyKeys), ctx, expiresAt)
+}
+
+// DeleteIdempotencyKey mocks base method.
+func (m *MockQuerier) DeleteIdempotencyKey(ctx context.Context, arg sqlc.DeleteIdempotencyKeyParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIdempotencyKey", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIdempotencyKey indicates an expected call of DeleteIdempotencyKey.
+func (mr *MockQuerierMockRecorder) DeleteIdempotencyKey(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIdempotencyKey", reflect.TypeOf((*MockQuerier)(nil).DeleteIdempotencyKey), ctx, arg)
+}
+
+// DeleteProject mocks base method.
+func (m *MockQuerier) DeleteProject(ctx context.Context, id string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProject", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteProject indicates an expected call of DeleteProject.
+func (mr *MockQuerierMockRecorder) DeleteProject(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProject", reflect.TypeOf((*MockQuerier)(nil).DeleteProject), ctx, id)
+}
+
+// DeleteSavedView mocks base method.
+func (m *MockQuerier) DeleteSavedView(ctx context.Context, id string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSavedView", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSavedView indicates an expected call of DeleteSavedView.
+func (mr *MockQuerierMockRecorder) DeleteSavedView(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSavedView", reflect.TypeOf((*MockQuerier)(nil).DeleteSavedView), ctx, id)
+}
+
 // DeleteTask mocks base method.
 func (m *MockQuerier) DeleteTask(ctx context.Context, id string) (int64, error) {
 	m.ctrl.T.Helper()
@@ -64,46 +438,1070 @@ func (mr *MockQuerierMockRecorder) DeleteTask(ctx, id interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockQuerier)(nil).DeleteTask), ctx, id)
 }
 
-// GetTask mocks base method.
-func (m *MockQuerier) GetTask(ctx context.Context, id string) (sqlc.Task, error) {
+// DeleteTaskAssignee mocks base method.
+func (m *MockQuerier) DeleteTaskAssignee(ctx context.Context, arg sqlc.DeleteTaskAssigneeParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTask", ctx, id)
-	ret0, _ := ret[0].(sqlc.Task)
+	ret := m.ctrl.Call(m, "DeleteTaskAssignee", ctx, arg)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTask indicates an expected call of GetTask.
-func (mr *MockQuerierMockRecorder) GetTask(ctx, id interface{}) *gomock.Call {
+// DeleteTaskAssignee indicates an expected call of DeleteTaskAssignee.
+func (mr *MockQuerierMockRecorder) DeleteTaskAssignee(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockQuerier)(nil).GetTask), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskAssignee", reflect.TypeOf((*MockQuerier)(nil).DeleteTaskAssignee), ctx, arg)
 }
 
-// GetTasks mocks base method.
-func (m *MockQuerier) GetTasks(ctx context.Context) ([]sqlc.Task, error) {
+// DeleteTaskAttachment mocks base method.
+func (m *MockQuerier) DeleteTaskAttachment(ctx context.Context, arg sqlc.DeleteTaskAttachmentParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTasks", ctx)
-	ret0, _ := ret[0].([]sqlc.Task)
+	ret := m.ctrl.Call(m, "DeleteTaskAttachment", ctx, arg)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTasks indicates an expected call of GetTasks.
-func (mr *MockQuerierMockRecorder) GetTasks(ctx interface{}) *gomock.Call {
+// DeleteTaskAttachment indicates an expected call of DeleteTaskAttachment.
+func (mr *MockQuerierMockRecorder) DeleteTaskAttachment(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockQuerier)(nil).GetTasks), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskAttachment", reflect.TypeOf((*MockQuerier)(nil).DeleteTaskAttachment), ctx, arg)
 }
 
-// UpdateTask mocks base method.
-func (m *MockQuerier) UpdateTask(ctx context.Context, arg sqlc.UpdateTaskParams) error {
+// DeleteTaskDependency mocks base method.
+func (m *MockQuerier) DeleteTaskDependency(ctx context.Context, arg sqlc.DeleteTaskDependencyParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTask", ctx, arg)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "DeleteTaskDependency", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// UpdateTask indicates an expected call of UpdateTask.
-func (mr *MockQuerierMockRecorder) UpdateTask(ctx, arg interface{}) *gomock.Call {
+// DeleteTaskDependency indicates an expected call of DeleteTaskDependency.
+func (mr *MockQuerierMockRecorder) DeleteTaskDependency(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockQuerier)(nil).UpdateTask), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskDependency", reflect.TypeOf((*MockQuerier)(nil).DeleteTaskDependency), ctx, arg)
+}
+
+// DeleteTaskReminder mocks base method.
+func (m *MockQuerier) DeleteTaskReminder(ctx context.Context, arg sqlc.DeleteTaskReminderParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaskReminder", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTaskReminder indicates an expected call of DeleteTaskReminder.
+func (mr *MockQuerierMockRecorder) DeleteTaskReminder(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskReminder", reflect.TypeOf((*MockQuerier)(nil).DeleteTaskReminder), ctx, arg)
+}
+
+// DeleteTaskSeries mocks base method.
+func (m *MockQuerier) DeleteTaskSeries(ctx context.Context, id string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaskSeries", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTaskSeries indicates an expected call of DeleteTaskSeries.
+func (mr *MockQuerierMockRecorder) DeleteTaskSeries(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskSeries", reflect.TypeOf((*MockQuerier)(nil).DeleteTaskSeries), ctx, id)
+}
+
+// DeleteTaskTags mocks base method.
+func (m *MockQuerier) DeleteTaskTags(ctx context.Context, taskID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaskTags", ctx, taskID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaskTags indicates an expected call of DeleteTaskTags.
+func (mr *MockQuerierMockRecorder) DeleteTaskTags(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskTags", reflect.TypeOf((*MockQuerier)(nil).DeleteTaskTags), ctx, taskID)
+}
+
+// DeleteTaskTemplate mocks base method.
+func (m *MockQuerier) DeleteTaskTemplate(ctx context.Context, id string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaskTemplate", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTaskTemplate indicates an expected call of DeleteTaskTemplate.
+func (mr *MockQuerierMockRecorder) DeleteTaskTemplate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskTemplate", reflect.TypeOf((*MockQuerier)(nil).DeleteTaskTemplate), ctx, id)
+}
+
+// DeleteTaskWorklog mocks base method.
+func (m *MockQuerier) DeleteTaskWorklog(ctx context.Context, arg sqlc.DeleteTaskWorklogParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaskWorklog", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTaskWorklog indicates an expected call of DeleteTaskWorklog.
+func (mr *MockQuerierMockRecorder) DeleteTaskWorklog(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskWorklog", reflect.TypeOf((*MockQuerier)(nil).DeleteTaskWorklog), ctx, arg)
+}
+
+// FailTaskReminder mocks base method.
+func (m *MockQuerier) FailTaskReminder(ctx context.Context, arg sqlc.FailTaskReminderParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailTaskReminder", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailTaskReminder indicates an expected call of FailTaskReminder.
+func (mr *MockQuerierMockRecorder) FailTaskReminder(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailTaskReminder", reflect.TypeOf((*MockQuerier)(nil).FailTaskReminder), ctx, arg)
+}
+
+// GetCalendarFeed mocks base method.
+func (m *MockQuerier) GetCalendarFeed(ctx context.Context, clientID string) (sqlc.CalendarFeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCalendarFeed", ctx, clientID)
+	ret0, _ := ret[0].(sqlc.CalendarFeed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCalendarFeed indicates an expected call of GetCalendarFeed.
+func (mr *MockQuerierMockRecorder) GetCalendarFeed(ctx, clientID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCalendarFeed", reflect.TypeOf((*MockQuerier)(nil).GetCalendarFeed), ctx, clientID)
+}
+
+// GetCalendarFeedByTokenHash mocks base method.
+func (m *MockQuerier) GetCalendarFeedByTokenHash(ctx context.Context, tokenHash string) (sqlc.CalendarFeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCalendarFeedByTokenHash", ctx, tokenHash)
+	ret0, _ := ret[0].(sqlc.CalendarFeed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCalendarFeedByTokenHash indicates an expected call of GetCalendarFeedByTokenHash.
+func (mr *MockQuerierMockRecorder) GetCalendarFeedByTokenHash(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCalendarFeedByTokenHash", reflect.TypeOf((*MockQuerier)(nil).GetCalendarFeedByTokenHash), ctx, tokenHash)
+}
+
+// GetDependencyEdges mocks base method.
+func (m *MockQuerier) GetDependencyEdges(ctx context.Context, taskID string) ([]sqlc.TaskDependency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDependencyEdges", ctx, taskID)
+	ret0, _ := ret[0].([]sqlc.TaskDependency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDependencyEdges indicates an expected call of GetDependencyEdges.
+func (mr *MockQuerierMockRecorder) GetDependencyEdges(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDependencyEdges", reflect.TypeOf((*MockQuerier)(nil).GetDependencyEdges), ctx, taskID)
+}
+
+// GetIdempotencyKey mocks base method.
+func (m *MockQuerier) GetIdempotencyKey(ctx context.Context, arg sqlc.GetIdempotencyKeyParams) (sqlc.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdempotencyKey", ctx, arg)
+	ret0, _ := ret[0].(sqlc.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdempotencyKey indicates an expected call of GetIdempotencyKey.
+func (mr *MockQuerierMockRecorder) GetIdempotencyKey(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdempotencyKey", reflect.TypeOf((*MockQuerier)(nil).GetIdempotencyKey), ctx, arg)
+}
+
+// GetLastTaskRank mocks base method.
+func (m *MockQuerier) GetLastTaskRank(ctx context.Context, arg sqlc.GetLastTaskRankParams) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastTaskRank", ctx, arg)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastTaskRank indicates an expected call of GetLastTaskRank.
+func (mr *MockQuerierMockRecorder) GetLastTaskRank(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastTaskRank", reflect.TypeOf((*MockQuerier)(nil).GetLastTaskRank), ctx, arg)
+}
+
+// GetLatestSeriesTask mocks base method.
+func (m *MockQuerier) GetLatestSeriesTask(ctx context.Context, seriesID sql.NullString) (sqlc.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestSeriesTask", ctx, seriesID)
+	ret0, _ := ret[0].(sqlc.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestSeriesTask indicates an expected call of GetLatestSeriesTask.
+func (mr *MockQuerierMockRecorder) GetLatestSeriesTask(ctx, seriesID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestSeriesTask", reflect.TypeOf((*MockQuerier)(nil).GetLatestSeriesTask), ctx, seriesID)
+}
+
+// GetNextTaskRank mocks base method.
+func (m *MockQuerier) GetNextTaskRank(ctx context.Context, arg sqlc.GetNextTaskRankParams) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNextTaskRank", ctx, arg)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNextTaskRank indicates an expected call of GetNextTaskRank.
+func (mr *MockQuerierMockRecorder) GetNextTaskRank(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextTaskRank", reflect.TypeOf((*MockQuerier)(nil).GetNextTaskRank), ctx, arg)
+}
+
+// GetPreviousTaskRank mocks base method.
+func (m *MockQuerier) GetPreviousTaskRank(ctx context.Context, arg sqlc.GetPreviousTaskRankParams) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreviousTaskRank", ctx, arg)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreviousTaskRank indicates an expected call of GetPreviousTaskRank.
+func (mr *MockQuerierMockRecorder) GetPreviousTaskRank(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreviousTaskRank", reflect.TypeOf((*MockQuerier)(nil).GetPreviousTaskRank), ctx, arg)
+}
+
+// GetProject mocks base method.
+func (m *MockQuerier) GetProject(ctx context.Context, id string) (sqlc.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProject", ctx, id)
+	ret0, _ := ret[0].(sqlc.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProject indicates an expected call of GetProject.
+func (mr *MockQuerierMockRecorder) GetProject(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProject", reflect.TypeOf((*MockQuerier)(nil).GetProject), ctx, id)
+}
+
+// GetRunningWorklog mocks base method.
+func (m *MockQuerier) GetRunningWorklog(ctx context.Context, author string) (sqlc.TaskWorklog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRunningWorklog", ctx, author)
+	ret0, _ := ret[0].(sqlc.TaskWorklog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRunningWorklog indicates an expected call of GetRunningWorklog.
+func (mr *MockQuerierMockRecorder) GetRunningWorklog(ctx, author interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunningWorklog", reflect.TypeOf((*MockQuerier)(nil).GetRunningWorklog), ctx, author)
+}
+
+// GetSavedView mocks base method.
+func (m *MockQuerier) GetSavedView(ctx context.Context, id string) (sqlc.SavedView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSavedView", ctx, id)
+	ret0, _ := ret[0].(sqlc.SavedView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSavedView indicates an expected call of GetSavedView.
+func (mr *MockQuerierMockRecorder) GetSavedView(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSavedView", reflect.TypeOf((*MockQuerier)(nil).GetSavedView), ctx, id)
+}
+
+// GetTask mocks base method.
+func (m *MockQuerier) GetTask(ctx context.Context, id string) (sqlc.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTask", ctx, id)
+	ret0, _ := ret[0].(sqlc.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTask indicates an expected call of GetTask.
+func (mr *MockQuerierMockRecorder) GetTask(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockQuerier)(nil).GetTask), ctx, id)
+}
+
+// GetTaskAttachment mocks base method.
+func (m *MockQuerier) GetTaskAttachment(ctx context.Context, arg sqlc.GetTaskAttachmentParams) (sqlc.TaskAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskAttachment", ctx, arg)
+	ret0, _ := ret[0].(sqlc.TaskAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskAttachment indicates an expected call of GetTaskAttachment.
+func (mr *MockQuerierMockRecorder) GetTaskAttachment(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskAttachment", reflect.TypeOf((*MockQuerier)(nil).GetTaskAttachment), ctx, arg)
+}
+
+// GetTaskByExternalID mocks base method.
+func (m *MockQuerier) GetTaskByExternalID(ctx context.Context, externalID sql.NullString) (sqlc.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskByExternalID", ctx, externalID)
+	ret0, _ := ret[0].(sqlc.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskByExternalID indicates an expected call of GetTaskByExternalID.
+func (mr *MockQuerierMockRecorder) GetTaskByExternalID(ctx, externalID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskByExternalID", reflect.TypeOf((*MockQuerier)(nil).GetTaskByExternalID), ctx, externalID)
+}
+
+// GetTaskByKey mocks base method.
+func (m *MockQuerier) GetTaskByKey(ctx context.Context, arg sqlc.GetTaskByKeyParams) (sqlc.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskByKey", ctx, arg)
+	ret0, _ := ret[0].(sqlc.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskByKey indicates an expected call of GetTaskByKey.
+func (mr *MockQuerierMockRecorder) GetTaskByKey(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskByKey", reflect.TypeOf((*MockQuerier)(nil).GetTaskByKey), ctx, arg)
+}
+
+// GetTaskComment mocks base method.
+func (m *MockQuerier) GetTaskComment(ctx context.Context, arg sqlc.GetTaskCommentParams) (sqlc.TaskComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskComment", ctx, arg)
+	ret0, _ := ret[0].(sqlc.TaskComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskComment indicates an expected call of GetTaskComment.
+func (mr *MockQuerierMockRecorder) GetTaskComment(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskComment", reflect.TypeOf((*MockQuerier)(nil).GetTaskComment), ctx, arg)
+}
+
+// GetTaskIncludingDeleted mocks base method.
+func (m *MockQuerier) GetTaskIncludingDeleted(ctx context.Context, id string) (sqlc.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskIncludingDeleted", ctx, id)
+	ret0, _ := ret[0].(sqlc.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskIncludingDeleted indicates an expected call of GetTaskIncludingDeleted.
+func (mr *MockQuerierMockRecorder) GetTaskIncludingDeleted(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskIncludingDeleted", reflect.TypeOf((*MockQuerier)(nil).GetTaskIncludingDeleted), ctx, id)
+}
+
+// GetTaskReminder mocks base method.
+func (m *MockQuerier) GetTaskReminder(ctx context.Context, arg sqlc.GetTaskReminderParams) (sqlc.TaskReminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskReminder", ctx, arg)
+	ret0, _ := ret[0].(sqlc.TaskReminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskReminder indicates an expected call of GetTaskReminder.
+func (mr *MockQuerierMockRecorder) GetTaskReminder(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskReminder", reflect.TypeOf((*MockQuerier)(nil).GetTaskReminder), ctx, arg)
+}
+
+// GetTaskSeries mocks base method.
+func (m *MockQuerier) GetTaskSeries(ctx context.Context, id string) (sqlc.TaskSeries, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskSeries", ctx, id)
+	ret0, _ := ret[0].(sqlc.TaskSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskSeries indicates an expected call of GetTaskSeries.
+func (mr *MockQuerierMockRecorder) GetTaskSeries(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskSeries", reflect.TypeOf((*MockQuerier)(nil).GetTaskSeries), ctx, id)
+}
+
+// GetTaskTemplate mocks base method.
+func (m *MockQuerier) GetTaskTemplate(ctx context.Context, id string) (sqlc.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskTemplate", ctx, id)
+	ret0, _ := ret[0].(sqlc.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskTemplate indicates an expected call of GetTaskTemplate.
+func (mr *MockQuerierMockRecorder) GetTaskTemplate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskTemplate", reflect.TypeOf((*MockQuerier)(nil).GetTaskTemplate), ctx, id)
+}
+
+// GetTaskWorklog mocks base method.
+func (m *MockQuerier) GetTaskWorklog(ctx context.Context, arg sqlc.GetTaskWorklogParams) (sqlc.TaskWorklog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskWorklog", ctx, arg)
+	ret0, _ := ret[0].(sqlc.TaskWorklog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskWorklog indicates an expected call of GetTaskWorklog.
+func (mr *MockQuerierMockRecorder) GetTaskWorklog(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskWorklog", reflect.TypeOf((*MockQuerier)(nil).GetTaskWorklog), ctx, arg)
+}
+
+// GetTasks mocks base method.
+func (m *MockQuerier) GetTasks(ctx context.Context, arg sqlc.GetTasksParams) ([]sqlc.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasks", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasks indicates an expected call of GetTasks.
+func (mr *MockQuerierMockRecorder) GetTasks(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasks", reflect.TypeOf((*MockQuerier)(nil).GetTasks), ctx, arg)
+}
+
+// GetTransitiveBlockerIDs mocks base method.
+func (m *MockQuerier) GetTransitiveBlockerIDs(ctx context.Context, taskID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransitiveBlockerIDs", ctx, taskID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransitiveBlockerIDs indicates an expected call of GetTransitiveBlockerIDs.
+func (mr *MockQuerierMockRecorder) GetTransitiveBlockerIDs(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransitiveBlockerIDs", reflect.TypeOf((*MockQuerier)(nil).GetTransitiveBlockerIDs), ctx, taskID)
+}
+
+// ListDeletedTasks mocks base method.
+func (m *MockQuerier) ListDeletedTasks(ctx context.Context) ([]sqlc.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeletedTasks", ctx)
+	ret0, _ := ret[0].([]sqlc.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeletedTasks indicates an expected call of ListDeletedTasks.
+func (mr *MockQuerierMockRecorder) ListDeletedTasks(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletedTasks", reflect.TypeOf((*MockQuerier)(nil).ListDeletedTasks), ctx)
+}
+
+// ListDueTaskReminders mocks base method.
+func (m *MockQuerier) ListDueTaskReminders(ctx context.Context, arg sqlc.ListDueTaskRemindersParams) ([]sqlc.TaskReminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueTaskReminders", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.TaskReminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueTaskReminders indicates an expected call of ListDueTaskReminders.
+func (mr *MockQuerierMockRecorder) ListDueTaskReminders(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueTaskReminders", reflect.TypeOf((*MockQuerier)(nil).ListDueTaskReminders), ctx, arg)
+}
+
+// ListDueTaskSeries mocks base method.
+func (m *MockQuerier) ListDueTaskSeries(ctx context.Context, nextAt sql.NullTime) ([]sqlc.TaskSeries, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueTaskSeries", ctx, nextAt)
+	ret0, _ := ret[0].([]sqlc.TaskSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueTaskSeries indicates an expected call of ListDueTaskSeries.
+func (mr *MockQuerierMockRecorder) ListDueTaskSeries(ctx, nextAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueTaskSeries", reflect.TypeOf((*MockQuerier)(nil).ListDueTaskSeries), ctx, nextAt)
+}
+
+// ListOpenSeriesTaskIDs mocks base method.
+func (m *MockQuerier) ListOpenSeriesTaskIDs(ctx context.Context, arg sqlc.ListOpenSeriesTaskIDsParams) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOpenSeriesTaskIDs", ctx, arg)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOpenSeriesTaskIDs indicates an expected call of ListOpenSeriesTaskIDs.
+func (mr *MockQuerierMockRecorder) ListOpenSeriesTaskIDs(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpenSeriesTaskIDs", reflect.TypeOf((*MockQuerier)(nil).ListOpenSeriesTaskIDs), ctx, arg)
+}
+
+// ListProjects mocks base method.
+func (m *MockQuerier) ListProjects(ctx context.Context) ([]sqlc.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProjects", ctx)
+	ret0, _ := ret[0].([]sqlc.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProjects indicates an expected call of ListProjects.
+func (mr *MockQuerierMockRecorder) ListProjects(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProjects", reflect.TypeOf((*MockQuerier)(nil).ListProjects), ctx)
+}
+
+// ListPurgeableTaskIDs mocks base method.
+func (m *MockQuerier) ListPurgeableTaskIDs(ctx context.Context, arg sqlc.ListPurgeableTaskIDsParams) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPurgeableTaskIDs", ctx, arg)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPurgeableTaskIDs indicates an expected call of ListPurgeableTaskIDs.
+func (mr *MockQuerierMockRecorder) ListPurgeableTaskIDs(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPurgeableTaskIDs", reflect.TypeOf((*MockQuerier)(nil).ListPurgeableTaskIDs), ctx, arg)
+}
+
+// ListRelativeTaskReminders mocks base method.
+func (m *MockQuerier) ListRelativeTaskReminders(ctx context.Context, taskID string) ([]sqlc.TaskReminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRelativeTaskReminders", ctx, taskID)
+	ret0, _ := ret[0].([]sqlc.TaskReminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRelativeTaskReminders indicates an expected call of ListRelativeTaskReminders.
+func (mr *MockQuerierMockRecorder) ListRelativeTaskReminders(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRelativeTaskReminders", reflect.TypeOf((*MockQuerier)(nil).ListRelativeTaskReminders), ctx, taskID)
+}
+
+// ListSavedViews mocks base method.
+func (m *MockQuerier) ListSavedViews(ctx context.Context, client string) ([]sqlc.SavedView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSavedViews", ctx, client)
+	ret0, _ := ret[0].([]sqlc.SavedView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSavedViews indicates an expected call of ListSavedViews.
+func (mr *MockQuerierMockRecorder) ListSavedViews(ctx, client interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSavedViews", reflect.TypeOf((*MockQuerier)(nil).ListSavedViews), ctx, client)
+}
+
+// ListSeriesTaskIDs mocks base method.
+func (m *MockQuerier) ListSeriesTaskIDs(ctx context.Context, seriesID sql.NullString) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSeriesTaskIDs", ctx, seriesID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSeriesTaskIDs indicates an expected call of ListSeriesTaskIDs.
+func (mr *MockQuerierMockRecorder) ListSeriesTaskIDs(ctx, seriesID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSeriesTaskIDs", reflect.TypeOf((*MockQuerier)(nil).ListSeriesTaskIDs), ctx, seriesID)
+}
+
+// ListTaskAssignees mocks base method.
+func (m *MockQuerier) ListTaskAssignees(ctx context.Context, taskID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskAssignees", ctx, taskID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskAssignees indicates an expected call of ListTaskAssignees.
+func (mr *MockQuerierMockRecorder) ListTaskAssignees(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskAssignees", reflect.TypeOf((*MockQuerier)(nil).ListTaskAssignees), ctx, taskID)
+}
+
+// ListTaskAssignmentEvents mocks base method.
+func (m *MockQuerier) ListTaskAssignmentEvents(ctx context.Context, taskID string) ([]sqlc.TaskAssignmentEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskAssignmentEvents", ctx, taskID)
+	ret0, _ := ret[0].([]sqlc.TaskAssignmentEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskAssignmentEvents indicates an expected call of ListTaskAssignmentEvents.
+func (mr *MockQuerierMockRecorder) ListTaskAssignmentEvents(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskAssignmentEvents", reflect.TypeOf((*MockQuerier)(nil).ListTaskAssignmentEvents), ctx, taskID)
+}
+
+// ListTaskAttachmentStorageKeys mocks base method.
+func (m *MockQuerier) ListTaskAttachmentStorageKeys(ctx context.Context, taskID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskAttachmentStorageKeys", ctx, taskID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskAttachmentStorageKeys indicates an expected call of ListTaskAttachmentStorageKeys.
+func (mr *MockQuerierMockRecorder) ListTaskAttachmentStorageKeys(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskAttachmentStorageKeys", reflect.TypeOf((*MockQuerier)(nil).ListTaskAttachmentStorageKeys), ctx, taskID)
+}
+
+// ListTaskAttachments mocks base method.
+func (m *MockQuerier) ListTaskAttachments(ctx context.Context, taskID string) ([]sqlc.TaskAttachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskAttachments", ctx, taskID)
+	ret0, _ := ret[0].([]sqlc.TaskAttachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskAttachments indicates an expected call of ListTaskAttachments.
+func (mr *MockQuerierMockRecorder) ListTaskAttachments(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskAttachments", reflect.TypeOf((*MockQuerier)(nil).ListTaskAttachments), ctx, taskID)
+}
+
+// ListTaskCommentRevisions mocks base method.
+func (m *MockQuerier) ListTaskCommentRevisions(ctx context.Context, commentID string) ([]sqlc.TaskCommentRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskCommentRevisions", ctx, commentID)
+	ret0, _ := ret[0].([]sqlc.TaskCommentRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskCommentRevisions indicates an expected call of ListTaskCommentRevisions.
+func (mr *MockQuerierMockRecorder) ListTaskCommentRevisions(ctx, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskCommentRevisions", reflect.TypeOf((*MockQuerier)(nil).ListTaskCommentRevisions), ctx, commentID)
+}
+
+// ListTaskComments mocks base method.
+func (m *MockQuerier) ListTaskComments(ctx context.Context, arg sqlc.ListTaskCommentsParams) ([]sqlc.TaskComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskComments", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.TaskComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskComments indicates an expected call of ListTaskComments.
+func (mr *MockQuerierMockRecorder) ListTaskComments(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskComments", reflect.TypeOf((*MockQuerier)(nil).ListTaskComments), ctx, arg)
+}
+
+// ListTaskEvents mocks base method.
+func (m *MockQuerier) ListTaskEvents(ctx context.Context, taskID string) ([]sqlc.TaskEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskEvents", ctx, taskID)
+	ret0, _ := ret[0].([]sqlc.TaskEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskEvents indicates an expected call of ListTaskEvents.
+func (mr *MockQuerierMockRecorder) ListTaskEvents(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskEvents", reflect.TypeOf((*MockQuerier)(nil).ListTaskEvents), ctx, taskID)
+}
+
+// ListTaskEventsBefore mocks base method.
+func (m *MockQuerier) ListTaskEventsBefore(ctx context.Context, arg sqlc.ListTaskEventsBeforeParams) ([]sqlc.TaskEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskEventsBefore", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.TaskEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskEventsBefore indicates an expected call of ListTaskEventsBefore.
+func (mr *MockQuerierMockRecorder) ListTaskEventsBefore(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskEventsBefore", reflect.TypeOf((*MockQuerier)(nil).ListTaskEventsBefore), ctx, arg)
+}
+
+// ListTaskEventsUntil mocks base method.
+func (m *MockQuerier) ListTaskEventsUntil(ctx context.Context, arg sqlc.ListTaskEventsUntilParams) ([]sqlc.TaskEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskEventsUntil", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.TaskEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskEventsUntil indicates an expected call of ListTaskEventsUntil.
+func (mr *MockQuerierMockRecorder) ListTaskEventsUntil(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskEventsUntil", reflect.TypeOf((*MockQuerier)(nil).ListTaskEventsUntil), ctx, arg)
+}
+
+// ListTaskReminders mocks base method.
+func (m *MockQuerier) ListTaskReminders(ctx context.Context, taskID string) ([]sqlc.TaskReminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskReminders", ctx, taskID)
+	ret0, _ := ret[0].([]sqlc.TaskReminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskReminders indicates an expected call of ListTaskReminders.
+func (mr *MockQuerierMockRecorder) ListTaskReminders(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskReminders", reflect.TypeOf((*MockQuerier)(nil).ListTaskReminders), ctx, taskID)
+}
+
+// ListTaskTags mocks base method.
+func (m *MockQuerier) ListTaskTags(ctx context.Context, taskID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskTags", ctx, taskID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskTags indicates an expected call of ListTaskTags.
+func (mr *MockQuerierMockRecorder) ListTaskTags(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskTags", reflect.TypeOf((*MockQuerier)(nil).ListTaskTags), ctx, taskID)
+}
+
+// ListTaskTemplates mocks base method.
+func (m *MockQuerier) ListTaskTemplates(ctx context.Context) ([]sqlc.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskTemplates", ctx)
+	ret0, _ := ret[0].([]sqlc.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskTemplates indicates an expected call of ListTaskTemplates.
+func (mr *MockQuerierMockRecorder) ListTaskTemplates(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskTemplates", reflect.TypeOf((*MockQuerier)(nil).ListTaskTemplates), ctx)
+}
+
+// ListTaskWorklogs mocks base method.
+func (m *MockQuerier) ListTaskWorklogs(ctx context.Context, taskID string) ([]sqlc.TaskWorklog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskWorklogs", ctx, taskID)
+	ret0, _ := ret[0].([]sqlc.TaskWorklog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskWorklogs indicates an expected call of ListTaskWorklogs.
+func (mr *MockQuerierMockRecorder) ListTaskWorklogs(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskWorklogs", reflect.TypeOf((*MockQuerier)(nil).ListTaskWorklogs), ctx, taskID)
+}
+
+// NextProjectTaskSeq mocks base method.
+func (m *MockQuerier) NextProjectTaskSeq(ctx context.Context, id string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextProjectTaskSeq", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextProjectTaskSeq indicates an expected call of NextProjectTaskSeq.
+func (mr *MockQuerierMockRecorder) NextProjectTaskSeq(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextProjectTaskSeq", reflect.TypeOf((*MockQuerier)(nil).NextProjectTaskSeq), ctx, id)
+}
+
+// ReleaseTaskReminder mocks base method.
+func (m *MockQuerier) ReleaseTaskReminder(ctx context.Context, arg sqlc.ReleaseTaskReminderParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseTaskReminder", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseTaskReminder indicates an expected call of ReleaseTaskReminder.
+func (mr *MockQuerierMockRecorder) ReleaseTaskReminder(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseTaskReminder", reflect.TypeOf((*MockQuerier)(nil).ReleaseTaskReminder), ctx, arg)
+}
+
+// RescheduleTaskReminder mocks base method.
+func (m *MockQuerier) RescheduleTaskReminder(ctx context.Context, arg sqlc.RescheduleTaskReminderParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RescheduleTaskReminder", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RescheduleTaskReminder indicates an expected call of RescheduleTaskReminder.
+func (mr *MockQuerierMockRecorder) RescheduleTaskReminder(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RescheduleTaskReminder", reflect.TypeOf((*MockQuerier)(nil).RescheduleTaskReminder), ctx, arg)
+}
+
+// RestoreTask mocks base method.
+func (m *MockQuerier) RestoreTask(ctx context.Context, arg sqlc.RestoreTaskParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreTask", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreTask indicates an expected call of RestoreTask.
+func (mr *MockQuerierMockRecorder) RestoreTask(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTask", reflect.TypeOf((*MockQuerier)(nil).RestoreTask), ctx, arg)
+}
+
+// SeriesOccurrenceExists mocks base method.
+func (m *MockQuerier) SeriesOccurrenceExists(ctx context.Context, arg sqlc.SeriesOccurrenceExistsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SeriesOccurrenceExists", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SeriesOccurrenceExists indicates an expected call of SeriesOccurrenceExists.
+func (mr *MockQuerierMockRecorder) SeriesOccurrenceExists(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SeriesOccurrenceExists", reflect.TypeOf((*MockQuerier)(nil).SeriesOccurrenceExists), ctx, arg)
+}
+
+// SetTaskExternalID mocks base method.
+func (m *MockQuerier) SetTaskExternalID(ctx context.Context, arg sqlc.SetTaskExternalIDParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTaskExternalID", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTaskExternalID indicates an expected call of SetTaskExternalID.
+func (mr *MockQuerierMockRecorder) SetTaskExternalID(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTaskExternalID", reflect.TypeOf((*MockQuerier)(nil).SetTaskExternalID), ctx, arg)
+}
+
+// SetTaskRank mocks base method.
+func (m *MockQuerier) SetTaskRank(ctx context.Context, arg sqlc.SetTaskRankParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTaskRank", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTaskRank indicates an expected call of SetTaskRank.
+func (mr *MockQuerierMockRecorder) SetTaskRank(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTaskRank", reflect.TypeOf((*MockQuerier)(nil).SetTaskRank), ctx, arg)
+}
+
+// SoftDeleteTask mocks base method.
+func (m *MockQuerier) SoftDeleteTask(ctx context.Context, arg sqlc.SoftDeleteTaskParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDeleteTask", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SoftDeleteTask indicates an expected call of SoftDeleteTask.
+func (mr *MockQuerierMockRecorder) SoftDeleteTask(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteTask", reflect.TypeOf((*MockQuerier)(nil).SoftDeleteTask), ctx, arg)
+}
+
+// SoftDeleteTaskComment mocks base method.
+func (m *MockQuerier) SoftDeleteTaskComment(ctx context.Context, arg sqlc.SoftDeleteTaskCommentParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDeleteTaskComment", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SoftDeleteTaskComment indicates an expected call of SoftDeleteTaskComment.
+func (mr *MockQuerierMockRecorder) SoftDeleteTaskComment(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteTaskComment", reflect.TypeOf((*MockQuerier)(nil).SoftDeleteTaskComment), ctx, arg)
+}
+
+// StopTaskWorklog mocks base method.
+func (m *MockQuerier) StopTaskWorklog(ctx context.Context, arg sqlc.StopTaskWorklogParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopTaskWorklog", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopTaskWorklog indicates an expected call of StopTaskWorklog.
+func (mr *MockQuerierMockRecorder) StopTaskWorklog(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTaskWorklog", reflect.TypeOf((*MockQuerier)(nil).StopTaskWorklog), ctx, arg)
+}
+
+// SumTaskWorklogs mocks base method.
+func (m *MockQuerier) SumTaskWorklogs(ctx context.Context, arg sqlc.SumTaskWorklogsParams) ([]sqlc.SumTaskWorklogsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumTaskWorklogs", ctx, arg)
+	ret0, _ := ret[0].([]sqlc.SumTaskWorklogsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumTaskWorklogs indicates an expected call of SumTaskWorklogs.
+func (mr *MockQuerierMockRecorder) SumTaskWorklogs(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumTaskWorklogs", reflect.TypeOf((*MockQuerier)(nil).SumTaskWorklogs), ctx, arg)
+}
+
+// UpdateProject mocks base method.
+func (m *MockQuerier) UpdateProject(ctx context.Context, arg sqlc.UpdateProjectParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProject", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProject indicates an expected call of UpdateProject.
+func (mr *MockQuerierMockRecorder) UpdateProject(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockQuerier)(nil).UpdateProject), ctx, arg)
+}
+
+// UpdateSavedView mocks base method.
+func (m *MockQuerier) UpdateSavedView(ctx context.Context, arg sqlc.UpdateSavedViewParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSavedView", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSavedView indicates an expected call of UpdateSavedView.
+func (mr *MockQuerierMockRecorder) UpdateSavedView(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSavedView", reflect.TypeOf((*MockQuerier)(nil).UpdateSavedView), ctx, arg)
+}
+
+// UpdateTask mocks base method.
+func (m *MockQuerier) UpdateTask(ctx context.Context, arg sqlc.UpdateTaskParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTask", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTask indicates an expected call of UpdateTask.
+func (mr *MockQuerierMockRecorder) UpdateTask(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockQuerier)(nil).UpdateTask), ctx, arg)
+}
+
+// UpdateTaskCommentBody mocks base method.
+func (m *MockQuerier) UpdateTaskCommentBody(ctx context.Context, arg sqlc.UpdateTaskCommentBodyParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaskCommentBody", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTaskCommentBody indicates an expected call of UpdateTaskCommentBody.
+func (mr *MockQuerierMockRecorder) UpdateTaskCommentBody(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskCommentBody", reflect.TypeOf((*MockQuerier)(nil).UpdateTaskCommentBody), ctx, arg)
+}
+
+// UpdateTaskSeries mocks base method.
+func (m *MockQuerier) UpdateTaskSeries(ctx context.Context, arg sqlc.UpdateTaskSeriesParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaskSeries", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTaskSeries indicates an expected call of UpdateTaskSeries.
+func (mr *MockQuerierMockRecorder) UpdateTaskSeries(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskSeries", reflect.TypeOf((*MockQuerier)(nil).UpdateTaskSeries), ctx, arg)
+}
+
+// UpdateTaskTemplate mocks base method.
+func (m *MockQuerier) UpdateTaskTemplate(ctx context.Context, arg sqlc.UpdateTaskTemplateParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaskTemplate", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTaskTemplate indicates an expected call of UpdateTaskTemplate.
+func (mr *MockQuerierMockRecorder) UpdateTaskTemplate(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskTemplate", reflect.TypeOf((*MockQuerier)(nil).UpdateTaskTemplate), ctx, arg)
+}
+
+// UpsertCalendarFeed mocks base method.
+func (m *MockQuerier) UpsertCalendarFeed(ctx context.Context, arg sqlc.UpsertCalendarFeedParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCalendarFeed", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertCalendarFeed indicates an expected call of UpsertCalendarFeed.
+func (mr *MockQuerierMockRecorder) UpsertCalendarFeed(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCalendarFeed", reflect.TypeOf((*MockQuerier)(nil).UpsertCalendarFeed), ctx, arg)
 }
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite/sqlc"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/google/uuid"
+)
+
+type ProjectService struct {
+	logger *log.Logger
+	db     *sqlite.Database
+}
+
+func NewProjectService(logger *log.Logger, db *sqlite.Database) *ProjectService {
+	return &ProjectService{
+		logger: logger,
+		db:     db,
+	}
+}
+
+func (s *ProjectService) CreateProject(ctx context.Context, req *domain.CreateProjectRequest) (domain.Project, error) {
+	key := strings.ToUpper(strings.TrimSpace(req.Key))
+	if !domain.IsValidProjectKey(key) {
+		return domain.Project{}, fmt.Errorf("create project: %w", domain.Invalid("Key", "key must be 2-10 letters or digits starting with a letter"))
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return domain.Project{}, fmt.Errorf("create project: %w", domain.Invalid("Name", "name is required"))
+	}
+
+	now := time.Now().UTC()
+	project := sqlc.Project{
+		ID:          uuid.New().String(),
+		Key:         key,
+		Name:        name,
+		Description: sql.NullString{String: req.Description, Valid: req.Description != ""},
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	err := s.db.Queries.CreateProject(ctx, sqlc.CreateProjectParams{
+		ID:          project.ID,
+		Key:         project.Key,
+		Name:        project.Name,
+		Description: project.Description,
+		CreatedAt:   project.CreatedAt,
+		UpdatedAt:   project.UpdatedAt,
+	})
+	if err != nil {
+		if sqlite.IsUniqueViolation(err) {
+			return domain.Project{}, fmt.Errorf("create project: %w", domain.Conflict("key %s is already in use", key))
+		}
+		return domain.Project{}, fmt.Errorf("create project: %w", err)
+	}
+
+	return projectToDomain(project), nil
+}
+
+func (s *ProjectService) ListProjects(ctx context.Context, includeArchived bool) ([]domain.Project, error) {
+	projects, err := s.db.Queries.ListProjects(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list projects: %w", err)
+	}
+
+	domainProjects := make([]domain.Project, 0, len(projects))
+	for _, project := range projects {
+		if project.Archived && !includeArchived {
+			continue
+		}
+		domainProjects = append(domainProjects, projectToDomain(project))
+	}
+
+	return domainProjects, nil
+}
+
+func (s *ProjectService) GetProject(ctx context.Context, id string) (domain.Project, error) {
+	if id == "" {
+		return domain.Project{}, fmt.Errorf("get project: %w", domain.Invalid("id", "id is required"))
+	}
+
+	project, err := s.db.Queries.GetProject(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Project{}, fmt.Errorf("get project: %w", domain.NotFound("project not found"))
+		}
+		return domain.Project{}, fmt.Errorf("get project: %w", err)
+	}
+
+	return projectToDomain(project), nil
+}
+
+func (s *ProjectService) UpdateProject(ctx context.Context, id string, req *domain.UpdateProjectRequest) (domain.Project, error) {
+	if id == "" {
+		return domain.Project{}, fmt.Errorf("update project: %w", domain.Invalid("id", "id is required"))
+	}
+
+	var updated sqlc.Project
+	err := s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		project, err := q.GetProject(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("update project: %w", domain.NotFound("project not found"))
+			}
+			return fmt.Errorf("update project: %w", err)
+		}
+
+		if req.Name != nil {
+			name := strings.TrimSpace(*req.Name)
+			if name == "" {
+				return fmt.Errorf("update project: %w", domain.Invalid("Name", "name must not be empty"))
+			}
+			project.Name = name
+		}
+
+		if req.Description != nil {
+			project.Description = sql.NullString{String: *req.Description, Valid: *req.Description != ""}
+		}
+
+		if req.Archived != nil {
+			if *req.Archived && project.ID == domain.DefaultProjectID.String() {
+				return fmt.Errorf("update project: %w", domain.Conflict("the default project cannot be archived"))
+			}
+			project.Archived = *req.Archived
+		}
+
+		project.UpdatedAt = time.Now().UTC()
+		err = q.UpdateProject(ctx, sqlc.UpdateProjectParams{
+			Name:        project.Name,
+			Description: project.Description,
+			Archived:    project.Archived,
+			UpdatedAt:   project.UpdatedAt,
+			ID:          project.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("update project: %w", err)
+		}
+
+		updated = project
+		return nil
+	})
+	if err != nil {
+		return domain.Project{}, err
+	}
+
+	return projectToDomain(updated), nil
+}
+
+// DeleteProject removes an empty project. Projects that still hold tasks must
+// be archived instead so their task keys stay resolvable.
+func (s *ProjectService) DeleteProject(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("delete project: %w", domain.Invalid("id", "id is required"))
+	}
+
+	if id == domain.DefaultProjectID.String() {
+		return fmt.Errorf("delete project: %w", domain.Conflict("the default project cannot be deleted"))
+	}
+
+	return s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		count, err := q.CountProjectTasks(ctx, id)
+		if err != nil {
+			return fmt.Errorf("delete project: %w", err)
+		}
+
+		if count > 0 {
+			return fmt.Errorf("delete project: %w", domain.Conflict("project still has %d task(s)", count))
+		}
+
+		result, err := q.DeleteProject(ctx, id)
+		if err != nil {
+			return fmt.Errorf("delete project: %w", err)
+		}
+
+		if result == 0 {
+			return fmt.Errorf("delete project: %w", domain.NotFound("project not found"))
+		}
+
+		return nil
+	})
+}
+
+func projectToDomain(project sqlc.Project) domain.Project {
+	return domain.Project{
+		ID:          uuid.MustParse(project.ID),
+		Key:         project.Key,
+		Name:        project.Name,
+		Description: project.Description.String,
+		Archived:    project.Archived,
+		CreatedAt:   project.CreatedAt,
+		UpdatedAt:   project.UpdatedAt,
+	}
+}
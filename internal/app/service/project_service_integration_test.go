@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/storage"
+)
+
+func TestProjectService_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, err := sqlite.NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+
+	logger := log.New(os.Stderr, "INTEGRATION_TEST: ", log.LstdFlags)
+	taskService := NewTaskService(logger, db, blobs, domain.DefaultWorkflow())
+	projectService := NewProjectService(logger, db)
+	ctx := context.Background()
+
+	project, err := projectService.CreateProject(ctx, &domain.CreateProjectRequest{Key: "ops", Name: "Operations"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	t.Run("key is normalized and unique", func(t *testing.T) {
+		if project.Key != "OPS" {
+			t.Errorf("Expected key OPS, got %q", project.Key)
+		}
+
+		_, err := projectService.CreateProject(ctx, &domain.CreateProjectRequest{Key: "OPS", Name: "Duplicate"})
+		if err == nil {
+			t.Fatal("Expected error for duplicate key, got nil")
+		}
+
+		expectedError := "create project: key OPS is already in use"
+		if err.Error() != expectedError {
+			t.Errorf("Expected error %q, got %q", expectedError, err.Error())
+		}
+	})
+
+	t.Run("tasks get per-project sequence numbers", func(t *testing.T) {
+		defaultTaskID, err := taskService.CreateTask(ctx, &domain.CreateTaskRequest{Title: "Default project task"})
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+
+		defaultTask, err := taskService.GetTask(ctx, defaultTaskID.String())
+		if err != nil {
+			t.Fatalf("Failed to get task: %v", err)
+		}
+
+		if defaultTask.ProjectID != domain.DefaultProjectID || defaultTask.Key != "TASK-1" {
+			t.Errorf("Expected default project task TASK-1, got %s in %s", defaultTask.Key, defaultTask.ProjectID)
+		}
+
+		for i := 0; i < 2; i++ {
+			if _, err := taskService.CreateTask(ctx, &domain.CreateTaskRequest{ProjectID: project.ID.String(), Title: "Ops task"}); err != nil {
+				t.Fatalf("Failed to create task: %v", err)
+			}
+		}
+
+		task, err := taskService.GetTask(ctx, "OPS-2")
+		if err != nil {
+			t.Fatalf("Failed to resolve task key: %v", err)
+		}
+
+		if task.Key != "OPS-2" || task.ProjectID != project.ID {
+			t.Errorf("Expected task OPS-2 in project %s, got %s in %s", project.ID, task.Key, task.ProjectID)
+		}
+
+		tasks, err := taskService.GetTasks(ctx, domain.TaskFilter{ProjectID: project.ID.String()})
+		if err != nil {
+			t.Fatalf("Failed to list tasks: %v", err)
+		}
+
+		if len(tasks) != 2 {
+			t.Errorf("Expected 2 tasks in project, got %d", len(tasks))
+		}
+	})
+
+	t.Run("archived project rejects new tasks", func(t *testing.T) {
+		archived := true
+		if _, err := projectService.UpdateProject(ctx, project.ID.String(), &domain.UpdateProjectRequest{Archived: &archived}); err != nil {
+			t.Fatalf("Failed to archive project: %v", err)
+		}
+
+		_, err := taskService.CreateTask(ctx, &domain.CreateTaskRequest{ProjectID: project.ID.String(), Title: "Late task"})
+		if err == nil {
+			t.Fatal("Expected error for archived project, got nil")
+		}
+
+		projects, err := projectService.ListProjects(ctx, false)
+		if err != nil {
+			t.Fatalf("Failed to list projects: %v", err)
+		}
+
+		if len(projects) != 1 || projects[0].ID != domain.DefaultProjectID {
+			t.Errorf("Expected only the default project to be listed, got %+v", projects)
+		}
+	})
+
+	t.Run("project with tasks cannot be deleted", func(t *testing.T) {
+		err := projectService.DeleteProject(ctx, project.ID.String())
+		if err == nil {
+			t.Fatal("Expected error deleting a project with tasks, got nil")
+		}
+
+		empty, err := projectService.CreateProject(ctx, &domain.CreateProjectRequest{Key: "TMP", Name: "Scratch"})
+		if err != nil {
+			t.Fatalf("Failed to create project: %v", err)
+		}
+
+		if err := projectService.DeleteProject(ctx, empty.ID.String()); err != nil {
+			t.Errorf("Expected empty project to be deleted, got %v", err)
+		}
+	})
+}
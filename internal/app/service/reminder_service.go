@@ -0,0 +1,286 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite/sqlc"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/notify"
+	"github.com/google/uuid"
+)
+
+const (
+	// reminderBatchSize caps how many due reminders are delivered per run.
+	reminderBatchSize = 100
+	// maxReminderAttempts is how often delivery is tried before giving up.
+	maxReminderAttempts = 5
+)
+
+type ReminderService struct {
+	logger   *log.Logger
+	db       *sqlite.Database
+	notifier notify.Notifier
+}
+
+func NewReminderService(logger *log.Logger, db *sqlite.Database, notifier notify.Notifier) *ReminderService {
+	return &ReminderService{
+		logger:   logger,
+		db:       db,
+		notifier: notifier,
+	}
+}
+
+func (s *ReminderService) CreateReminder(ctx context.Context, taskID string, creator string, req *domain.CreateReminderRequest) (domain.Reminder, error) {
+	if taskID == "" {
+		return domain.Reminder{}, fmt.Errorf("create reminder: %w", domain.Invalid("id", "task id is required"))
+	}
+
+	if (req.RemindAt == nil) == (req.Before == "") {
+		return domain.Reminder{}, fmt.Errorf("create reminder: %w", domain.Invalid("RemindAt", "exactly one of remind at or before is required"))
+	}
+
+	var offset sql.NullInt64
+	if req.Before != "" {
+		before, err := time.ParseDuration(req.Before)
+		if err != nil || before <= 0 {
+			return domain.Reminder{}, fmt.Errorf("create reminder: %w", domain.Invalid("Before", "before must be a positive duration such as 1h or 30m"))
+		}
+		offset = sql.NullInt64{Int64: int64(before / time.Second), Valid: true}
+	}
+
+	task, err := s.db.Queries.GetTask(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Reminder{}, fmt.Errorf("create reminder: %w", domain.NotFound("task not found"))
+		}
+		return domain.Reminder{}, fmt.Errorf("create reminder: %w", err)
+	}
+
+	var remindAt time.Time
+	if offset.Valid {
+		if !task.DueAt.Valid {
+			return domain.Reminder{}, fmt.Errorf("create reminder: %w", domain.Conflict("task has no due date"))
+		}
+		remindAt = task.DueAt.Time.Add(-time.Duration(offset.Int64) * time.Second)
+	} else {
+		remindAt = req.RemindAt.UTC()
+	}
+
+	now := time.Now().UTC()
+	reminder := sqlc.TaskReminder{
+		ID:            uuid.New().String(),
+		TaskID:        taskID,
+		OffsetSeconds: offset,
+		RemindAt:      remindAt,
+		CreatedBy:     creator,
+		CreatedAt:     now,
+	}
+
+	err = s.db.Queries.CreateTaskReminder(ctx, sqlc.CreateTaskReminderParams{
+		ID:            reminder.ID,
+		TaskID:        reminder.TaskID,
+		OffsetSeconds: reminder.OffsetSeconds,
+		RemindAt:      reminder.RemindAt,
+		CreatedBy:     reminder.CreatedBy,
+		CreatedAt:     reminder.CreatedAt,
+	})
+	if err != nil {
+		return domain.Reminder{}, fmt.Errorf("create reminder: %w", err)
+	}
+
+	return reminderToDomain(reminder), nil
+}
+
+func (s *ReminderService) ListReminders(ctx context.Context, taskID string) ([]domain.Reminder, error) {
+	if taskID == "" {
+		return nil, fmt.Errorf("list reminders: %w", domain.Invalid("id", "task id is required"))
+	}
+
+	if _, err := s.db.Queries.GetTask(ctx, taskID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("list reminders: %w", domain.NotFound("task not found"))
+		}
+		return nil, fmt.Errorf("list reminders: %w", err)
+	}
+
+	reminders, err := s.db.Queries.ListTaskReminders(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("list reminders: %w", err)
+	}
+
+	domainReminders := make([]domain.Reminder, len(reminders))
+	for i, reminder := range reminders {
+		domainReminders[i] = reminderToDomain(reminder)
+	}
+
+	return domainReminders, nil
+}
+
+func (s *ReminderService) GetReminder(ctx context.Context, taskID string, reminderID string) (domain.Reminder, error) {
+	if taskID == "" || reminderID == "" {
+		return domain.Reminder{}, fmt.Errorf("get reminder: %w", domain.Invalid("id", "task id and reminder id are required"))
+	}
+
+	reminder, err := s.db.Queries.GetTaskReminder(ctx, sqlc.GetTaskReminderParams{ID: reminderID, TaskID: taskID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Reminder{}, fmt.Errorf("get reminder: %w", domain.NotFound("reminder not found"))
+		}
+		return domain.Reminder{}, fmt.Errorf("get reminder: %w", err)
+	}
+
+	return reminderToDomain(reminder), nil
+}
+
+func (s *ReminderService) DeleteReminder(ctx context.Context, taskID string, reminderID string) error {
+	if taskID == "" || reminderID == "" {
+		return fmt.Errorf("delete reminder: %w", domain.Invalid("id", "task id and reminder id are required"))
+	}
+
+	result, err := s.db.Queries.DeleteTaskReminder(ctx, sqlc.DeleteTaskReminderParams{ID: reminderID, TaskID: taskID})
+	if err != nil {
+		return fmt.Errorf("delete reminder: %w", err)
+	}
+
+	if result == 0 {
+		return fmt.Errorf("delete reminder: %w", domain.NotFound("reminder not found"))
+	}
+
+	return nil
+}
+
+// FireDueReminders delivers the reminders whose time has come and returns how
+// many were delivered. Each reminder is claimed in the database before it is
+// sent, so a reminder is never delivered twice, even across restarts; one
+// that fails is released and retried on a later run.
+func (s *ReminderService) FireDueReminders(ctx context.Context, now time.Time) (int, error) {
+	reminders, err := s.db.Queries.ListDueTaskReminders(ctx, sqlc.ListDueTaskRemindersParams{
+		Now:   now,
+		Limit: reminderBatchSize,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("fire reminders: %w", err)
+	}
+
+	fired := 0
+	for _, reminder := range reminders {
+		if ctx.Err() != nil {
+			break
+		}
+
+		claimed, err := s.db.Queries.ClaimTaskReminder(ctx, sqlc.ClaimTaskReminderParams{
+			FiredAt: sql.NullTime{Time: now, Valid: true},
+			ID:      reminder.ID,
+		})
+		if err != nil {
+			return fired, fmt.Errorf("fire reminders: %w", err)
+		}
+		if claimed == 0 {
+			continue
+		}
+
+		if err := s.deliver(ctx, reminder); err != nil {
+			s.logger.Printf("failed to deliver reminder %s: %v", reminder.ID, err)
+			s.recordFailure(reminder, err)
+			continue
+		}
+
+		fired++
+	}
+
+	return fired, nil
+}
+
+func (s *ReminderService) deliver(ctx context.Context, reminder sqlc.TaskReminder) error {
+	task, err := s.db.Queries.GetTask(ctx, reminder.TaskID)
+	if err != nil {
+		return err
+	}
+
+	domainTask, err := toDomainTask(ctx, s.db.Queries, task)
+	if err != nil {
+		return err
+	}
+
+	recipients := domainTask.Assignees
+	if len(recipients) == 0 {
+		recipients = []string{reminder.CreatedBy}
+	}
+
+	return s.notifier.Notify(ctx, notify.Notification{
+		ReminderID: reminder.ID,
+		TaskID:     task.ID,
+		TaskKey:    domainTask.Key,
+		Title:      task.Title,
+		DueAt:      domainTask.DueAt,
+		RemindAt:   reminder.RemindAt,
+		Recipients: recipients,
+	})
+}
+
+// recordFailure releases a reminder for another attempt, or gives up once it
+// has been tried maxReminderAttempts times. It uses its own context so the
+// outcome is saved even when delivery was interrupted by shutdown.
+func (s *ReminderService) recordFailure(reminder sqlc.TaskReminder, deliveryErr error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	lastError := sql.NullString{String: deliveryErr.Error(), Valid: true}
+
+	var err error
+	if reminder.Attempts+1 >= maxReminderAttempts {
+		err = s.db.Queries.FailTaskReminder(ctx, sqlc.FailTaskReminderParams{LastError: lastError, ID: reminder.ID})
+	} else {
+		err = s.db.Queries.ReleaseTaskReminder(ctx, sqlc.ReleaseTaskReminderParams{LastError: lastError, ID: reminder.ID})
+	}
+
+	if err != nil {
+		s.logger.Printf("failed to record delivery failure of reminder %s: %v", reminder.ID, err)
+	}
+}
+
+// rescheduleReminders moves the pending reminders that are relative to the
+// due date of a task when that date changes.
+func rescheduleReminders(ctx context.Context, q *sqlc.Queries, taskID string, dueAt time.Time) error {
+	reminders, err := q.ListRelativeTaskReminders(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	for _, reminder := range reminders {
+		err := q.RescheduleTaskReminder(ctx, sqlc.RescheduleTaskReminderParams{
+			RemindAt: dueAt.Add(-time.Duration(reminder.OffsetSeconds.Int64) * time.Second),
+			ID:       reminder.ID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func reminderToDomain(reminder sqlc.TaskReminder) domain.Reminder {
+	before := ""
+	if reminder.OffsetSeconds.Valid {
+		before = (time.Duration(reminder.OffsetSeconds.Int64) * time.Second).String()
+	}
+
+	return domain.Reminder{
+		ID:        uuid.MustParse(reminder.ID),
+		TaskID:    uuid.MustParse(reminder.TaskID),
+		RemindAt:  reminder.RemindAt,
+		Before:    before,
+		CreatedBy: reminder.CreatedBy,
+		FiredAt:   nullTimePtr(reminder.FiredAt),
+		Attempts:  reminder.Attempts,
+		LastError: reminder.LastError.String,
+		CreatedAt: reminder.CreatedAt,
+	}
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/notify"
+	"github.com/alexgolang/ishare-task/internal/app/storage"
+)
+
+type recordingNotifier struct {
+	sent []notify.Notification
+	err  error
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, notification notify.Notification) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.sent = append(n.sent, notification)
+	return nil
+}
+
+func TestReminderService_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, err := sqlite.NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+
+	logger := log.New(os.Stderr, "INTEGRATION_TEST: ", log.LstdFlags)
+	notifier := &recordingNotifier{}
+	taskService := NewTaskService(logger, db, blobs, domain.DefaultWorkflow())
+	reminderService := NewReminderService(logger, db, notifier)
+	ctx := context.Background()
+
+	due := time.Date(2025, time.March, 3, 12, 0, 0, 0, time.UTC)
+	taskID, err := taskService.CreateTask(ctx, &domain.CreateTaskRequest{
+		Title:     "Renew certificate",
+		Assignees: []string{"alice"},
+		DueAt:     &due,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	relative, err := reminderService.CreateReminder(ctx, taskID.String(), "client-a", &domain.CreateReminderRequest{Before: "1h"})
+	if err != nil {
+		t.Fatalf("Failed to create reminder: %v", err)
+	}
+
+	t.Run("relative reminder follows the due date", func(t *testing.T) {
+		if !relative.RemindAt.Equal(due.Add(-time.Hour)) {
+			t.Errorf("Expected reminder at %v, got %v", due.Add(-time.Hour), relative.RemindAt)
+		}
+
+		due = due.Add(24 * time.Hour)
+		if err := taskService.UpdateTask(ctx, taskID.String(), &domain.UpdateTaskRequest{DueAt: &due}, nil); err != nil {
+			t.Fatalf("Failed to update task: %v", err)
+		}
+
+		reminders, err := reminderService.ListReminders(ctx, taskID.String())
+		if err != nil {
+			t.Fatalf("Failed to list reminders: %v", err)
+		}
+
+		if len(reminders) != 1 || !reminders[0].RemindAt.Equal(due.Add(-time.Hour)) {
+			t.Errorf("Expected reminder moved to %v, got %+v", due.Add(-time.Hour), reminders)
+		}
+
+		reminder, err := reminderService.GetReminder(ctx, taskID.String(), relative.ID.String())
+		if err != nil {
+			t.Fatalf("Failed to get reminder: %v", err)
+		}
+		if !reminder.RemindAt.Equal(due.Add(-time.Hour)) {
+			t.Errorf("Expected the moved reminder, got %+v", reminder)
+		}
+	})
+
+	t.Run("either an absolute time or an offset is required", func(t *testing.T) {
+		at := due
+		_, err := reminderService.CreateReminder(ctx, taskID.String(), "client-a", &domain.CreateReminderRequest{RemindAt: &at, Before: "1h"})
+		if err == nil {
+			t.Fatal("Expected error when both are given, got nil")
+		}
+
+		var validationErr *domain.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Fields[0].Message != "exactly one of remind at or before is required" {
+			t.Errorf("Expected the field message without the operation, got %v", err)
+		}
+		if err.Error() != "create reminder: exactly one of remind at or before is required" {
+			t.Errorf("Expected the operation in the error, got %q", err.Error())
+		}
+	})
+
+	t.Run("failed delivery is retried", func(t *testing.T) {
+		notifier.err = errors.New("relay unavailable")
+
+		fired, err := reminderService.FireDueReminders(ctx, due)
+		if err != nil {
+			t.Fatalf("Failed to fire reminders: %v", err)
+		}
+
+		if fired != 0 {
+			t.Errorf("Expected no reminder to be delivered, got %d", fired)
+		}
+
+		reminders, err := reminderService.ListReminders(ctx, taskID.String())
+		if err != nil {
+			t.Fatalf("Failed to list reminders: %v", err)
+		}
+
+		if reminders[0].FiredAt != nil || reminders[0].LastError != "relay unavailable" {
+			t.Errorf("Expected reminder released with its error, got %+v", reminders[0])
+		}
+
+		notifier.err = nil
+	})
+
+	t.Run("reminders fire once", func(t *testing.T) {
+		if fired, err := reminderService.FireDueReminders(ctx, due.Add(-2*time.Hour)); err != nil || fired != 0 {
+			t.Fatalf("Expected nothing due yet, got %d (%v)", fired, err)
+		}
+
+		for i := 0; i < 2; i++ {
+			if _, err := reminderService.FireDueReminders(ctx, due); err != nil {
+				t.Fatalf("Failed to fire reminders: %v", err)
+			}
+		}
+
+		if len(notifier.sent) != 1 {
+			t.Fatalf("Expected exactly one notification, got %d", len(notifier.sent))
+		}
+
+		sent := notifier.sent[0]
+		if sent.TaskKey != "TASK-1" || len(sent.Recipients) != 1 || sent.Recipients[0] != "alice" {
+			t.Errorf("Expected a notification for TASK-1 to alice, got %+v", sent)
+		}
+	})
+}
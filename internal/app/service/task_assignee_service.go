@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite/sqlc"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+)
+
+func (s *TaskService) GetAssignmentHistory(ctx context.Context, taskID string) ([]domain.AssignmentEvent, error) {
+	if taskID == "" {
+		return nil, fmt.Errorf("get assignment history: %w", domain.Invalid("id", "id is required"))
+	}
+
+	if _, err := s.db.Queries.GetTask(ctx, taskID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("get assignment history: %w", domain.NotFound("task not found"))
+		}
+		return nil, fmt.Errorf("get assignment history: %w", err)
+	}
+
+	events, err := s.db.Queries.ListTaskAssignmentEvents(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("get assignment history: %w", err)
+	}
+
+	domainEvents := make([]domain.AssignmentEvent, len(events))
+	for i, event := range events {
+		domainEvents[i] = domain.AssignmentEvent{
+			Assignee:  event.Assignee,
+			Action:    domain.AssignmentAction(event.Action),
+			Actor:     event.Actor,
+			CreatedAt: event.CreatedAt,
+		}
+	}
+
+	return domainEvents, nil
+}
+
+// normalizeAssignees trims assignee IDs and drops duplicates, keeping the
+// order in which they were given.
+func normalizeAssignees(assignees []string) ([]string, error) {
+	seen := make(map[string]bool, len(assignees))
+	normalized := make([]string, 0, len(assignees))
+
+	for _, assignee := range assignees {
+		assignee = strings.TrimSpace(assignee)
+		if assignee == "" {
+			return nil, domain.Invalid("Assignees", "assignee must not be empty")
+		}
+
+		if !seen[assignee] {
+			seen[assignee] = true
+			normalized = append(normalized, assignee)
+		}
+	}
+
+	return normalized, nil
+}
+
+// replaceAssignees makes the task's assignees match the given list and records
+// an assignment event, attributed to the context actor, for every change.
+func replaceAssignees(ctx context.Context, q *sqlc.Queries, taskID string, assignees []string, now time.Time) error {
+	current, err := q.ListTaskAssignees(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	wanted := make(map[string]bool, len(assignees))
+	for _, assignee := range assignees {
+		wanted[assignee] = true
+	}
+
+	existing := make(map[string]bool, len(current))
+	for _, assignee := range current {
+		existing[assignee] = true
+	}
+
+	actor := domain.ActorFromContext(ctx)
+
+	for _, assignee := range current {
+		if wanted[assignee] {
+			continue
+		}
+
+		if _, err := q.DeleteTaskAssignee(ctx, sqlc.DeleteTaskAssigneeParams{TaskID: taskID, Assignee: assignee}); err != nil {
+			return err
+		}
+
+		if err := recordAssignment(ctx, q, taskID, assignee, domain.AssignmentActionUnassigned, actor, now); err != nil {
+			return err
+		}
+	}
+
+	for _, assignee := range assignees {
+		if existing[assignee] {
+			continue
+		}
+
+		err := q.CreateTaskAssignee(ctx, sqlc.CreateTaskAssigneeParams{
+			TaskID:     taskID,
+			Assignee:   assignee,
+			AssignedAt: now,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := recordAssignment(ctx, q, taskID, assignee, domain.AssignmentActionAssigned, actor, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func recordAssignment(ctx context.Context, q *sqlc.Queries, taskID string, assignee string, action domain.AssignmentAction, actor string, now time.Time) error {
+	return q.CreateTaskAssignmentEvent(ctx, sqlc.CreateTaskAssignmentEventParams{
+		TaskID:    taskID,
+		Assignee:  assignee,
+		Action:    string(action),
+		Actor:     actor,
+		CreatedAt: now,
+	})
+}
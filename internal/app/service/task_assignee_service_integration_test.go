@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/storage"
+)
+
+func TestTaskService_Assignees_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, err := sqlite.NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+
+	logger := log.New(os.Stderr, "INTEGRATION_TEST: ", log.LstdFlags)
+	service := NewTaskService(logger, db, blobs, domain.DefaultWorkflow())
+	ctx := domain.WithActor(context.Background(), "client-lead")
+
+	taskID, err := service.CreateTask(ctx, &domain.CreateTaskRequest{
+		Title:     "Shared task",
+		Assignees: []string{"alice", " bob ", "alice"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	if _, err := service.CreateTask(ctx, &domain.CreateTaskRequest{Title: "Unassigned task"}); err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	t.Run("assignees are normalized", func(t *testing.T) {
+		task, err := service.GetTask(ctx, taskID.String())
+		if err != nil {
+			t.Fatalf("Failed to get task: %v", err)
+		}
+
+		if len(task.Assignees) != 2 || task.Assignees[0] != "alice" || task.Assignees[1] != "bob" {
+			t.Errorf("Expected assignees [alice bob], got %v", task.Assignees)
+		}
+	})
+
+	t.Run("filter by assignee", func(t *testing.T) {
+		tasks, err := service.GetTasks(ctx, domain.TaskFilter{Assignee: "bob"})
+		if err != nil {
+			t.Fatalf("Failed to list tasks: %v", err)
+		}
+
+		if len(tasks) != 1 || tasks[0].ID != taskID {
+			t.Errorf("Expected only the shared task, got %+v", tasks)
+		}
+
+		all, err := service.GetTasks(ctx, domain.TaskFilter{})
+		if err != nil {
+			t.Fatalf("Failed to list tasks: %v", err)
+		}
+
+		if len(all) != 2 {
+			t.Errorf("Expected 2 tasks without a filter, got %d", len(all))
+		}
+	})
+
+	t.Run("reassignment bumps UpdatedAt and is recorded", func(t *testing.T) {
+		before, err := service.GetTask(ctx, taskID.String())
+		if err != nil {
+			t.Fatalf("Failed to get task: %v", err)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+
+		assignees := []string{"bob", "carol"}
+		if err := service.UpdateTask(ctx, taskID.String(), &domain.UpdateTaskRequest{Assignees: &assignees}, nil); err != nil {
+			t.Fatalf("Failed to update task: %v", err)
+		}
+
+		after, err := service.GetTask(ctx, taskID.String())
+		if err != nil {
+			t.Fatalf("Failed to get task: %v", err)
+		}
+
+		if !after.UpdatedAt.After(before.UpdatedAt) {
+			t.Errorf("Expected UpdatedAt to move forward, got %v then %v", before.UpdatedAt, after.UpdatedAt)
+		}
+
+		history, err := service.GetAssignmentHistory(ctx, taskID.String())
+		if err != nil {
+			t.Fatalf("Failed to get assignment history: %v", err)
+		}
+
+		// alice and bob assigned on create, alice removed and carol added on update.
+		if len(history) != 4 {
+			t.Fatalf("Expected 4 assignment events, got %+v", history)
+		}
+
+		last := history[len(history)-2:]
+		for _, event := range last {
+			if event.Actor != "client-lead" {
+				t.Errorf("Expected actor client-lead, got %q", event.Actor)
+			}
+		}
+
+		if last[0].Assignee != "alice" || last[0].Action != domain.AssignmentActionUnassigned {
+			t.Errorf("Expected alice to be unassigned, got %+v", last[0])
+		}
+
+		if last[1].Assignee != "carol" || last[1].Action != domain.AssignmentActionAssigned {
+			t.Errorf("Expected carol to be assigned, got %+v", last[1])
+		}
+	})
+}
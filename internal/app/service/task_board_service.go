@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite/sqlc"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/rank"
+)
+
+// GetBoard returns the tasks matching filter with one column per workflow
+// status, each in rank order. Tasks whose status is no longer part of the
+// workflow are left out.
+func (s *TaskService) GetBoard(ctx context.Context, filter domain.TaskFilter) (domain.Board, error) {
+	tasks, err := s.GetTasks(ctx, filter)
+	if err != nil {
+		return domain.Board{}, fmt.Errorf("get board: %w", err)
+	}
+
+	columns := make([]domain.BoardColumn, len(s.workflow.Statuses))
+	index := make(map[domain.TaskStatus]int, len(s.workflow.Statuses))
+	for i, status := range s.workflow.Statuses {
+		columns[i] = domain.BoardColumn{Status: status, Tasks: []domain.Task{}}
+		index[status] = i
+	}
+
+	for _, task := range tasks {
+		if i, ok := index[task.Status]; ok {
+			columns[i].Tasks = append(columns[i].Tasks, task)
+		}
+	}
+
+	return domain.Board{Columns: columns}, nil
+}
+
+// MoveTask places a task between two neighbours in a status, optionally
+// moving it to another status. Reordering only writes the moved task; status
+// changes follow the workflow and are recorded like any other update. When
+// ifMatch is non-empty, the task must be at one of those versions; nil sets
+// no precondition.
+func (s *TaskService) MoveTask(ctx context.Context, id string, req *domain.MoveTaskRequest, ifMatch []int64) error {
+	if id == "" {
+		return fmt.Errorf("move task: %w", domain.Invalid("id", "id is required"))
+	}
+
+	err := s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		current, err := q.GetTask(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return domain.NotFound("task not found")
+			}
+			return err
+		}
+
+		if err := checkVersion(current, ifMatch); err != nil {
+			return err
+		}
+
+		status := current.Status
+		if req.Status != "" {
+			status = req.Status
+		}
+
+		if !s.workflow.HasStatus(status) {
+			return domain.Invalid("Status", "invalid status")
+		}
+
+		key, err := moveRank(ctx, q, current.ID, status, req.AfterID, req.BeforeID)
+		if err != nil {
+			return err
+		}
+
+		if status == current.Status {
+			return q.SetTaskRank(ctx, sqlc.SetTaskRankParams{
+				Rank:      key,
+				UpdatedAt: time.Now().UTC(),
+				ID:        current.ID,
+			})
+		}
+
+		changes, err := currentTaskChanges(ctx, q, current)
+		if err != nil {
+			return err
+		}
+
+		changes.Status = status
+		changes.Rank = key
+		changes.Comment = req.Comment
+
+		return s.applyTaskChanges(ctx, q, current, changes)
+	})
+	if err != nil {
+		return fmt.Errorf("move task: %w", err)
+	}
+
+	return nil
+}
+
+// moveRank returns the rank that places a task right after afterID and right
+// before beforeID in a status. With one neighbour the task goes next to it,
+// with none at the end of the status.
+func moveRank(ctx context.Context, q *sqlc.Queries, taskID string, status domain.TaskStatus, afterID, beforeID string) (string, error) {
+	after, err := neighbourRank(ctx, q, "AfterID", afterID, taskID, status)
+	if err != nil {
+		return "", err
+	}
+
+	before, err := neighbourRank(ctx, q, "BeforeID", beforeID, taskID, status)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case afterID == "" && beforeID == "":
+		return endRank(ctx, q, status, taskID)
+	case beforeID == "":
+		before, err = q.GetNextTaskRank(ctx, sqlc.GetNextTaskRankParams{Status: status, Rank: after, ID: taskID})
+	case afterID == "":
+		after, err = q.GetPreviousTaskRank(ctx, sqlc.GetPreviousTaskRankParams{Status: status, Rank: before, ID: taskID})
+	case after >= before:
+		return "", domain.Conflict("task %s is not above task %s", afterID, beforeID)
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", err
+	}
+
+	return rank.Between(after, before)
+}
+
+// neighbourRank returns the rank of the task a moved task is placed next to,
+// or an empty rank when there is none.
+func neighbourRank(ctx context.Context, q *sqlc.Queries, field, neighbourID, taskID string, status domain.TaskStatus) (string, error) {
+	if neighbourID == "" {
+		return "", nil
+	}
+
+	if neighbourID == taskID {
+		return "", domain.Invalid(field, "a task cannot be placed next to itself")
+	}
+
+	neighbour, err := q.GetTask(ctx, neighbourID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", domain.Invalid(field, "task %s not found", neighbourID)
+		}
+		return "", err
+	}
+
+	if neighbour.Status != status {
+		return "", domain.Conflict("task %s is not in status %s", neighbourID, status)
+	}
+
+	return neighbour.Rank, nil
+}
+
+// endRank returns the rank that places a task at the end of a status. Deleted
+// tasks count too: they keep their rank for when they are restored.
+func endRank(ctx context.Context, q *sqlc.Queries, status domain.TaskStatus, taskID string) (string, error) {
+	last, err := q.GetLastTaskRank(ctx, sqlc.GetLastTaskRankParams{Status: status, ID: taskID})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", err
+	}
+
+	return rank.Between(last, "")
+}
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/storage"
+)
+
+func TestTaskBoard_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, err := sqlite.NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+
+	logger := log.New(os.Stderr, "INTEGRATION_TEST: ", log.LstdFlags)
+	taskService := NewTaskService(logger, db, blobs, domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	ids := map[string]string{}
+	for _, title := range []string{"A", "B", "C", "D"} {
+		id, err := taskService.CreateTask(ctx, &domain.CreateTaskRequest{Title: title, Tags: []string{"board"}})
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+		ids[title] = id.String()
+	}
+
+	// columns returns the titles in each column of the board, by status.
+	columns := func(t *testing.T) map[domain.TaskStatus][]string {
+		t.Helper()
+
+		board, err := taskService.GetBoard(ctx, domain.TaskFilter{Tag: "board"})
+		if err != nil {
+			t.Fatalf("Failed to get board: %v", err)
+		}
+
+		titles := map[domain.TaskStatus][]string{}
+		for _, column := range board.Columns {
+			titles[column.Status] = []string{}
+			for _, task := range column.Tasks {
+				titles[column.Status] = append(titles[column.Status], task.Title)
+			}
+		}
+		return titles
+	}
+
+	expectColumn := func(t *testing.T, status domain.TaskStatus, expected ...string) {
+		t.Helper()
+
+		column := columns(t)[status]
+		if len(column) != len(expected) {
+			t.Fatalf("Expected %s to hold %v, got %v", status, expected, column)
+		}
+		for i := range expected {
+			if column[i] != expected[i] {
+				t.Fatalf("Expected %s to hold %v, got %v", status, expected, column)
+			}
+		}
+	}
+
+	t.Run("new tasks go to the bottom of their column", func(t *testing.T) {
+		board, err := taskService.GetBoard(ctx, domain.TaskFilter{Tag: "board"})
+		if err != nil {
+			t.Fatalf("Failed to get board: %v", err)
+		}
+		if len(board.Columns) != 3 || board.Columns[0].Status != domain.TaskStatusToDo || board.Columns[2].Status != domain.TaskStatusDone {
+			t.Errorf("Expected a column per workflow status, got %+v", board.Columns)
+		}
+
+		expectColumn(t, domain.TaskStatusToDo, "A", "B", "C", "D")
+	})
+
+	t.Run("moving a task within a column only writes that task", func(t *testing.T) {
+		before, err := taskService.GetTask(ctx, ids["B"])
+		if err != nil {
+			t.Fatalf("Failed to get task: %v", err)
+		}
+
+		if err := taskService.MoveTask(ctx, ids["D"], &domain.MoveTaskRequest{AfterID: ids["A"], BeforeID: ids["B"]}, nil); err != nil {
+			t.Fatalf("Failed to move task: %v", err)
+		}
+		expectColumn(t, domain.TaskStatusToDo, "A", "D", "B", "C")
+
+		if err := taskService.MoveTask(ctx, ids["C"], &domain.MoveTaskRequest{BeforeID: ids["A"]}, nil); err != nil {
+			t.Fatalf("Failed to move task: %v", err)
+		}
+		expectColumn(t, domain.TaskStatusToDo, "C", "A", "D", "B")
+
+		if err := taskService.MoveTask(ctx, ids["A"], &domain.MoveTaskRequest{AfterID: ids["B"]}, nil); err != nil {
+			t.Fatalf("Failed to move task: %v", err)
+		}
+		expectColumn(t, domain.TaskStatusToDo, "C", "D", "B", "A")
+
+		after, err := taskService.GetTask(ctx, ids["B"])
+		if err != nil {
+			t.Fatalf("Failed to get task: %v", err)
+		}
+		if after.Version != before.Version || after.Rank != before.Rank {
+			t.Errorf("Expected the neighbour to stay untouched, got version %d and rank %q", after.Version, after.Rank)
+		}
+
+		history, err := taskService.GetTaskHistory(ctx, ids["A"])
+		if err != nil {
+			t.Fatalf("Failed to get history: %v", err)
+		}
+		if len(history) != 1 {
+			t.Errorf("Expected reordering to stay out of the history, got %+v", history)
+		}
+	})
+
+	t.Run("moving a task to another column changes its status", func(t *testing.T) {
+		if err := taskService.MoveTask(ctx, ids["B"], &domain.MoveTaskRequest{Status: domain.TaskStatusInProgress}, nil); err != nil {
+			t.Fatalf("Failed to move task: %v", err)
+		}
+		if err := taskService.MoveTask(ctx, ids["C"], &domain.MoveTaskRequest{Status: domain.TaskStatusInProgress, BeforeID: ids["B"]}, nil); err != nil {
+			t.Fatalf("Failed to move task: %v", err)
+		}
+
+		expectColumn(t, domain.TaskStatusToDo, "D", "A")
+		expectColumn(t, domain.TaskStatusInProgress, "C", "B")
+
+		task, err := taskService.GetTask(ctx, ids["C"])
+		if err != nil {
+			t.Fatalf("Failed to get task: %v", err)
+		}
+		if task.Status != domain.TaskStatusInProgress {
+			t.Errorf("Expected the task to be in progress, got %s", task.Status)
+		}
+	})
+
+	t.Run("status updates move a task to the bottom of its new column", func(t *testing.T) {
+		status := domain.TaskStatusInProgress
+		if err := taskService.UpdateTask(ctx, ids["D"], &domain.UpdateTaskRequest{Status: &status}, nil); err != nil {
+			t.Fatalf("Failed to update task: %v", err)
+		}
+
+		expectColumn(t, domain.TaskStatusInProgress, "C", "B", "D")
+	})
+
+	t.Run("moves follow the workflow", func(t *testing.T) {
+		if err := taskService.MoveTask(ctx, ids["A"], &domain.MoveTaskRequest{Status: domain.TaskStatusDone}, nil); err != nil {
+			t.Fatalf("Failed to move task: %v", err)
+		}
+
+		err := taskService.MoveTask(ctx, ids["A"], &domain.MoveTaskRequest{Status: domain.TaskStatusToDo}, nil)
+		var transitionErr *domain.TransitionError
+		if !errors.As(err, &transitionErr) {
+			t.Errorf("Expected reopening without a comment to be refused, got %v", err)
+		}
+
+		if err := taskService.MoveTask(ctx, ids["A"], &domain.MoveTaskRequest{Status: domain.TaskStatusToDo, Comment: "Not finished"}, nil); err != nil {
+			t.Errorf("Expected reopening with a comment to succeed, got %v", err)
+		}
+	})
+
+	t.Run("invalid moves are refused", func(t *testing.T) {
+		tests := map[string]struct {
+			req      domain.MoveTaskRequest
+			expected error
+		}{
+			"neighbour in another column": {domain.MoveTaskRequest{AfterID: ids["A"]}, domain.ErrConflict},
+			"neighbours out of order":     {domain.MoveTaskRequest{AfterID: ids["D"], BeforeID: ids["C"]}, domain.ErrConflict},
+			"itself as neighbour":         {domain.MoveTaskRequest{AfterID: ids["B"]}, domain.ErrValidation},
+			"unknown neighbour":           {domain.MoveTaskRequest{AfterID: "00000000-0000-0000-0000-000000000000"}, domain.ErrValidation},
+			"unknown status":              {domain.MoveTaskRequest{Status: "archived"}, domain.ErrValidation},
+		}
+
+		for name, tt := range tests {
+			if err := taskService.MoveTask(ctx, ids["B"], &tt.req, nil); !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %s to fail with %v, got %v", name, tt.expected, err)
+			}
+		}
+
+		if err := taskService.MoveTask(ctx, ids["B"], &domain.MoveTaskRequest{}, []int64{1}); !errors.Is(err, domain.ErrVersionMismatch) {
+			t.Errorf("Expected a stale version to be refused, got %v", err)
+		}
+	})
+
+	t.Run("trashed tasks are left out of the ranking", func(t *testing.T) {
+		trashedID, err := taskService.CreateTask(ctx, &domain.CreateTaskRequest{Title: "E", Tags: []string{"board"}})
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+		if err := taskService.DeleteTask(ctx, trashedID.String(), nil); err != nil {
+			t.Fatalf("Failed to delete task: %v", err)
+		}
+
+		id, err := taskService.CreateTask(ctx, &domain.CreateTaskRequest{Title: "F", Tags: []string{"board"}})
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+
+		trashed, err := taskService.GetTrashedTask(ctx, trashedID.String())
+		if err != nil {
+			t.Fatalf("Failed to get trashed task: %v", err)
+		}
+		created, err := taskService.GetTask(ctx, id.String())
+		if err != nil {
+			t.Fatalf("Failed to get task: %v", err)
+		}
+		if created.Rank > trashed.Rank {
+			t.Errorf("Expected the new task not to be ranked after the trashed one, got %q after %q", created.Rank, trashed.Rank)
+		}
+
+		if _, err := taskService.RestoreTask(ctx, trashedID.String()); err != nil {
+			t.Fatalf("Failed to restore task: %v", err)
+		}
+		column := columns(t)[domain.TaskStatusToDo]
+		if len(column) < 2 || column[len(column)-2] != "F" || column[len(column)-1] != "E" {
+			t.Errorf("Expected the restored task at the bottom of its column, got %v", column)
+		}
+	})
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite/sqlc"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+)
+
+const (
+	// defaultBurndownDays is the length of a burndown report without From.
+	defaultBurndownDays = 14
+	// maxBurndownDays limits the length of a burndown report.
+	maxBurndownDays = 366
+)
+
+// GetBurndown builds daily burndown and burnup series for the tasks of a
+// project or tag. Every day is computed by replaying the task history up to
+// the end of that day, so past days show the tasks, estimates and statuses
+// as they were then. Deleted tasks count until the day they were deleted.
+func (s *TaskService) GetBurndown(ctx context.Context, filter domain.BurndownFilter) (domain.BurndownReport, error) {
+	to := filter.To
+	if to.IsZero() {
+		to = time.Now()
+	}
+	to = utcDate(to)
+
+	from := filter.From
+	if from.IsZero() {
+		from = to.AddDate(0, 0, 1-defaultBurndownDays)
+	}
+	from = utcDate(from)
+
+	if to.Before(from) {
+		return domain.BurndownReport{}, fmt.Errorf("get burndown: %w", domain.Invalid("to", "to is before from"))
+	}
+
+	days := int(to.Sub(from)/(24*time.Hour)) + 1
+	if days > maxBurndownDays {
+		return domain.BurndownReport{}, fmt.Errorf("get burndown: %w", domain.Invalid("from", "a report covers at most %d days", maxBurndownDays))
+	}
+
+	tag, err := normalizeFilterTag(filter.Tag)
+	if err != nil {
+		return domain.BurndownReport{}, fmt.Errorf("get burndown: %w", err)
+	}
+
+	if filter.ProjectID != "" {
+		if _, err := s.db.Queries.GetProject(ctx, filter.ProjectID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return domain.BurndownReport{}, fmt.Errorf("get burndown: %w", domain.NotFound("project not found"))
+			}
+			return domain.BurndownReport{}, fmt.Errorf("get burndown: %w", err)
+		}
+	}
+
+	// Only the history of tasks that were ever in the project or carried the
+	// tag is replayed; burndownIncludes picks the days they count on.
+	events, err := s.db.Queries.ListTaskEventsBefore(ctx, sqlc.ListTaskEventsBeforeParams{
+		Before:    to.AddDate(0, 0, 1),
+		ProjectID: filter.ProjectID,
+		Tag:       tag,
+	})
+	if err != nil {
+		return domain.BurndownReport{}, fmt.Errorf("get burndown: %w", err)
+	}
+
+	// Snapshots are replayed in event order; states are decoded again only
+	// for the tasks that changed since the previous day.
+	var taskIDs []string
+	snapshots := map[string]taskSnapshot{}
+	states := map[string]*taskState{}
+	changed := map[string]bool{}
+
+	report := domain.BurndownReport{
+		ProjectID: filter.ProjectID,
+		Tag:       tag,
+		From:      from.Format(time.DateOnly),
+		To:        to.Format(time.DateOnly),
+		Points:    make([]domain.BurndownPoint, 0, days),
+	}
+
+	next := 0
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		end := day.AddDate(0, 0, 1)
+		for ; next < len(events) && events[next].CreatedAt.Before(end); next++ {
+			event := events[next]
+			if _, seen := snapshots[event.TaskID]; !seen {
+				taskIDs = append(taskIDs, event.TaskID)
+			}
+
+			snapshot, err := applyTaskEvent(snapshots[event.TaskID], event)
+			if err != nil {
+				return domain.BurndownReport{}, fmt.Errorf("get burndown: %w", err)
+			}
+			snapshots[event.TaskID] = snapshot
+			changed[event.TaskID] = true
+		}
+
+		for taskID := range changed {
+			delete(states, taskID)
+			if snapshot := snapshots[taskID]; snapshot != nil {
+				state, err := snapshot.state()
+				if err != nil {
+					return domain.BurndownReport{}, fmt.Errorf("get burndown: task %s: %w", taskID, err)
+				}
+				states[taskID] = &state
+			}
+			delete(changed, taskID)
+		}
+
+		point := domain.BurndownPoint{Date: day.Format(time.DateOnly)}
+		for _, taskID := range taskIDs {
+			state := states[taskID]
+			if state == nil || !burndownIncludes(state, filter.ProjectID, tag) {
+				continue
+			}
+			addToBurndown(&point, state, s.workflow.IsDone(state.Status))
+		}
+		report.Points = append(report.Points, point)
+	}
+
+	if last := len(report.Points) - 1; last > 0 {
+		start := report.Points[0].Remaining
+		for i := range report.Points {
+			report.Points[i].Ideal = start * float64(last-i) / float64(last)
+		}
+	}
+
+	return report, nil
+}
+
+// burndownIncludes reports whether a task belongs to the project and carries
+// the tag a burndown report follows.
+func burndownIncludes(state *taskState, projectID, tag string) bool {
+	if projectID != "" && state.ProjectID != projectID {
+		return false
+	}
+	return tag == "" || slices.Contains(state.Tags, tag)
+}
+
+// addToBurndown counts a task in the point of a day. Done tasks have no work
+// left, whatever their remaining work says.
+func addToBurndown(point *domain.BurndownPoint, state *taskState, done bool) {
+	point.Tasks++
+
+	var estimate float64
+	if state.Estimate != nil {
+		estimate = *state.Estimate
+	} else {
+		point.Unestimated++
+	}
+	point.Scope += estimate
+
+	if done {
+		point.DoneTasks++
+		point.Completed += estimate
+		return
+	}
+
+	if state.Remaining != nil {
+		point.Remaining += *state.Remaining
+	} else {
+		point.Remaining += estimate
+	}
+}
+
+// utcDate returns midnight UTC of the day t falls on in UTC.
+func utcDate(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite/sqlc"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/storage"
+	"github.com/google/uuid"
+)
+
+func TestTaskBurndown_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, err := sqlite.NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+
+	logger := log.New(os.Stderr, "INTEGRATION_TEST: ", log.LstdFlags)
+	taskService := NewTaskService(logger, db, blobs, domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	// record writes a history event in the past, as if the task had been
+	// changed back then.
+	record := func(taskID string, action domain.TaskEventAction, at time.Time, fields map[string]any) {
+		t.Helper()
+
+		changes := []domain.FieldChange{}
+		for field, value := range fields {
+			data, err := json.Marshal(value)
+			if err != nil {
+				t.Fatalf("Failed to encode %s: %v", field, err)
+			}
+			changes = append(changes, domain.FieldChange{Field: field, After: data})
+		}
+
+		data, err := json.Marshal(changes)
+		if err != nil {
+			t.Fatalf("Failed to encode changes: %v", err)
+		}
+
+		err = db.Queries.CreateTaskEvent(ctx, sqlc.CreateTaskEventParams{
+			ID:        uuid.New().String(),
+			TaskID:    taskID,
+			Action:    string(action),
+			Actor:     "test",
+			Changes:   string(data),
+			CreatedAt: at,
+		})
+		if err != nil {
+			t.Fatalf("Failed to record event: %v", err)
+		}
+	}
+
+	monday := time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+	wednesday := monday.AddDate(0, 0, 2)
+	project := domain.DefaultProjectID.String()
+
+	first, second, third := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	record(first, domain.TaskEventCreated, monday.Add(10*time.Hour), map[string]any{
+		"project_id": project, "status": "to_do", "tags": []string{"sprint-1"}, "estimate": 5, "remaining": 5,
+	})
+	record(second, domain.TaskEventCreated, monday.Add(11*time.Hour), map[string]any{
+		"project_id": project, "status": "to_do", "tags": []string{"sprint-1"}, "estimate": 3,
+	})
+	record(third, domain.TaskEventCreated, tuesday.Add(9*time.Hour), map[string]any{
+		"project_id": project, "status": "to_do", "tags": []string{}, "estimate": 8,
+	})
+	record(first, domain.TaskEventUpdated, tuesday.Add(12*time.Hour), map[string]any{"remaining": 2})
+	record(second, domain.TaskEventDeleted, tuesday.Add(15*time.Hour), nil)
+	record(first, domain.TaskEventUpdated, wednesday.Add(9*time.Hour), map[string]any{"status": "done", "remaining": 0})
+	record(third, domain.TaskEventUpdated, wednesday.Add(10*time.Hour), map[string]any{"tags": []string{"sprint-1"}})
+
+	t.Run("every day replays the history up to its end", func(t *testing.T) {
+		report, err := taskService.GetBurndown(ctx, domain.BurndownFilter{Tag: "Sprint-1", From: monday, To: wednesday})
+		if err != nil {
+			t.Fatalf("Failed to get burndown: %v", err)
+		}
+
+		expected := []domain.BurndownPoint{
+			{Date: "2025-03-03", Scope: 8, Remaining: 8, Ideal: 8, Tasks: 2},
+			{Date: "2025-03-04", Scope: 5, Remaining: 2, Ideal: 4, Tasks: 1},
+			{Date: "2025-03-05", Scope: 13, Completed: 5, Remaining: 8, Ideal: 0, Tasks: 2, DoneTasks: 1},
+		}
+		if len(report.Points) != len(expected) {
+			t.Fatalf("Expected %d points, got %+v", len(expected), report.Points)
+		}
+		for i, point := range report.Points {
+			if point != expected[i] {
+				t.Errorf("Expected %+v, got %+v", expected[i], point)
+			}
+		}
+		if report.Tag != "sprint-1" || report.From != "2025-03-03" || report.To != "2025-03-05" {
+			t.Errorf("Expected the normalized filter in the report, got %+v", report)
+		}
+	})
+
+	t.Run("days before any history are empty", func(t *testing.T) {
+		report, err := taskService.GetBurndown(ctx, domain.BurndownFilter{ProjectID: project, From: monday.AddDate(0, 0, -1), To: monday})
+		if err != nil {
+			t.Fatalf("Failed to get burndown: %v", err)
+		}
+		if len(report.Points) != 2 || report.Points[0].Tasks != 0 || report.Points[1].Tasks != 2 {
+			t.Errorf("Expected no tasks on Sunday and two on Monday, got %+v", report.Points)
+		}
+	})
+
+	t.Run("tasks count while they are in the project", func(t *testing.T) {
+		other := uuid.NewString()
+		moved, elsewhere := uuid.NewString(), uuid.NewString()
+		record(moved, domain.TaskEventCreated, monday.Add(12*time.Hour), map[string]any{"project_id": other, "status": "to_do", "estimate": 1})
+		record(elsewhere, domain.TaskEventCreated, monday.Add(12*time.Hour), map[string]any{"project_id": other, "status": "to_do", "estimate": 1})
+		record(moved, domain.TaskEventUpdated, tuesday.Add(12*time.Hour), map[string]any{"project_id": project})
+
+		report, err := taskService.GetBurndown(ctx, domain.BurndownFilter{ProjectID: project, From: monday, To: wednesday})
+		if err != nil {
+			t.Fatalf("Failed to get burndown: %v", err)
+		}
+
+		tasks := []int64{}
+		for _, point := range report.Points {
+			tasks = append(tasks, point.Tasks)
+		}
+		if !slices.Equal(tasks, []int64{2, 3, 3}) {
+			t.Errorf("Expected the moved task from Tuesday on, got %v tasks per day", tasks)
+		}
+	})
+
+	t.Run("estimates are recorded in the task history", func(t *testing.T) {
+		estimate := 5.0
+		id, err := taskService.CreateTask(ctx, &domain.CreateTaskRequest{Title: "Estimated", Tags: []string{"live"}, Estimate: &estimate})
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+
+		task, err := taskService.GetTask(ctx, id.String())
+		if err != nil {
+			t.Fatalf("Failed to get task: %v", err)
+		}
+		if task.Estimate == nil || *task.Estimate != 5 || task.Remaining == nil || *task.Remaining != 5 {
+			t.Errorf("Expected the remaining work to default to the estimate, got %v %v", task.Estimate, task.Remaining)
+		}
+
+		remaining := 1.5
+		if err := taskService.UpdateTask(ctx, id.String(), &domain.UpdateTaskRequest{Remaining: &remaining}, nil); err != nil {
+			t.Fatalf("Failed to update task: %v", err)
+		}
+
+		events, err := taskService.GetTaskHistory(ctx, id.String())
+		if err != nil {
+			t.Fatalf("Failed to get history: %v", err)
+		}
+		last := events[len(events)-1]
+		if len(last.Changes) != 1 || last.Changes[0].Field != "remaining" || string(last.Changes[0].After) != "1.5" {
+			t.Errorf("Expected the remaining work change to be recorded, got %+v", last.Changes)
+		}
+
+		report, err := taskService.GetBurndown(ctx, domain.BurndownFilter{Tag: "live"})
+		if err != nil {
+			t.Fatalf("Failed to get burndown: %v", err)
+		}
+		today := report.Points[len(report.Points)-1]
+		if len(report.Points) != 14 || today.Date != time.Now().UTC().Format(time.DateOnly) || today.Scope != 5 || today.Remaining != 1.5 {
+			t.Errorf("Expected two weeks up to today with the live task, got %+v", report.Points)
+		}
+	})
+
+	t.Run("invalid estimates and ranges are refused", func(t *testing.T) {
+		negative := -1.0
+		if _, err := taskService.CreateTask(ctx, &domain.CreateTaskRequest{Title: "Negative", Estimate: &negative}); !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("Expected a negative estimate to be refused, got %v", err)
+		}
+
+		for name, filter := range map[string]domain.BurndownFilter{
+			"reversed": {From: tuesday, To: monday},
+			"too long": {From: monday.AddDate(-2, 0, 0), To: monday},
+		} {
+			if _, err := taskService.GetBurndown(ctx, filter); !errors.Is(err, domain.ErrValidation) {
+				t.Errorf("Expected the %s range to be refused, got %v", name, err)
+			}
+		}
+
+		if _, err := taskService.GetBurndown(ctx, domain.BurndownFilter{ProjectID: uuid.NewString()}); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Expected an unknown project to be reported, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite/sqlc"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/google/uuid"
+)
+
+func (s *TaskService) AddDependency(ctx context.Context, taskID string, blockedByID string) error {
+	if taskID == "" || blockedByID == "" {
+		return fmt.Errorf("add dependency: %w", domain.Invalid("id", "task id and blocker id are required"))
+	}
+
+	if taskID == blockedByID {
+		return fmt.Errorf("add dependency: %w", domain.Invalid("BlockedByID", "task cannot block itself"))
+	}
+
+	return s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		for _, id := range []string{taskID, blockedByID} {
+			if _, err := q.GetTask(ctx, id); err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return fmt.Errorf("add dependency: %w", domain.NotFound("task %s not found", id))
+				}
+				return fmt.Errorf("add dependency: %w", err)
+			}
+		}
+
+		// The new edge closes a cycle if the blocker already waits on the task.
+		upstream, err := q.GetTransitiveBlockerIDs(ctx, blockedByID)
+		if err != nil {
+			return fmt.Errorf("add dependency: %w", err)
+		}
+
+		for _, id := range upstream {
+			if id == taskID {
+				return fmt.Errorf("add dependency: %w", domain.Conflict("dependency would create a cycle"))
+			}
+		}
+
+		err = q.CreateTaskDependency(ctx, sqlc.CreateTaskDependencyParams{
+			TaskID:      taskID,
+			BlockedByID: blockedByID,
+			CreatedAt:   time.Now().UTC(),
+		})
+		if err != nil {
+			return fmt.Errorf("add dependency: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (s *TaskService) RemoveDependency(ctx context.Context, taskID string, blockedByID string) error {
+	if taskID == "" || blockedByID == "" {
+		return fmt.Errorf("remove dependency: %w", domain.Invalid("id", "task id and blocker id are required"))
+	}
+
+	result, err := s.db.Queries.DeleteTaskDependency(ctx, sqlc.DeleteTaskDependencyParams{
+		TaskID:      taskID,
+		BlockedByID: blockedByID,
+	})
+	if err != nil {
+		return fmt.Errorf("remove dependency: %w", err)
+	}
+
+	if result == 0 {
+		return fmt.Errorf("remove dependency: %w", domain.NotFound("dependency not found"))
+	}
+
+	return nil
+}
+
+// GetDependencyGraph returns every task the given task transitively waits on,
+// topologically sorted so that blockers come before the tasks they block,
+// together with the longest chain of blockers ending at the task.
+func (s *TaskService) GetDependencyGraph(ctx context.Context, taskID string) (domain.DependencyGraph, error) {
+	if taskID == "" {
+		return domain.DependencyGraph{}, fmt.Errorf("get dependency graph: %w", domain.Invalid("id", "id is required"))
+	}
+
+	root, err := s.db.Queries.GetTask(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.DependencyGraph{}, fmt.Errorf("get dependency graph: %w", domain.NotFound("task not found"))
+		}
+		return domain.DependencyGraph{}, fmt.Errorf("get dependency graph: %w", err)
+	}
+
+	edges, err := s.db.Queries.GetDependencyEdges(ctx, taskID)
+	if err != nil {
+		return domain.DependencyGraph{}, fmt.Errorf("get dependency graph: %w", err)
+	}
+
+	order, criticalPath := sortDependencies(taskID, edges)
+
+	tasks := make([]domain.Task, 0, len(order))
+	for _, id := range order {
+		task := root
+		if id != taskID {
+			task, err = s.db.Queries.GetTask(ctx, id)
+			if err != nil {
+				return domain.DependencyGraph{}, fmt.Errorf("get dependency graph: %w", err)
+			}
+		}
+
+		domainTask, err := toDomainTask(ctx, s.db.Queries, task)
+		if err != nil {
+			return domain.DependencyGraph{}, fmt.Errorf("get dependency graph: %w", err)
+		}
+		tasks = append(tasks, domainTask)
+	}
+
+	domainEdges := make([]domain.TaskDependency, len(edges))
+	for i, edge := range edges {
+		domainEdges[i] = domain.TaskDependency{
+			TaskID:      uuid.MustParse(edge.TaskID),
+			BlockedByID: uuid.MustParse(edge.BlockedByID),
+			CreatedAt:   edge.CreatedAt,
+		}
+	}
+
+	path := make([]uuid.UUID, len(criticalPath))
+	for i, id := range criticalPath {
+		path[i] = uuid.MustParse(id)
+	}
+
+	return domain.DependencyGraph{
+		TaskID:       uuid.MustParse(taskID),
+		Tasks:        tasks,
+		Edges:        domainEdges,
+		CriticalPath: path,
+	}, nil
+}
+
+// sortDependencies orders the graph with Kahn's algorithm, breaking ties by ID
+// so the result is stable, and returns the longest blocker chain ending at root.
+func sortDependencies(root string, edges []sqlc.TaskDependency) ([]string, []string) {
+	blockers := make(map[string][]string)
+	dependents := make(map[string][]string)
+	pending := map[string]int{root: 0}
+
+	for _, edge := range edges {
+		blockers[edge.TaskID] = append(blockers[edge.TaskID], edge.BlockedByID)
+		dependents[edge.BlockedByID] = append(dependents[edge.BlockedByID], edge.TaskID)
+		pending[edge.TaskID]++
+		if _, ok := pending[edge.BlockedByID]; !ok {
+			pending[edge.BlockedByID] = 0
+		}
+	}
+
+	var ready []string
+	for id, count := range pending {
+		if count == 0 {
+			ready = append(ready, id)
+		}
+	}
+
+	order := make([]string, 0, len(pending))
+	for len(ready) > 0 {
+		sort.Strings(ready)
+		id := ready[0]
+		ready = ready[1:]
+		order = append(order, id)
+
+		for _, dependent := range dependents[id] {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	depth := make(map[string]int, len(order))
+	previous := make(map[string]string, len(order))
+	for _, id := range order {
+		depth[id] = 1
+		for _, blocker := range blockers[id] {
+			if depth[blocker]+1 > depth[id] {
+				depth[id] = depth[blocker] + 1
+				previous[id] = blocker
+			}
+		}
+	}
+
+	var path []string
+	for id := root; id != ""; id = previous[id] {
+		path = append([]string{id}, path...)
+	}
+
+	return order, path
+}
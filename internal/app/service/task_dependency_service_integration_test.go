@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/storage"
+	"github.com/google/uuid"
+)
+
+func TestTaskService_Dependencies_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, err := sqlite.NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	logger := log.New(os.Stderr, "INTEGRATION_TEST: ", log.LstdFlags)
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+
+	service := NewTaskService(logger, db, blobs, domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	createTask := func(title string) string {
+		id, err := service.CreateTask(ctx, &domain.CreateTaskRequest{Title: title})
+		if err != nil {
+			t.Fatalf("Failed to create task %q: %v", title, err)
+		}
+		return id.String()
+	}
+
+	design := createTask("Design")
+	build := createTask("Build")
+	release := createTask("Release")
+	docs := createTask("Docs")
+
+	for _, edge := range [][2]string{{build, design}, {release, build}, {release, docs}} {
+		if err := service.AddDependency(ctx, edge[0], edge[1]); err != nil {
+			t.Fatalf("Failed to add dependency: %v", err)
+		}
+	}
+
+	t.Run("cycle is rejected", func(t *testing.T) {
+		err := service.AddDependency(ctx, design, release)
+
+		if err == nil {
+			t.Fatal("Expected error for cyclic dependency, got nil")
+		}
+
+		expectedError := "add dependency: dependency would create a cycle"
+		if err.Error() != expectedError {
+			t.Errorf("Expected error %q, got %q", expectedError, err.Error())
+		}
+	})
+
+	t.Run("self dependency is rejected", func(t *testing.T) {
+		if err := service.AddDependency(ctx, design, design); err == nil {
+			t.Fatal("Expected error for self dependency, got nil")
+		}
+	})
+
+	t.Run("blocked task cannot start", func(t *testing.T) {
+		inProgress := domain.TaskStatusInProgress
+
+		err := service.UpdateTask(ctx, build, &domain.UpdateTaskRequest{Status: &inProgress}, nil)
+		if err == nil {
+			t.Fatal("Expected error for blocked task, got nil")
+		}
+
+		done := domain.TaskStatusDone
+		if err := service.UpdateTask(ctx, design, &domain.UpdateTaskRequest{Status: &done}, nil); err != nil {
+			t.Fatalf("Failed to complete blocker: %v", err)
+		}
+
+		if err := service.UpdateTask(ctx, build, &domain.UpdateTaskRequest{Status: &inProgress}, nil); err != nil {
+			t.Errorf("Expected unblocked task to start, got %v", err)
+		}
+	})
+
+	t.Run("graph is topologically sorted", func(t *testing.T) {
+		graph, err := service.GetDependencyGraph(ctx, release)
+		if err != nil {
+			t.Fatalf("Failed to get dependency graph: %v", err)
+		}
+
+		if len(graph.Tasks) != 4 {
+			t.Fatalf("Expected 4 tasks in graph, got %d", len(graph.Tasks))
+		}
+
+		position := make(map[uuid.UUID]int)
+		for i, task := range graph.Tasks {
+			position[task.ID] = i
+		}
+
+		for _, edge := range graph.Edges {
+			if position[edge.BlockedByID] > position[edge.TaskID] {
+				t.Errorf("Blocker %v sorted after dependent %v", edge.BlockedByID, edge.TaskID)
+			}
+		}
+
+		expectedPath := []string{design, build, release}
+		if len(graph.CriticalPath) != len(expectedPath) {
+			t.Fatalf("Expected critical path of %d tasks, got %d", len(expectedPath), len(graph.CriticalPath))
+		}
+
+		for i, id := range expectedPath {
+			if graph.CriticalPath[i].String() != id {
+				t.Errorf("Critical path step %d: expected %v, got %v", i, id, graph.CriticalPath[i])
+			}
+		}
+	})
+
+	t.Run("trashed blockers are left out", func(t *testing.T) {
+		deploy := createTask("Deploy")
+		review := createTask("Review")
+		audit := createTask("Audit")
+
+		for _, edge := range [][2]string{{deploy, review}, {review, audit}} {
+			if err := service.AddDependency(ctx, edge[0], edge[1]); err != nil {
+				t.Fatalf("Failed to add dependency: %v", err)
+			}
+		}
+
+		if err := service.DeleteTask(ctx, review, nil); err != nil {
+			t.Fatalf("Failed to delete task: %v", err)
+		}
+
+		graph, err := service.GetDependencyGraph(ctx, deploy)
+		if err != nil {
+			t.Fatalf("Failed to get dependency graph: %v", err)
+		}
+
+		if len(graph.Tasks) != 1 || len(graph.Edges) != 0 {
+			t.Errorf("Expected only the task itself, got %d tasks and %d edges", len(graph.Tasks), len(graph.Edges))
+		}
+
+		if err := service.AddDependency(ctx, audit, deploy); err != nil {
+			t.Errorf("Expected no cycle through the trashed task, got %v", err)
+		}
+	})
+
+	t.Run("custom workflows set the done and started statuses", func(t *testing.T) {
+		custom := NewTaskService(logger, db, blobs, domain.Workflow{
+			InitialStatus:   "backlog",
+			Statuses:        []domain.TaskStatus{"backlog", "doing", "shipped"},
+			DoneStatuses:    []domain.TaskStatus{"shipped"},
+			StartedStatuses: []domain.TaskStatus{"doing"},
+			Transitions: []domain.WorkflowTransition{
+				{From: "backlog", To: "doing"},
+				{From: "doing", To: "shipped"},
+			},
+		})
+
+		spec, err := custom.CreateTask(ctx, &domain.CreateTaskRequest{Title: "Spec"})
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+		code, err := custom.CreateTask(ctx, &domain.CreateTaskRequest{Title: "Code"})
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+		if err := custom.AddDependency(ctx, code.String(), spec.String()); err != nil {
+			t.Fatalf("Failed to add dependency: %v", err)
+		}
+
+		doing := domain.TaskStatus("doing")
+		if err := custom.UpdateTask(ctx, code.String(), &domain.UpdateTaskRequest{Status: &doing}, nil); !errors.Is(err, domain.ErrConflict) {
+			t.Fatalf("Expected the blocked task not to start, got %v", err)
+		}
+
+		shipped := domain.TaskStatus("shipped")
+		for _, status := range []*domain.TaskStatus{&doing, &shipped} {
+			if err := custom.UpdateTask(ctx, spec.String(), &domain.UpdateTaskRequest{Status: status}, nil); err != nil {
+				t.Fatalf("Failed to move blocker to %s: %v", *status, err)
+			}
+		}
+
+		if err := custom.UpdateTask(ctx, code.String(), &domain.UpdateTaskRequest{Status: &doing}, nil); err != nil {
+			t.Errorf("Expected a shipped blocker to be done, got %v", err)
+		}
+	})
+
+	t.Run("purging a task removes its edges", func(t *testing.T) {
+		if err := service.PurgeTask(ctx, docs); err != nil {
+			t.Fatalf("Failed to purge task: %v", err)
+		}
+
+		if err := service.RemoveDependency(ctx, release, docs); err == nil {
+			t.Error("Expected dependency to be removed with the task")
+		}
+	})
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+)
+
+// ExportTasks passes every task matching the filter to fn, one at a time, so
+// that callers can stream them out. It stops at the first error fn returns.
+func (s *TaskService) ExportTasks(ctx context.Context, filter domain.TaskFilter, fn func(task domain.Task) error) error {
+	tasks, err := s.listTasks(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("export tasks: %w", err)
+	}
+
+	for _, task := range tasks {
+		domainTask, err := toDomainTask(ctx, s.db.Queries, task)
+		if err != nil {
+			return fmt.Errorf("export tasks: %w", err)
+		}
+
+		if err := fn(domainTask); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite/sqlc"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/filter"
+)
+
+// listTasks returns the tasks matching a filter in board order. Filters with
+// an expression are compiled into a query of their own; the others use the
+// static GetTasks query.
+func (s *TaskService) listTasks(ctx context.Context, taskFilter domain.TaskFilter) ([]sqlc.Task, error) {
+	if taskFilter.Expression == "" {
+		params, err := getTasksParams(taskFilter)
+		if err != nil {
+			return nil, err
+		}
+		return s.db.Queries.GetTasks(ctx, params)
+	}
+
+	query, err := s.filterQuery(ctx, taskFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryTasks(ctx, query.OrderBy(domain.DefaultTaskSort...))
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]sqlc.Task, 0, len(rows))
+	for _, row := range rows {
+		task, err := s.db.Queries.GetTask(ctx, row.ID)
+		if err != nil {
+			// Deleted between the listing and now.
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+// filterQuery returns a query for the tasks matching a filter. The filter
+// expression is checked against the task fields, with "me" standing for the
+// client in ctx.
+func (s *TaskService) filterQuery(ctx context.Context, taskFilter domain.TaskFilter) (*sqlite.TaskQuery, error) {
+	query := sqlite.NewTaskQuery()
+
+	if taskFilter.Expression != "" {
+		expr, err := filter.Parse(taskFilter.Expression, s.taskFilterSchema(domain.ActorFromContext(ctx)))
+		if err != nil {
+			return nil, domain.Classify(domain.ErrValidation, err)
+		}
+		query.Filter(expr, time.Now())
+	}
+
+	if taskFilter.Assignee != "" {
+		query.AssignedTo(taskFilter.Assignee)
+	}
+	if taskFilter.ProjectID != "" {
+		query.InProject(taskFilter.ProjectID)
+	}
+	tag, err := normalizeFilterTag(taskFilter.Tag)
+	if err != nil {
+		return nil, err
+	}
+	if tag != "" {
+		query.Tagged(tag)
+	}
+
+	return query, nil
+}
+
+// taskFilterSchema lists the task fields filter expressions can use. "me"
+// stands for the given client.
+func (s *TaskService) taskFilterSchema(client string) filter.Schema {
+	statuses := make([]string, len(s.workflow.Statuses))
+	for i, status := range s.workflow.Statuses {
+		statuses[i] = string(status)
+	}
+
+	priorities := []string{string(domain.TaskPriorityLow), string(domain.TaskPriorityMedium), string(domain.TaskPriorityHigh)}
+
+	return filter.Schema{
+		"status":      {Type: filter.TypeEnum, Values: statuses},
+		"priority":    {Type: filter.TypeEnum, Values: priorities, Ordered: true, Fold: true},
+		"title":       {Type: filter.TypeText},
+		"description": {Type: filter.TypeText, Nullable: true},
+		"project":     {Type: filter.TypeID},
+		"assignee":    {Type: filter.TypeSet, Nullable: true, Aliases: map[string]string{"me": client}},
+		"tag":         {Type: filter.TypeSet, Nullable: true, Fold: true},
+		"estimate":    {Type: filter.TypeNumber, Nullable: true},
+		"remaining":   {Type: filter.TypeNumber, Nullable: true},
+		"due":         {Type: filter.TypeTime, Nullable: true},
+		"created":     {Type: filter.TypeTime},
+		"updated":     {Type: filter.TypeTime},
+	}
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/filter"
+	"github.com/alexgolang/ishare-task/internal/app/storage"
+)
+
+func TestTaskFilter_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, err := sqlite.NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+
+	logger := log.New(os.Stderr, "INTEGRATION_TEST: ", log.LstdFlags)
+	taskService := NewTaskService(logger, db, blobs, domain.DefaultWorkflow())
+	projectService := NewProjectService(logger, db)
+	ctx := domain.WithActor(context.Background(), "alice")
+
+	project, err := projectService.CreateProject(ctx, &domain.CreateProjectRequest{Key: "OPS", Name: "Operations"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	three := 3.0
+	tasks := []domain.CreateTaskRequest{
+		{Title: "Fix login", Description: "Users see a 500", Priority: domain.TaskPriorityHigh, Assignees: []string{"alice"}, Tags: []string{"urgent"}},
+		{Title: "Write docs", Priority: domain.TaskPriorityLow, Status: domain.TaskStatusDone, Estimate: &three},
+		{Title: "Rotate keys", Priority: domain.TaskPriorityMedium, Assignees: []string{"bob"}, Tags: []string{"security", "urgent"}, ProjectID: project.ID.String()},
+		{Title: "Plan sprint", Priority: domain.TaskPriorityHigh, Status: domain.TaskStatusDone, Assignees: []string{"alice", "bob"}},
+	}
+	for i := range tasks {
+		if _, err := taskService.CreateTask(ctx, &tasks[i]); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+
+	t.Run("expressions select tasks", func(t *testing.T) {
+		tests := map[string][]string{
+			`priority = high and status != done or tag = urgent`:   {"Fix login", "Rotate keys"},
+			`priority = high and (status != done or tag = urgent)`: {"Fix login"},
+			`priority >= medium`:                       {"Fix login", "Rotate keys", "Plan sprint"},
+			`status in (done) and not priority = low`:  {"Plan sprint"},
+			`title contains "LOG"`:                     {"Fix login"},
+			`description contains "500"`:               {"Fix login"},
+			`description = null`:                       {"Write docs", "Rotate keys", "Plan sprint"},
+			`assignee = me`:                            {"Fix login", "Plan sprint"},
+			`assignee != me`:                           {"Write docs", "Rotate keys"},
+			`assignee = null`:                          {"Write docs"},
+			`assignee in (bob, carol)`:                 {"Rotate keys", "Plan sprint"},
+			`tag not in (urgent)`:                      {"Write docs", "Plan sprint"},
+			`tag contains SECURITY`:                    {"Rotate keys"},
+			`project = ops`:                            {"Rotate keys"},
+			`project != "` + project.ID.String() + `"`: {"Fix login", "Write docs", "Plan sprint"},
+			`estimate >= 3`:                            {"Write docs"},
+			`not estimate >= 3`:                        {"Fix login", "Rotate keys", "Plan sprint"},
+			`estimate != 3`:                            {"Fix login", "Rotate keys", "Plan sprint"},
+			`due = null and created > now-1h`:          {"Fix login", "Write docs", "Rotate keys", "Plan sprint"},
+			`updated < today-1d`:                       {},
+			`priority < low`:                           {},
+		}
+
+		for expression, expected := range tests {
+			result, err := taskService.GetTasks(ctx, domain.TaskFilter{Expression: expression})
+			if err != nil {
+				t.Errorf("Expected %q to run, got %v", expression, err)
+				continue
+			}
+
+			titles := []string{}
+			for _, task := range result {
+				titles = append(titles, task.Title)
+			}
+			slices.Sort(titles)
+			slices.Sort(expected)
+			if !slices.Equal(titles, expected) {
+				t.Errorf("Expected %q to select %v, got %v", expression, expected, titles)
+			}
+		}
+	})
+
+	t.Run("expressions combine with the other filters", func(t *testing.T) {
+		result, err := taskService.GetTasks(ctx, domain.TaskFilter{Assignee: "bob", Expression: `status = done`})
+		if err != nil {
+			t.Fatalf("Failed to get tasks: %v", err)
+		}
+		if len(result) != 1 || result[0].Title != "Plan sprint" {
+			t.Errorf("Expected only bob's finished task, got %+v", result)
+		}
+	})
+
+	t.Run("invalid expressions are refused with their position", func(t *testing.T) {
+		_, err := taskService.GetTasks(ctx, domain.TaskFilter{Expression: `priority = high and stat = done`})
+
+		var filterErr *filter.Error
+		if !errors.Is(err, domain.ErrValidation) || !errors.As(err, &filterErr) {
+			t.Fatalf("Expected a validation error, got %v", err)
+		}
+		if filterErr.Pos != 21 {
+			t.Errorf("Expected the error at position 21, got %d", filterErr.Pos)
+		}
+
+		_, err = taskService.GetTasks(ctx, domain.TaskFilter{Expression: `status = archived`})
+		if !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("Expected statuses outside the workflow to be refused, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,321 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite/sqlc"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/google/uuid"
+)
+
+// taskState is the part of a task that is tracked by its history. The JSON
+// names are the field names recorded in task events.
+type taskState struct {
+	Key         string              `json:"key"`
+	ProjectID   string              `json:"project_id"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Status      domain.TaskStatus   `json:"status"`
+	Priority    domain.TaskPriority `json:"priority"`
+	Assignees   []string            `json:"assignees"`
+	Tags        []string            `json:"tags"`
+	DueAt       *time.Time          `json:"due_at"`
+	SeriesID    *uuid.UUID          `json:"series_id"`
+	Estimate    *float64            `json:"estimate"`
+	Remaining   *float64            `json:"remaining"`
+	ParentID    *uuid.UUID          `json:"parent_id"`
+	TemplateID  *uuid.UUID          `json:"template_id"`
+}
+
+// taskStateFields fixes the order in which changes are recorded.
+var taskStateFields = []string{
+	"key", "project_id", "title", "description", "status", "priority", "assignees", "tags", "due_at", "series_id",
+	"estimate", "remaining", "parent_id", "template_id",
+}
+
+// taskSnapshot is a taskState keyed by field name, with JSON values.
+type taskSnapshot map[string]json.RawMessage
+
+// GetTaskHistory returns the events recorded for a task, oldest first. The
+// history of a deleted task remains available by its ID.
+func (s *TaskService) GetTaskHistory(ctx context.Context, id string) ([]domain.TaskEvent, error) {
+	taskID, err := s.resolveHistoryTaskID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get task history: %w", err)
+	}
+
+	events, err := s.db.Queries.ListTaskEvents(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("get task history: %w", err)
+	}
+
+	if len(events) == 0 {
+		return nil, fmt.Errorf("get task history: %w", domain.NotFound("task not found"))
+	}
+
+	domainEvents := make([]domain.TaskEvent, len(events))
+	for i, event := range events {
+		domainEvents[i], err = taskEventToDomain(event)
+		if err != nil {
+			return nil, fmt.Errorf("get task history: %w", err)
+		}
+	}
+
+	return domainEvents, nil
+}
+
+// GetTaskAt reconstructs a task as it was at the given time by replaying its
+// history up to that moment.
+func (s *TaskService) GetTaskAt(ctx context.Context, id string, at time.Time) (domain.Task, error) {
+	taskID, err := s.resolveHistoryTaskID(ctx, id)
+	if err != nil {
+		return domain.Task{}, fmt.Errorf("get task at: %w", err)
+	}
+
+	events, err := s.db.Queries.ListTaskEventsUntil(ctx, sqlc.ListTaskEventsUntilParams{
+		TaskID: taskID,
+		Until:  at.UTC(),
+	})
+	if err != nil {
+		return domain.Task{}, fmt.Errorf("get task at: %w", err)
+	}
+
+	var snapshot taskSnapshot
+	var createdAt, updatedAt time.Time
+	for _, event := range events {
+		switch domain.TaskEventAction(event.Action) {
+		case domain.TaskEventCreated, domain.TaskEventBaseline:
+			createdAt = event.CreatedAt
+		}
+
+		snapshot, err = applyTaskEvent(snapshot, event)
+		if err != nil {
+			return domain.Task{}, fmt.Errorf("get task at: %w", err)
+		}
+
+		if snapshot != nil {
+			updatedAt = event.CreatedAt
+		}
+	}
+
+	if snapshot == nil {
+		return domain.Task{}, fmt.Errorf("get task at: %w", domain.NotFound("task not found at %s", at.UTC().Format(time.RFC3339)))
+	}
+
+	state, err := snapshot.state()
+	if err != nil {
+		return domain.Task{}, fmt.Errorf("get task at: %w", err)
+	}
+
+	task := domain.Task{
+		ID:          uuid.MustParse(taskID),
+		Key:         state.Key,
+		Title:       state.Title,
+		Description: state.Description,
+		Status:      state.Status,
+		Priority:    state.Priority,
+		Assignees:   state.Assignees,
+		Tags:        state.Tags,
+		DueAt:       state.DueAt,
+		SeriesID:    state.SeriesID,
+		ParentID:    state.ParentID,
+		TemplateID:  state.TemplateID,
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+		Estimate:    state.Estimate,
+		Remaining:   state.Remaining,
+	}
+	if projectID, err := uuid.Parse(state.ProjectID); err == nil {
+		task.ProjectID = projectID
+	}
+
+	return task, nil
+}
+
+// applyTaskEvent replays an event on the snapshot of its task and returns the
+// resulting snapshot, which is nil while the task does not exist.
+func applyTaskEvent(snapshot taskSnapshot, event sqlc.TaskEvent) (taskSnapshot, error) {
+	switch domain.TaskEventAction(event.Action) {
+	case domain.TaskEventDeleted, domain.TaskEventPurged:
+		return nil, nil
+	case domain.TaskEventCreated, domain.TaskEventBaseline, domain.TaskEventRestored:
+		snapshot = taskSnapshot{}
+	}
+
+	if snapshot == nil {
+		return nil, nil
+	}
+
+	var changes []domain.FieldChange
+	if err := json.Unmarshal([]byte(event.Changes), &changes); err != nil {
+		return nil, fmt.Errorf("event %s: %w", event.ID, err)
+	}
+
+	for _, change := range changes {
+		snapshot[change.Field] = change.After
+	}
+
+	return snapshot, nil
+}
+
+// resolveHistoryTaskID turns a task key into the task's ID. IDs are returned
+// as they are, since deleted tasks can no longer be looked up by key.
+func (s *TaskService) resolveHistoryTaskID(ctx context.Context, id string) (string, error) {
+	if id == "" {
+		return "", domain.Invalid("id", "id is required")
+	}
+
+	projectKey, seq, ok := domain.ParseTaskKey(id)
+	if !ok {
+		return id, nil
+	}
+
+	task, err := s.db.Queries.GetTaskByKey(ctx, sqlc.GetTaskByKeyParams{Key: projectKey, Seq: seq})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", domain.NotFound("task not found")
+		}
+		return "", err
+	}
+
+	return task.ID, nil
+}
+
+// snapshotTask captures the tracked state of a task inside a transaction.
+func snapshotTask(ctx context.Context, q *sqlc.Queries, id string) (taskSnapshot, error) {
+	task, err := q.GetTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	domainTask, err := toDomainTask(ctx, q, task)
+	if err != nil {
+		return nil, err
+	}
+
+	assignees := domainTask.Assignees
+	if assignees == nil {
+		assignees = []string{}
+	}
+
+	tags := domainTask.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	var dueAt *time.Time
+	if domainTask.DueAt != nil {
+		utc := domainTask.DueAt.UTC()
+		dueAt = &utc
+	}
+
+	data, err := json.Marshal(taskState{
+		Key:         domainTask.Key,
+		ProjectID:   domainTask.ProjectID.String(),
+		Title:       domainTask.Title,
+		Description: domainTask.Description,
+		Status:      domainTask.Status,
+		Priority:    domainTask.Priority,
+		Assignees:   assignees,
+		Tags:        tags,
+		DueAt:       dueAt,
+		SeriesID:    domainTask.SeriesID,
+		Estimate:    domainTask.Estimate,
+		Remaining:   domainTask.Remaining,
+		ParentID:    domainTask.ParentID,
+		TemplateID:  domainTask.TemplateID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot taskSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+func (s taskSnapshot) state() (taskState, error) {
+	var state taskState
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return state, err
+	}
+
+	err = json.Unmarshal(data, &state)
+	return state, err
+}
+
+// diffSnapshots lists the fields that differ between two snapshots. A nil
+// snapshot stands for a task that does not exist.
+func diffSnapshots(before, after taskSnapshot) []domain.FieldChange {
+	changes := []domain.FieldChange{}
+	if before == nil && after == nil {
+		return changes
+	}
+
+	for _, field := range taskStateFields {
+		if before != nil && after != nil && bytes.Equal(before[field], after[field]) {
+			continue
+		}
+
+		changes = append(changes, domain.FieldChange{
+			Field:  field,
+			Before: before[field],
+			After:  after[field],
+		})
+	}
+	return changes
+}
+
+// recordTaskEvent writes a change of a task to its history, attributed to the
+// actor and request in ctx. Updates that change nothing are not recorded.
+func recordTaskEvent(ctx context.Context, q *sqlc.Queries, taskID string, action domain.TaskEventAction, before, after taskSnapshot, now time.Time) error {
+	changes := diffSnapshots(before, after)
+	if action == domain.TaskEventUpdated && len(changes) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+
+	requestID := domain.RequestIDFromContext(ctx)
+
+	return q.CreateTaskEvent(ctx, sqlc.CreateTaskEventParams{
+		ID:        uuid.New().String(),
+		TaskID:    taskID,
+		Action:    string(action),
+		Actor:     domain.ActorFromContext(ctx),
+		RequestID: sql.NullString{String: requestID, Valid: requestID != ""},
+		Changes:   string(data),
+		CreatedAt: now,
+	})
+}
+
+func taskEventToDomain(event sqlc.TaskEvent) (domain.TaskEvent, error) {
+	var changes []domain.FieldChange
+	if err := json.Unmarshal([]byte(event.Changes), &changes); err != nil {
+		return domain.TaskEvent{}, fmt.Errorf("event %s: %w", event.ID, err)
+	}
+
+	return domain.TaskEvent{
+		ID:        uuid.MustParse(event.ID),
+		TaskID:    uuid.MustParse(event.TaskID),
+		Action:    domain.TaskEventAction(event.Action),
+		Actor:     event.Actor,
+		RequestID: event.RequestID.String,
+		Changes:   changes,
+		CreatedAt: event.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/storage"
+)
+
+func TestTaskHistory_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, err := sqlite.NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+
+	logger := log.New(os.Stderr, "INTEGRATION_TEST: ", log.LstdFlags)
+	taskService := NewTaskService(logger, db, blobs, domain.DefaultWorkflow())
+
+	ctx := domain.WithActor(context.Background(), "client-a")
+	ctx = domain.WithRequestID(ctx, "req-1")
+
+	id, err := taskService.CreateTask(ctx, &domain.CreateTaskRequest{
+		Title:    "Ship release",
+		Priority: domain.TaskPriorityLow,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	beforeUpdate := time.Now().UTC()
+	time.Sleep(10 * time.Millisecond)
+
+	priority := domain.TaskPriorityHigh
+	updateCtx := domain.WithRequestID(domain.WithActor(context.Background(), "client-b"), "req-2")
+	if err := taskService.UpdateTask(updateCtx, id.String(), &domain.UpdateTaskRequest{Priority: &priority}, nil); err != nil {
+		t.Fatalf("Failed to update task: %v", err)
+	}
+
+	t.Run("updates record who changed which field", func(t *testing.T) {
+		events, err := taskService.GetTaskHistory(ctx, id.String())
+		if err != nil {
+			t.Fatalf("Failed to get history: %v", err)
+		}
+
+		if len(events) != 2 {
+			t.Fatalf("Expected 2 events, got %d", len(events))
+		}
+
+		if events[0].Action != domain.TaskEventCreated || events[0].Actor != "client-a" || events[0].RequestID != "req-1" {
+			t.Errorf("Expected creation by client-a in req-1, got %+v", events[0])
+		}
+
+		update := events[1]
+		if update.Action != domain.TaskEventUpdated || update.Actor != "client-b" || update.RequestID != "req-2" {
+			t.Errorf("Expected update by client-b in req-2, got %+v", update)
+		}
+
+		if len(update.Changes) != 1 || update.Changes[0].Field != "priority" ||
+			string(update.Changes[0].Before) != `"low"` || string(update.Changes[0].After) != `"high"` {
+			t.Errorf("Expected a single priority change from low to high, got %+v", update.Changes)
+		}
+	})
+
+	t.Run("updates that change nothing are not recorded", func(t *testing.T) {
+		if err := taskService.UpdateTask(ctx, id.String(), &domain.UpdateTaskRequest{Priority: &priority}, nil); err != nil {
+			t.Fatalf("Failed to update task: %v", err)
+		}
+
+		events, err := taskService.GetTaskHistory(ctx, "TASK-1")
+		if err != nil {
+			t.Fatalf("Failed to get history by key: %v", err)
+		}
+
+		if len(events) != 2 {
+			t.Errorf("Expected 2 events, got %d", len(events))
+		}
+	})
+
+	t.Run("state is reconstructed at a point in time", func(t *testing.T) {
+		past, err := taskService.GetTaskAt(ctx, id.String(), beforeUpdate)
+		if err != nil {
+			t.Fatalf("Failed to reconstruct task: %v", err)
+		}
+
+		if past.Priority != domain.TaskPriorityLow || past.Title != "Ship release" || past.Key != "TASK-1" {
+			t.Errorf("Expected the task as created, got %+v", past)
+		}
+
+		_, err = taskService.GetTaskAt(ctx, id.String(), beforeUpdate.Add(-time.Hour))
+		if err == nil {
+			t.Error("Expected error before the task existed, got nil")
+		}
+	})
+
+	t.Run("history outlives the task", func(t *testing.T) {
+		if err := taskService.DeleteTask(ctx, id.String(), nil); err != nil {
+			t.Fatalf("Failed to delete task: %v", err)
+		}
+
+		events, err := taskService.GetTaskHistory(ctx, id.String())
+		if err != nil {
+			t.Fatalf("Failed to get history: %v", err)
+		}
+
+		last := events[len(events)-1]
+		if last.Action != domain.TaskEventDeleted || string(last.Changes[0].After) != "null" {
+			t.Errorf("Expected a deletion event, got %+v", last)
+		}
+
+		if _, err := taskService.GetTaskAt(ctx, id.String(), time.Now()); err == nil {
+			t.Error("Expected error after deletion, got nil")
+		}
+
+		past, err := taskService.GetTaskAt(ctx, id.String(), beforeUpdate)
+		if err != nil || past.Priority != domain.TaskPriorityLow {
+			t.Errorf("Expected the deleted task to be reconstructable, got %+v (%v)", past, err)
+		}
+	})
+
+	t.Run("reconstructed subtasks keep their parent", func(t *testing.T) {
+		parent, err := taskService.CreateTask(ctx, &domain.CreateTaskRequest{Title: "Plan release"})
+		if err != nil {
+			t.Fatalf("Failed to create parent task: %v", err)
+		}
+		child, err := taskService.CreateTask(ctx, &domain.CreateTaskRequest{Title: "Write notes", ParentID: parent.String()})
+		if err != nil {
+			t.Fatalf("Failed to create subtask: %v", err)
+		}
+
+		past, err := taskService.GetTaskAt(ctx, child.String(), time.Now())
+		if err != nil {
+			t.Fatalf("Failed to reconstruct subtask: %v", err)
+		}
+		if past.ParentID == nil || *past.ParentID != parent {
+			t.Errorf("Expected parent %s, got %v", parent, past.ParentID)
+		}
+	})
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite/sqlc"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/jsonpatch"
+)
+
+// taskPatchDocument is the JSON document that patches are applied to. Every
+// member is present, so that JSON Patch paths like /DueAt or /Tags/- resolve;
+// removing a member or setting it to null clears the field.
+type taskPatchDocument struct {
+	Title       *string
+	Description *string
+	Status      *domain.TaskStatus
+	Priority    *domain.TaskPriority
+	Assignees   []string
+	Tags        []string
+	DueAt       *time.Time
+	Estimate    *float64
+	Remaining   *float64
+	// Comment is null in the document; setting it adds a comment along with
+	// the change.
+	Comment *string
+}
+
+// PatchTask applies a JSON Merge Patch or JSON Patch document to a task and
+// validates the result. When ifMatch is non-empty, the task must be at one of
+// those versions; nil sets no precondition.
+func (s *TaskService) PatchTask(ctx context.Context, id string, format domain.PatchFormat, patch []byte, ifMatch []int64) error {
+	if id == "" {
+		return fmt.Errorf("patch task: %w", domain.Invalid("id", "id is required"))
+	}
+
+	err := s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		current, err := q.GetTask(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return domain.NotFound("task not found")
+			}
+			return err
+		}
+
+		if err := checkVersion(current, ifMatch); err != nil {
+			return err
+		}
+
+		changes, err := currentTaskChanges(ctx, q, current)
+		if err != nil {
+			return err
+		}
+
+		doc, err := json.Marshal(taskPatchDocument{
+			Title:       &changes.Title,
+			Description: &changes.Description,
+			Status:      &changes.Status,
+			Priority:    &changes.Priority,
+			Assignees:   changes.Assignees,
+			Tags:        changes.Tags,
+			DueAt:       changes.DueAt,
+			Estimate:    changes.Estimate,
+			Remaining:   changes.Remaining,
+		})
+		if err != nil {
+			return err
+		}
+
+		var patched []byte
+		switch format {
+		case domain.PatchFormatMergePatch:
+			patched, err = jsonpatch.MergePatch(doc, patch)
+		case domain.PatchFormatJSONPatch:
+			patched, err = jsonpatch.Apply(doc, patch)
+		default:
+			return domain.Invalid("Content-Type", "unsupported patch format %q", format)
+		}
+		if err != nil {
+			return classifyPatchError(err)
+		}
+
+		result, err := decodeTaskPatchDocument(patched)
+		if err != nil {
+			return classifyPatchError(err)
+		}
+
+		return s.applyTaskChanges(ctx, q, current, result)
+	})
+	if err != nil {
+		return fmt.Errorf("patch task: %w", err)
+	}
+
+	return nil
+}
+
+// classifyPatchError gives the errors of the jsonpatch package their domain
+// kind: a failed test operation is a conflict, anything else a bad request.
+func classifyPatchError(err error) error {
+	if errors.Is(err, jsonpatch.ErrTestFailed) {
+		return domain.Classify(domain.ErrConflict, err)
+	}
+	return domain.Classify(domain.ErrValidation, err)
+}
+
+// decodeTaskPatchDocument turns a patched document into the new state of the
+// task. Unknown members, e.g. read-only fields, are rejected.
+func decodeTaskPatchDocument(data []byte) (taskChanges, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var doc taskPatchDocument
+	if err := decoder.Decode(&doc); err != nil {
+		return taskChanges{}, fmt.Errorf("%w: %v", jsonpatch.ErrInvalidPatch, err)
+	}
+
+	changes := taskChanges{
+		Assignees: doc.Assignees,
+		Tags:      doc.Tags,
+		DueAt:     doc.DueAt,
+		Estimate:  doc.Estimate,
+		Remaining: doc.Remaining,
+	}
+	if doc.Title != nil {
+		changes.Title = *doc.Title
+	}
+	if doc.Description != nil {
+		changes.Description = *doc.Description
+	}
+	if doc.Status != nil {
+		changes.Status = *doc.Status
+	}
+	if doc.Priority != nil {
+		changes.Priority = *doc.Priority
+	}
+	if doc.Comment != nil {
+		changes.Comment = *doc.Comment
+	}
+
+	return changes, nil
+}
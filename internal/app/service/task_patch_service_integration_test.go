@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/jsonpatch"
+	"github.com/alexgolang/ishare-task/internal/app/storage"
+)
+
+func TestTaskPatch_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, err := sqlite.NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+
+	logger := log.New(os.Stderr, "INTEGRATION_TEST: ", log.LstdFlags)
+	taskService := NewTaskService(logger, db, blobs, domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	dueAt := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	id, err := taskService.CreateTask(ctx, &domain.CreateTaskRequest{
+		Title:       "Prepare demo",
+		Description: "Slides and a recording",
+		Assignees:   []string{"alice"},
+		DueAt:       &dueAt,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	t.Run("plain updates leave empty fields unchanged", func(t *testing.T) {
+		empty := ""
+		if err := taskService.UpdateTask(ctx, id.String(), &domain.UpdateTaskRequest{Description: &empty}, nil); err != nil {
+			t.Fatalf("Failed to update task: %v", err)
+		}
+
+		task, err := taskService.GetTask(ctx, id.String())
+		if err != nil {
+			t.Fatalf("Failed to get task: %v", err)
+		}
+		if task.Description != "Slides and a recording" {
+			t.Errorf("Expected the description to be kept, got %q", task.Description)
+		}
+	})
+
+	t.Run("merge patch null clears fields", func(t *testing.T) {
+		patch := `{"Description": null, "DueAt": null, "Assignees": null, "Tags": ["demo"], "Priority": "high"}`
+		if err := taskService.PatchTask(ctx, id.String(), domain.PatchFormatMergePatch, []byte(patch), nil); err != nil {
+			t.Fatalf("Failed to patch task: %v", err)
+		}
+
+		task, err := taskService.GetTask(ctx, id.String())
+		if err != nil {
+			t.Fatalf("Failed to get task: %v", err)
+		}
+
+		if task.Description != "" || task.DueAt != nil || len(task.Assignees) != 0 {
+			t.Errorf("Expected description, due date and assignees to be cleared, got %+v", task)
+		}
+		if task.Priority != domain.TaskPriorityHigh || len(task.Tags) != 1 || task.Tags[0] != "demo" {
+			t.Errorf("Expected priority and tags to be set, got %+v", task)
+		}
+	})
+
+	t.Run("merge patch null clears the remaining work", func(t *testing.T) {
+		patch := `{"Estimate": 8, "Remaining": 5}`
+		if err := taskService.PatchTask(ctx, id.String(), domain.PatchFormatMergePatch, []byte(patch), nil); err != nil {
+			t.Fatalf("Failed to patch task: %v", err)
+		}
+
+		if err := taskService.PatchTask(ctx, id.String(), domain.PatchFormatMergePatch, []byte(`{"Remaining": null}`), nil); err != nil {
+			t.Fatalf("Failed to patch task: %v", err)
+		}
+
+		task, err := taskService.GetTask(ctx, id.String())
+		if err != nil {
+			t.Fatalf("Failed to get task: %v", err)
+		}
+		if task.Remaining != nil {
+			t.Errorf("Expected remaining to be cleared, got %v", *task.Remaining)
+		}
+		if task.Estimate == nil || *task.Estimate != 8 {
+			t.Errorf("Expected the estimate to be kept, got %v", task.Estimate)
+		}
+	})
+
+	t.Run("the patched task is validated", func(t *testing.T) {
+		patches := map[string]string{
+			"null title":     `{"Title": null}`,
+			"invalid status": `{"Status": "archived"}`,
+		}
+
+		for name, patch := range patches {
+			if err := taskService.PatchTask(ctx, id.String(), domain.PatchFormatMergePatch, []byte(patch), nil); err == nil {
+				t.Errorf("Expected %s to be refused, got nil error", name)
+			}
+		}
+
+		err := taskService.PatchTask(ctx, id.String(), domain.PatchFormatMergePatch, []byte(`{"Version": 7}`), nil)
+		if !errors.Is(err, jsonpatch.ErrInvalidPatch) {
+			t.Errorf("Expected read-only fields to be refused, got %v", err)
+		}
+	})
+
+	t.Run("json patch applies operations after passing tests", func(t *testing.T) {
+		patch := `[
+			{"op": "test", "path": "/Title", "value": "Prepare demo"},
+			{"op": "replace", "path": "/Title", "value": "Record demo"},
+			{"op": "add", "path": "/Tags/-", "value": "video"},
+			{"op": "add", "path": "/Assignees/-", "value": "bob"}
+		]`
+		if err := taskService.PatchTask(ctx, id.String(), domain.PatchFormatJSONPatch, []byte(patch), nil); err != nil {
+			t.Fatalf("Failed to patch task: %v", err)
+		}
+
+		task, err := taskService.GetTask(ctx, id.String())
+		if err != nil {
+			t.Fatalf("Failed to get task: %v", err)
+		}
+
+		if task.Title != "Record demo" || len(task.Tags) != 2 || len(task.Assignees) != 1 || task.Assignees[0] != "bob" {
+			t.Errorf("Expected the patch to be applied, got %+v", task)
+		}
+	})
+
+	t.Run("a failed json patch test changes nothing", func(t *testing.T) {
+		before, err := taskService.GetTask(ctx, id.String())
+		if err != nil {
+			t.Fatalf("Failed to get task: %v", err)
+		}
+
+		patch := `[
+			{"op": "replace", "path": "/Title", "value": "Cancel demo"},
+			{"op": "test", "path": "/Priority", "value": "low"}
+		]`
+		err = taskService.PatchTask(ctx, id.String(), domain.PatchFormatJSONPatch, []byte(patch), nil)
+		if !errors.Is(err, jsonpatch.ErrTestFailed) {
+			t.Fatalf("Expected a failed test, got %v", err)
+		}
+
+		after, err := taskService.GetTask(ctx, id.String())
+		if err != nil {
+			t.Fatalf("Failed to get task: %v", err)
+		}
+		if after.Title != before.Title || after.Version != before.Version {
+			t.Errorf("Expected the task to be unchanged, got %+v", after)
+		}
+	})
+
+	t.Run("patches honour If-Match", func(t *testing.T) {
+		err := taskService.PatchTask(ctx, id.String(), domain.PatchFormatMergePatch, []byte(`{"Title": "Stale"}`), []int64{1})
+		if !errors.Is(err, domain.ErrVersionMismatch) {
+			t.Errorf("Expected a version mismatch, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,477 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite/sqlc"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/recurrence"
+	"github.com/google/uuid"
+)
+
+// maxCatchUpOccurrences bounds how many missed occurrences of one series are
+// spawned in a single run, e.g. after the service was down for a while.
+const maxCatchUpOccurrences = 100
+
+// recurrenceActor is recorded as the actor of changes made by the scheduler.
+const recurrenceActor = "system:recurrence"
+
+func (s *TaskService) GetTaskSeries(ctx context.Context, taskID string) (domain.TaskSeries, error) {
+	if taskID == "" {
+		return domain.TaskSeries{}, fmt.Errorf("get task series: %w", domain.Invalid("id", "id is required"))
+	}
+
+	series, err := getSeriesOf(ctx, s.db.Queries, taskID)
+	if err != nil {
+		return domain.TaskSeries{}, fmt.Errorf("get task series: %w", err)
+	}
+
+	return seriesToDomain(series), nil
+}
+
+// UpdateTaskSeries applies an update to the series of a recurring task: its
+// template, used for future occurrences, and every occurrence not yet done.
+// A non-empty ifMatch applies to the version of the task named by taskID.
+func (s *TaskService) UpdateTaskSeries(ctx context.Context, taskID string, task *domain.UpdateTaskRequest, ifMatch []int64) error {
+	if taskID == "" {
+		return fmt.Errorf("update task series: %w", domain.Invalid("id", "id is required"))
+	}
+
+	if task.Status != nil || task.Comment != nil || task.DueAt != nil {
+		return fmt.Errorf("update task series: %w", domain.Invalid("scope", "status, comment and due date can only be changed for a single occurrence"))
+	}
+
+	if task.Title != nil && strings.TrimSpace(*task.Title) == "" {
+		return fmt.Errorf("update task series: %w", domain.Invalid("Title", "title must not be empty"))
+	}
+
+	if task.Priority != nil && (*task.Priority == "" || !task.Priority.IsValid()) {
+		return fmt.Errorf("update task series: %w", domain.Invalid("Priority", "invalid priority"))
+	}
+
+	var assignees []string
+	if task.Assignees != nil {
+		normalized, err := normalizeAssignees(*task.Assignees)
+		if err != nil {
+			return fmt.Errorf("update task series: %w", err)
+		}
+		assignees = normalized
+	}
+
+	var tags []string
+	if task.Tags != nil {
+		normalized, err := normalizeTags(*task.Tags)
+		if err != nil {
+			return fmt.Errorf("update task series: %w", err)
+		}
+		tags = normalized
+	}
+
+	return s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		if err := requireVersion(ctx, q, taskID, ifMatch); err != nil {
+			return fmt.Errorf("update task series: %w", err)
+		}
+
+		series, err := getSeriesOf(ctx, q, taskID)
+		if err != nil {
+			return fmt.Errorf("update task series: %w", err)
+		}
+
+		if task.Title != nil {
+			series.Title = *task.Title
+		}
+		if task.Description != nil {
+			series.Description = sql.NullString{String: *task.Description, Valid: *task.Description != ""}
+		}
+		if task.Priority != nil {
+			series.Priority = *task.Priority
+		}
+
+		now := time.Now().UTC()
+		err = q.UpdateTaskSeries(ctx, sqlc.UpdateTaskSeriesParams{
+			Title:       series.Title,
+			Description: series.Description,
+			Priority:    series.Priority,
+			UpdatedAt:   now,
+			ID:          series.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("update task series: %w", err)
+		}
+
+		ids, err := q.ListOpenSeriesTaskIDs(ctx, sqlc.ListOpenSeriesTaskIDsParams{
+			SeriesID:     sql.NullString{String: series.ID, Valid: true},
+			DoneStatuses: s.doneStatuses(),
+		})
+		if err != nil {
+			return fmt.Errorf("update task series: %w", err)
+		}
+
+		for _, id := range ids {
+			before, err := snapshotTask(ctx, q, id)
+			if err != nil {
+				return fmt.Errorf("update task series: %w", err)
+			}
+
+			current, err := q.GetTask(ctx, id)
+			if err != nil {
+				return fmt.Errorf("update task series: %w", err)
+			}
+
+			params := sqlc.UpdateTaskParams{
+				ID:          id,
+				Title:       current.Title,
+				Description: current.Description,
+				Status:      current.Status,
+				Priority:    current.Priority,
+				DueAt:       current.DueAt,
+				Estimate:    current.Estimate,
+				Remaining:   current.Remaining,
+				Rank:        current.Rank,
+				UpdatedAt:   now,
+			}
+			if task.Title != nil {
+				params.Title = series.Title
+			}
+			if task.Description != nil {
+				params.Description = series.Description
+			}
+			if task.Priority != nil {
+				params.Priority = series.Priority
+			}
+
+			if err := q.UpdateTask(ctx, params); err != nil {
+				return fmt.Errorf("update task series: %w", err)
+			}
+
+			if task.Assignees != nil {
+				if err := replaceAssignees(ctx, q, id, assignees, now); err != nil {
+					return fmt.Errorf("update task series: %w", err)
+				}
+			}
+
+			if task.Tags != nil {
+				if err := replaceTags(ctx, q, id, tags); err != nil {
+					return fmt.Errorf("update task series: %w", err)
+				}
+			}
+
+			after, err := snapshotTask(ctx, q, id)
+			if err != nil {
+				return fmt.Errorf("update task series: %w", err)
+			}
+
+			if err := recordTaskEvent(ctx, q, id, domain.TaskEventUpdated, before, after, now); err != nil {
+				return fmt.Errorf("update task series: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// DeleteTaskSeries stops a recurring task and moves all of its occurrences to
+// the trash. Restored occurrences no longer belong to a live series.
+// A non-empty ifMatch applies to the version of the task named by taskID.
+func (s *TaskService) DeleteTaskSeries(ctx context.Context, taskID string, ifMatch []int64) error {
+	if taskID == "" {
+		return fmt.Errorf("delete task series: %w", domain.Invalid("id", "id is required"))
+	}
+
+	return s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		if err := requireVersion(ctx, q, taskID, ifMatch); err != nil {
+			return fmt.Errorf("delete task series: %w", err)
+		}
+
+		series, err := getSeriesOf(ctx, q, taskID)
+		if err != nil {
+			return fmt.Errorf("delete task series: %w", err)
+		}
+
+		ids, err := q.ListSeriesTaskIDs(ctx, sql.NullString{String: series.ID, Valid: true})
+		if err != nil {
+			return fmt.Errorf("delete task series: %w", err)
+		}
+
+		now := time.Now().UTC()
+		for _, id := range ids {
+			before, err := snapshotTask(ctx, q, id)
+			if err != nil {
+				return fmt.Errorf("delete task series: %w", err)
+			}
+
+			if _, err := q.SoftDeleteTask(ctx, sqlc.SoftDeleteTaskParams{
+				DeletedAt: sql.NullTime{Time: now, Valid: true},
+				ID:        id,
+			}); err != nil {
+				return fmt.Errorf("delete task series: %w", err)
+			}
+
+			if err := recordTaskEvent(ctx, q, id, domain.TaskEventDeleted, before, nil, now); err != nil {
+				return fmt.Errorf("delete task series: %w", err)
+			}
+		}
+
+		if _, err := q.DeleteTaskSeries(ctx, series.ID); err != nil {
+			return fmt.Errorf("delete task series: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// SpawnDueOccurrences creates the occurrences whose date has been reached and
+// returns how many were created. It is run periodically by the application.
+func (s *TaskService) SpawnDueOccurrences(ctx context.Context, now time.Time) (int, error) {
+	ctx = domain.WithActor(ctx, recurrenceActor)
+
+	due, err := s.db.Queries.ListDueTaskSeries(ctx, sql.NullTime{Time: now, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("spawn due occurrences: %w", err)
+	}
+
+	spawned := 0
+	for _, series := range due {
+		count := 0
+		err := s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+			count = 0
+			for count < maxCatchUpOccurrences {
+				// Reload the series: a completion may have advanced it meanwhile.
+				current, err := q.GetTaskSeries(ctx, series.ID)
+				if err != nil {
+					if errors.Is(err, sql.ErrNoRows) {
+						return nil
+					}
+					return err
+				}
+
+				if !current.NextAt.Valid || current.NextAt.Time.After(now) {
+					return nil
+				}
+
+				created, err := s.spawnOccurrence(ctx, q, current, now)
+				if err != nil {
+					return err
+				}
+				if created {
+					count++
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return spawned, fmt.Errorf("spawn due occurrences: series %s: %w", series.ID, err)
+		}
+		spawned += count
+	}
+
+	return spawned, nil
+}
+
+// startSeries creates the series of a new recurring task and returns its ID
+// together with the due date of the first occurrence.
+func startSeries(ctx context.Context, q *sqlc.Queries, rule recurrence.Rule, dtstart time.Time, projectID string, task *domain.CreateTaskRequest, priority domain.TaskPriority, now time.Time) (string, time.Time, error) {
+	first, ok := rule.Next(dtstart, dtstart.Add(-time.Second), 0)
+	if !ok {
+		return "", time.Time{}, domain.Invalid("RRule", "recurrence rule produces no occurrences")
+	}
+
+	next, ok := rule.Next(dtstart, first, 1)
+
+	id := uuid.New().String()
+	err := q.CreateTaskSeries(ctx, sqlc.CreateTaskSeriesParams{
+		ID:          id,
+		ProjectID:   projectID,
+		Rrule:       rule.String(),
+		Dtstart:     dtstart,
+		Title:       task.Title,
+		Description: sql.NullString{String: task.Description, Valid: task.Description != ""},
+		Priority:    priority,
+		Occurrences: 1,
+		NextAt:      sql.NullTime{Time: next, Valid: ok},
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return id, first, nil
+}
+
+// spawnAfterCompletion creates the next occurrence early when the latest
+// occurrence of a series is completed before its successor is due.
+func (s *TaskService) spawnAfterCompletion(ctx context.Context, q *sqlc.Queries, task sqlc.Task, now time.Time) error {
+	series, err := q.GetTaskSeries(ctx, task.SeriesID.String)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	latest, err := q.GetLatestSeriesTask(ctx, task.SeriesID)
+	if err != nil {
+		return err
+	}
+
+	if latest.ID != task.ID || !series.NextAt.Valid {
+		return nil
+	}
+
+	_, err = s.spawnOccurrence(ctx, q, series, now)
+	return err
+}
+
+// spawnOccurrence creates the occurrence due at the series' next date, copying
+// the assignees and tags of the latest occurrence, and advances the series.
+// When an occurrence was moved onto that date, no task is created for it; it
+// reports whether a task was created.
+func (s *TaskService) spawnOccurrence(ctx context.Context, q *sqlc.Queries, series sqlc.TaskSeries, now time.Time) (bool, error) {
+	rule, err := recurrence.Parse(series.Rrule)
+	if err != nil {
+		return false, err
+	}
+
+	seriesID := sql.NullString{String: series.ID, Valid: true}
+
+	exists, err := q.SeriesOccurrenceExists(ctx, sqlc.SeriesOccurrenceExistsParams{SeriesID: seriesID, DueAt: series.NextAt})
+	if err != nil {
+		return false, err
+	}
+	if exists == 0 {
+		if err := s.createOccurrence(ctx, q, series, now); err != nil {
+			return false, err
+		}
+	}
+
+	next, ok := rule.Next(series.Dtstart, series.NextAt.Time, int(series.Occurrences)+1)
+
+	err = q.AdvanceTaskSeries(ctx, sqlc.AdvanceTaskSeriesParams{
+		Occurrences: series.Occurrences + 1,
+		NextAt:      sql.NullTime{Time: next, Valid: ok},
+		UpdatedAt:   now,
+		ID:          series.ID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return exists == 0, nil
+}
+
+// createOccurrence creates the task of the occurrence due at the series' next
+// date.
+func (s *TaskService) createOccurrence(ctx context.Context, q *sqlc.Queries, series sqlc.TaskSeries, now time.Time) error {
+	seriesID := sql.NullString{String: series.ID, Valid: true}
+
+	var assignees, tags []string
+	var estimate sql.NullFloat64
+	latest, err := q.GetLatestSeriesTask(ctx, seriesID)
+	switch {
+	case err == nil:
+		estimate = latest.Estimate
+
+		assignees, err = q.ListTaskAssignees(ctx, latest.ID)
+		if err != nil {
+			return err
+		}
+
+		tags, err = q.ListTaskTags(ctx, latest.ID)
+		if err != nil {
+			return err
+		}
+	case !errors.Is(err, sql.ErrNoRows):
+		return err
+	}
+
+	seq, err := q.NextProjectTaskSeq(ctx, series.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	id := uuid.New().String()
+	key, err := endRank(ctx, q, s.workflow.InitialStatus, id)
+	if err != nil {
+		return err
+	}
+
+	err = q.CreateTask(ctx, sqlc.CreateTaskParams{
+		ID:          id,
+		Title:       series.Title,
+		Description: series.Description,
+		Status:      s.workflow.InitialStatus,
+		Priority:    series.Priority,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		ProjectID:   series.ProjectID,
+		Seq:         seq,
+		DueAt:       series.NextAt,
+		SeriesID:    seriesID,
+		Estimate:    estimate,
+		Remaining:   estimate,
+		Rank:        key,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := replaceAssignees(ctx, q, id, assignees, now); err != nil {
+		return err
+	}
+
+	if err := replaceTags(ctx, q, id, tags); err != nil {
+		return err
+	}
+
+	after, err := snapshotTask(ctx, q, id)
+	if err != nil {
+		return err
+	}
+
+	return recordTaskEvent(ctx, q, id, domain.TaskEventCreated, nil, after, now)
+}
+
+func getSeriesOf(ctx context.Context, q *sqlc.Queries, taskID string) (sqlc.TaskSeries, error) {
+	task, err := q.GetTask(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sqlc.TaskSeries{}, domain.NotFound("task not found")
+		}
+		return sqlc.TaskSeries{}, err
+	}
+
+	if !task.SeriesID.Valid {
+		return sqlc.TaskSeries{}, domain.NotFound("task is not recurring")
+	}
+
+	series, err := q.GetTaskSeries(ctx, task.SeriesID.String)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sqlc.TaskSeries{}, domain.NotFound("task series not found")
+		}
+		return sqlc.TaskSeries{}, err
+	}
+
+	return series, nil
+}
+
+func seriesToDomain(series sqlc.TaskSeries) domain.TaskSeries {
+	return domain.TaskSeries{
+		ID:          uuid.MustParse(series.ID),
+		ProjectID:   uuid.MustParse(series.ProjectID),
+		RRule:       series.Rrule,
+		Title:       series.Title,
+		Description: series.Description.String,
+		Priority:    series.Priority,
+		Occurrences: series.Occurrences,
+		NextAt:      nullTimePtr(series.NextAt),
+		CreatedAt:   series.CreatedAt,
+		UpdatedAt:   series.UpdatedAt,
+	}
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/storage"
+)
+
+func TestTaskService_Recurrence_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, err := sqlite.NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+
+	logger := log.New(os.Stderr, "INTEGRATION_TEST: ", log.LstdFlags)
+	service := NewTaskService(logger, db, blobs, domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	// Monday, 6 January 2025, every Monday and Thursday, four times.
+	start := time.Date(2025, time.January, 6, 9, 0, 0, 0, time.UTC)
+	taskID, err := service.CreateTask(ctx, &domain.CreateTaskRequest{
+		Title:     "Rotate logs",
+		Assignees: []string{"ops-bot"},
+		DueAt:     &start,
+		RRule:     "FREQ=WEEKLY;BYDAY=MO,TH;COUNT=4",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create recurring task: %v", err)
+	}
+
+	first, err := service.GetTask(ctx, taskID.String())
+	if err != nil {
+		t.Fatalf("Failed to get task: %v", err)
+	}
+
+	seriesTasks := func(t *testing.T) []domain.Task {
+		all, err := service.GetTasks(ctx, domain.TaskFilter{})
+		if err != nil {
+			t.Fatalf("Failed to list tasks: %v", err)
+		}
+
+		var tasks []domain.Task
+		for _, task := range all {
+			if task.SeriesID != nil && *task.SeriesID == *first.SeriesID {
+				tasks = append(tasks, task)
+			}
+		}
+		return tasks
+	}
+
+	t.Run("first occurrence is linked to a series", func(t *testing.T) {
+		if first.SeriesID == nil {
+			t.Fatal("Expected the task to belong to a series")
+		}
+
+		if first.DueAt == nil || !first.DueAt.Equal(start) {
+			t.Errorf("Expected first occurrence due at %v, got %v", start, first.DueAt)
+		}
+	})
+
+	t.Run("completing an occurrence spawns the next one", func(t *testing.T) {
+		done := domain.TaskStatusDone
+		if err := service.UpdateTask(ctx, taskID.String(), &domain.UpdateTaskRequest{Status: &done}, nil); err != nil {
+			t.Fatalf("Failed to complete task: %v", err)
+		}
+
+		tasks := seriesTasks(t)
+		if len(tasks) != 2 {
+			t.Fatalf("Expected 2 occurrences, got %d", len(tasks))
+		}
+
+		var next domain.Task
+		for _, task := range tasks {
+			if task.ID != taskID {
+				next = task
+			}
+		}
+
+		expectedDue := time.Date(2025, time.January, 9, 9, 0, 0, 0, time.UTC)
+		if next.DueAt == nil || !next.DueAt.Equal(expectedDue) {
+			t.Errorf("Expected next occurrence due at %v, got %v", expectedDue, next.DueAt)
+		}
+
+		if next.Status != domain.TaskStatusToDo || len(next.Assignees) != 1 || next.Assignees[0] != "ops-bot" {
+			t.Errorf("Expected a fresh occurrence assigned to ops-bot, got %+v", next)
+		}
+	})
+
+	t.Run("reaching the next date spawns remaining occurrences up to COUNT", func(t *testing.T) {
+		spawned, err := service.SpawnDueOccurrences(ctx, start.AddDate(0, 1, 0))
+		if err != nil {
+			t.Fatalf("Failed to spawn occurrences: %v", err)
+		}
+
+		if spawned != 2 {
+			t.Errorf("Expected 2 occurrences to be spawned, got %d", spawned)
+		}
+
+		again, err := service.SpawnDueOccurrences(ctx, start.AddDate(0, 1, 0))
+		if err != nil {
+			t.Fatalf("Failed to spawn occurrences: %v", err)
+		}
+
+		if again != 0 {
+			t.Errorf("Expected the exhausted series not to spawn again, got %d", again)
+		}
+
+		if tasks := seriesTasks(t); len(tasks) != 4 {
+			t.Errorf("Expected 4 occurrences in total, got %d", len(tasks))
+		}
+	})
+
+	t.Run("series scope updates open occurrences only", func(t *testing.T) {
+		title := "Rotate and compress logs"
+		if err := service.UpdateTaskSeries(ctx, taskID.String(), &domain.UpdateTaskRequest{Title: &title}, nil); err != nil {
+			t.Fatalf("Failed to update series: %v", err)
+		}
+
+		for _, task := range seriesTasks(t) {
+			expected := title
+			if task.ID == taskID {
+				expected = "Rotate logs"
+			}
+
+			if task.Title != expected {
+				t.Errorf("Expected %s to be titled %q, got %q", task.Key, expected, task.Title)
+			}
+		}
+
+		series, err := service.GetTaskSeries(ctx, taskID.String())
+		if err != nil {
+			t.Fatalf("Failed to get series: %v", err)
+		}
+
+		if series.Title != title || series.NextAt != nil {
+			t.Errorf("Expected an exhausted series titled %q, got %+v", title, series)
+		}
+	})
+
+	t.Run("series scope deletes every occurrence", func(t *testing.T) {
+		if err := service.DeleteTaskSeries(ctx, taskID.String(), nil); err != nil {
+			t.Fatalf("Failed to delete series: %v", err)
+		}
+
+		if tasks := seriesTasks(t); len(tasks) != 0 {
+			t.Errorf("Expected no occurrences left, got %d", len(tasks))
+		}
+	})
+
+	t.Run("an occurrence moved onto the next date is not spawned twice", func(t *testing.T) {
+		// Daily from Monday, 3 February 2025, three times.
+		backupStart := time.Date(2025, time.February, 3, 9, 0, 0, 0, time.UTC)
+		backupID, err := service.CreateTask(ctx, &domain.CreateTaskRequest{
+			Title: "Back up database",
+			DueAt: &backupStart,
+			RRule: "FREQ=DAILY;COUNT=3",
+		})
+		if err != nil {
+			t.Fatalf("Failed to create recurring task: %v", err)
+		}
+
+		moved := backupStart.AddDate(0, 0, 1)
+		if err := service.UpdateTask(ctx, backupID.String(), &domain.UpdateTaskRequest{DueAt: &moved}, nil); err != nil {
+			t.Fatalf("Failed to move the occurrence: %v", err)
+		}
+
+		spawned, err := service.SpawnDueOccurrences(ctx, backupStart.AddDate(0, 0, 7))
+		if err != nil {
+			t.Fatalf("Failed to spawn occurrences: %v", err)
+		}
+		if spawned != 1 {
+			t.Errorf("Expected 1 occurrence to be spawned, got %d", spawned)
+		}
+
+		series, err := service.GetTaskSeries(ctx, backupID.String())
+		if err != nil {
+			t.Fatalf("Failed to get series: %v", err)
+		}
+		if series.NextAt != nil {
+			t.Errorf("Expected the series to be exhausted, got next date %v", series.NextAt)
+		}
+
+		all, err := service.GetTasks(ctx, domain.TaskFilter{})
+		if err != nil {
+			t.Fatalf("Failed to list tasks: %v", err)
+		}
+		var last domain.Task
+		for _, task := range all {
+			if task.SeriesID != nil && *task.SeriesID == series.ID && task.ID != backupID {
+				last = task
+			}
+		}
+		expectedDue := backupStart.AddDate(0, 0, 2)
+		if last.DueAt == nil || !last.DueAt.Equal(expectedDue) {
+			t.Fatalf("Expected the last occurrence due at %v, got %v", expectedDue, last.DueAt)
+		}
+
+		err = service.UpdateTask(ctx, last.ID.String(), &domain.UpdateTaskRequest{DueAt: &moved}, nil)
+		if !errors.Is(err, domain.ErrConflict) {
+			t.Errorf("Expected conflict for two occurrences due at once, got %v", err)
+		}
+	})
+
+	t.Run("invalid rule is rejected", func(t *testing.T) {
+		_, err := service.CreateTask(ctx, &domain.CreateTaskRequest{Title: "Bad", RRule: "FREQ=HOURLY"})
+		if err == nil {
+			t.Fatal("Expected error for unsupported frequency, got nil")
+		}
+	})
+}
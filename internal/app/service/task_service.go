@@ -3,34 +3,62 @@ package service
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
 	"github.com/alexgolang/ishare-task/internal/app/db/sqlite/sqlc"
 	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/recurrence"
+	"github.com/alexgolang/ishare-task/internal/app/storage"
 	"github.com/google/uuid"
 )
 
 type TaskService struct {
-	logger *log.Logger
-	db     *sqlite.Database
+	logger   *log.Logger
+	db       *sqlite.Database
+	blobs    storage.BlobStore
+	workflow domain.Workflow
 }
 
-func NewTaskService(logger *log.Logger, db *sqlite.Database) *TaskService {
+func NewTaskService(logger *log.Logger, db *sqlite.Database, blobs storage.BlobStore, workflow domain.Workflow) *TaskService {
 	return &TaskService{
-		logger: logger,
-		db:     db,
+		logger:   logger,
+		db:       db,
+		blobs:    blobs,
+		workflow: workflow,
 	}
 }
 
 func (s *TaskService) CreateTask(ctx context.Context, task *domain.CreateTaskRequest) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		var err error
+		id, err = s.createTask(ctx, q, task)
+		return err
+	})
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("create task: %w", err)
+	}
+
+	return id, nil
+}
+
+// createTask validates and creates a task inside a transaction.
+func (s *TaskService) createTask(ctx context.Context, q *sqlc.Queries, task *domain.CreateTaskRequest) (uuid.UUID, error) {
+	return s.insertTask(ctx, q, task, sql.NullString{})
+}
+
+// insertTask is createTask for a task created from the given template.
+func (s *TaskService) insertTask(ctx context.Context, q *sqlc.Queries, task *domain.CreateTaskRequest, templateID sql.NullString) (uuid.UUID, error) {
 	if task.Title == "" {
-		return uuid.UUID{}, fmt.Errorf("create task: title is required")
+		return uuid.UUID{}, domain.Invalid("Title", "title is required")
 	}
 
-	status := domain.TaskStatusToDo
+	status := s.workflow.InitialStatus
 	priority := domain.TaskPriorityLow
 
 	if task.Status != "" {
@@ -41,133 +69,631 @@ func (s *TaskService) CreateTask(ctx context.Context, task *domain.CreateTaskReq
 		priority = task.Priority
 	}
 
-	if !status.IsValid() {
-		return uuid.UUID{}, fmt.Errorf("create task: invalid status")
+	if !s.workflow.HasStatus(status) {
+		return uuid.UUID{}, domain.Invalid("Status", "invalid status")
 	}
 
 	if !priority.IsValid() {
-		return uuid.UUID{}, fmt.Errorf("create task: invalid priority")
+		return uuid.UUID{}, domain.Invalid("Priority", "invalid priority")
+	}
+
+	assignees, err := normalizeAssignees(task.Assignees)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	tags, err := normalizeTags(task.Tags)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	projectID := domain.DefaultProjectID.String()
+	if task.ProjectID != "" {
+		projectID = task.ProjectID
+	}
+
+	var parentID sql.NullString
+	if task.ParentID != "" {
+		parent, err := q.GetTask(ctx, task.ParentID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return uuid.UUID{}, domain.NotFound("parent task not found")
+			}
+			return uuid.UUID{}, err
+		}
+
+		if task.ProjectID == "" {
+			projectID = parent.ProjectID
+		} else if projectID != parent.ProjectID {
+			return uuid.UUID{}, domain.Invalid("ParentID", "subtasks must be in the project of their parent")
+		}
+
+		parentID = sql.NullString{String: parent.ID, Valid: true}
+	}
+
+	var dueAt sql.NullTime
+	if task.DueAt != nil {
+		dueAt = sql.NullTime{Time: task.DueAt.UTC(), Valid: true}
+	}
+
+	remaining := task.Remaining
+	if remaining == nil {
+		remaining = task.Estimate
+	}
+
+	if err := validateEstimate(task.Estimate, remaining); err != nil {
+		return uuid.UUID{}, err
+	}
+
+	var rule recurrence.Rule
+	if task.RRule != "" {
+		rule, err = recurrence.Parse(task.RRule)
+		if err != nil {
+			return uuid.UUID{}, domain.Invalid("RRule", "%v", err)
+		}
 	}
 
 	id := uuid.New()
-	err := s.db.Queries.CreateTask(ctx, sqlc.CreateTaskParams{
+	now := time.Now().UTC()
+
+	project, err := q.GetProject(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.UUID{}, domain.NotFound("project not found")
+		}
+		return uuid.UUID{}, err
+	}
+
+	if project.Archived {
+		return uuid.UUID{}, domain.Conflict("project %s is archived", project.Key)
+	}
+
+	seq, err := q.NextProjectTaskSeq(ctx, projectID)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	var seriesID sql.NullString
+	if task.RRule != "" {
+		dtstart := now
+		if dueAt.Valid {
+			dtstart = dueAt.Time
+		}
+
+		series, first, err := startSeries(ctx, q, rule, dtstart.Truncate(time.Second), projectID, task, priority, now)
+		if err != nil {
+			return uuid.UUID{}, err
+		}
+
+		seriesID = sql.NullString{String: series, Valid: true}
+		dueAt = sql.NullTime{Time: first, Valid: true}
+	}
+
+	key, err := endRank(ctx, q, status, id.String())
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
+	err = q.CreateTask(ctx, sqlc.CreateTaskParams{
 		ID:          id.String(),
 		Title:       task.Title,
 		Description: sql.NullString{String: task.Description, Valid: task.Description != ""},
 		Status:      status,
 		Priority:    priority,
-		CreatedAt:   time.Now().UTC(),
-		UpdatedAt:   time.Now().UTC(),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		ProjectID:   projectID,
+		Seq:         seq,
+		DueAt:       dueAt,
+		SeriesID:    seriesID,
+		Estimate:    nullFloat(task.Estimate),
+		Remaining:   nullFloat(remaining),
+		Rank:        key,
+		ParentID:    parentID,
+		TemplateID:  templateID,
 	})
+	if err != nil {
+		return uuid.UUID{}, err
+	}
 
+	if err := replaceAssignees(ctx, q, id.String(), assignees, now); err != nil {
+		return uuid.UUID{}, err
+	}
+
+	if err := replaceTags(ctx, q, id.String(), tags); err != nil {
+		return uuid.UUID{}, err
+	}
+
+	after, err := snapshotTask(ctx, q, id.String())
 	if err != nil {
-		return uuid.UUID{}, fmt.Errorf("create task: %w", err)
+		return uuid.UUID{}, err
+	}
+
+	if err := recordTaskEvent(ctx, q, id.String(), domain.TaskEventCreated, nil, after, now); err != nil {
+		return uuid.UUID{}, err
 	}
 
 	return id, nil
 }
 
-func (s *TaskService) GetTasks(ctx context.Context) ([]domain.Task, error) {
-	tasks, err := s.db.Queries.GetTasks(ctx)
+func (s *TaskService) GetTasks(ctx context.Context, filter domain.TaskFilter) ([]domain.Task, error) {
+	tasks, err := s.listTasks(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("get tasks: %w", err)
 	}
 
 	domainTasks := make([]domain.Task, len(tasks))
 	for i, task := range tasks {
-		domainTasks[i] = toDomain(task)
+		domainTasks[i], err = toDomainTask(ctx, s.db.Queries, task)
+		if err != nil {
+			return nil, fmt.Errorf("get tasks: %w", err)
+		}
 	}
 
 	return domainTasks, nil
 }
 
+// getTasksParams turns a filter into the parameters of the GetTasks query.
+func getTasksParams(filter domain.TaskFilter) (sqlc.GetTasksParams, error) {
+	tag, err := normalizeFilterTag(filter.Tag)
+	if err != nil {
+		return sqlc.GetTasksParams{}, err
+	}
+
+	return sqlc.GetTasksParams{
+		Assignee:  sql.NullString{String: filter.Assignee, Valid: filter.Assignee != ""},
+		ProjectID: sql.NullString{String: filter.ProjectID, Valid: filter.ProjectID != ""},
+		Tag:       sql.NullString{String: tag, Valid: tag != ""},
+	}, nil
+}
+
 func (s *TaskService) GetTask(ctx context.Context, id string) (domain.Task, error) {
 	if id == "" {
-		return domain.Task{}, fmt.Errorf("get task: id is required")
+		return domain.Task{}, fmt.Errorf("get task: %w", domain.Invalid("id", "id is required"))
 	}
 
-	task, err := s.db.Queries.GetTask(ctx, id)
+	var task sqlc.Task
+	var err error
+	if projectKey, seq, ok := domain.ParseTaskKey(id); ok {
+		task, err = s.db.Queries.GetTaskByKey(ctx, sqlc.GetTaskByKeyParams{Key: projectKey, Seq: seq})
+	} else {
+		task, err = s.db.Queries.GetTask(ctx, id)
+	}
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Task{}, fmt.Errorf("get task: %w", domain.NotFound("task not found"))
+		}
 		return domain.Task{}, fmt.Errorf("get task: %w", err)
 	}
 
-	return toDomain(task), nil
+	domainTask, err := toDomainTask(ctx, s.db.Queries, task)
+	if err != nil {
+		return domain.Task{}, fmt.Errorf("get task: %w", err)
+	}
+
+	return domainTask, nil
+}
+
+// UpdateTask applies a partial update to a task. When ifMatch is non-empty, the
+// update only succeeds if the task is at one of those versions; nil sets no
+// precondition.
+func (s *TaskService) UpdateTask(ctx context.Context, id string, task *domain.UpdateTaskRequest, ifMatch []int64) error {
+	err := s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		return s.updateTask(ctx, q, id, task, ifMatch)
+	})
+	if err != nil {
+		return fmt.Errorf("update task: %w", err)
+	}
+
+	return nil
 }
 
-func (s *TaskService) UpdateTask(ctx context.Context, id string, task *domain.UpdateTaskRequest) error {
+// updateTask applies a partial update inside a transaction. Fields that are
+// nil or empty in the request are left unchanged.
+func (s *TaskService) updateTask(ctx context.Context, q *sqlc.Queries, id string, task *domain.UpdateTaskRequest, ifMatch []int64) error {
 	if id == "" {
-		return fmt.Errorf("update task: id is required")
+		return domain.Invalid("id", "id is required")
 	}
 
-	if task.Status != nil && !task.Status.IsValid() {
-		return fmt.Errorf("update task: invalid status")
+	current, err := q.GetTask(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.NotFound("task not found")
+		}
+		return err
 	}
 
-	if task.Priority != nil && !task.Priority.IsValid() {
-		return fmt.Errorf("update task: invalid priority")
+	if err := checkVersion(current, ifMatch); err != nil {
+		return err
 	}
 
-	title := ""
-	if task.Title != nil {
-		title = *task.Title
+	changes, err := currentTaskChanges(ctx, q, current)
+	if err != nil {
+		return err
 	}
 
-	status := domain.TaskStatus("")
-	if task.Status != nil {
-		status = *task.Status
+	if task.Title != nil && *task.Title != "" {
+		changes.Title = *task.Title
+	}
+	if task.Description != nil && *task.Description != "" {
+		changes.Description = *task.Description
+	}
+	if task.Status != nil && *task.Status != "" {
+		changes.Status = *task.Status
+	}
+	if task.Priority != nil && *task.Priority != "" {
+		changes.Priority = *task.Priority
+	}
+	if task.Assignees != nil {
+		changes.Assignees = *task.Assignees
+	}
+	if task.Tags != nil {
+		changes.Tags = *task.Tags
+	}
+	if task.DueAt != nil {
+		changes.DueAt = task.DueAt
+	}
+	if task.Estimate != nil {
+		changes.Estimate = task.Estimate
+	}
+	if task.Remaining != nil {
+		changes.Remaining = task.Remaining
+	} else if task.Estimate != nil && changes.Remaining == nil {
+		// Remaining work starts at the first estimate, as on creation.
+		changes.Remaining = task.Estimate
+	}
+	if task.Comment != nil {
+		changes.Comment = *task.Comment
 	}
 
-	priority := domain.TaskPriority("")
-	if task.Priority != nil {
-		priority = *task.Priority
+	return s.applyTaskChanges(ctx, q, current, changes)
+}
+
+// taskChanges is the complete new state of the editable fields of a task.
+type taskChanges struct {
+	Title       string
+	Description string
+	Status      domain.TaskStatus
+	Priority    domain.TaskPriority
+	Assignees   []string
+	Tags        []string
+	DueAt       *time.Time
+	Estimate    *float64
+	Remaining   *float64
+	// Rank places the task in its status. Empty keeps the current rank, or
+	// moves the task to the end of a new status.
+	Rank string
+	// Comment is added to the task's thread along with the change.
+	Comment string
+}
+
+// currentTaskChanges returns the editable fields of a task as they are.
+func currentTaskChanges(ctx context.Context, q *sqlc.Queries, task sqlc.Task) (taskChanges, error) {
+	domainTask, err := toDomainTask(ctx, q, task)
+	if err != nil {
+		return taskChanges{}, err
 	}
 
-	description := ""
-	if task.Description != nil {
-		description = *task.Description
+	return taskChanges{
+		Title:       domainTask.Title,
+		Description: domainTask.Description,
+		Status:      domainTask.Status,
+		Priority:    domainTask.Priority,
+		Assignees:   domainTask.Assignees,
+		Tags:        domainTask.Tags,
+		DueAt:       domainTask.DueAt,
+		Estimate:    domainTask.Estimate,
+		Remaining:   domainTask.Remaining,
+	}, nil
+}
+
+// applyTaskChanges validates the new state of a task and writes it, along with
+// its history event, inside a transaction.
+func (s *TaskService) applyTaskChanges(ctx context.Context, q *sqlc.Queries, current sqlc.Task, changes taskChanges) error {
+	if changes.Title == "" {
+		return domain.Invalid("Title", "title is required")
 	}
 
-	err := s.db.Queries.UpdateTask(ctx, sqlc.UpdateTaskParams{
-		ID:          id,
-		Title:       title,
-		Description: sql.NullString{String: description, Valid: description != ""},
-		Status:      status,
-		Priority:    priority,
-		UpdatedAt:   time.Now().UTC(),
+	if changes.Status == "" {
+		return domain.Invalid("Status", "status is required")
+	}
+
+	if !s.workflow.HasStatus(changes.Status) {
+		return domain.Invalid("Status", "invalid status")
+	}
+
+	if changes.Priority == "" {
+		return domain.Invalid("Priority", "priority is required")
+	}
+
+	if !changes.Priority.IsValid() {
+		return domain.Invalid("Priority", "invalid priority")
+	}
+
+	assignees, err := normalizeAssignees(changes.Assignees)
+	if err != nil {
+		return err
+	}
+
+	tags, err := normalizeTags(changes.Tags)
+	if err != nil {
+		return err
+	}
+
+	comment := strings.TrimSpace(changes.Comment)
+
+	var dueAt sql.NullTime
+	if changes.DueAt != nil {
+		dueAt = sql.NullTime{Time: changes.DueAt.UTC(), Valid: true}
+	}
+
+	if err := validateEstimate(changes.Estimate, changes.Remaining); err != nil {
+		return err
+	}
+
+	key := changes.Rank
+	if key == "" {
+		key = current.Rank
+		if changes.Status != current.Status {
+			if key, err = endRank(ctx, q, changes.Status, current.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	before, err := snapshotTask(ctx, q, current.ID)
+	if err != nil {
+		return err
+	}
+
+	if changes.Status != current.Status {
+		if err := s.checkTransition(current.Status, changes.Status, comment); err != nil {
+			return err
+		}
+
+		if s.workflow.IsStarted(changes.Status) {
+			openBlockers, err := q.CountOpenBlockers(ctx, sqlc.CountOpenBlockersParams{
+				TaskID:       current.ID,
+				DoneStatuses: s.doneStatuses(),
+			})
+			if err != nil {
+				return err
+			}
+
+			if openBlockers > 0 {
+				return domain.Conflict("task is blocked by %d unfinished task(s)", openBlockers)
+			}
+		}
+	}
+
+	now := time.Now().UTC()
+	err = q.UpdateTask(ctx, sqlc.UpdateTaskParams{
+		ID:          current.ID,
+		Title:       changes.Title,
+		Description: sql.NullString{String: changes.Description, Valid: changes.Description != ""},
+		Status:      changes.Status,
+		Priority:    changes.Priority,
+		DueAt:       dueAt,
+		Estimate:    nullFloat(changes.Estimate),
+		Remaining:   nullFloat(changes.Remaining),
+		Rank:        key,
+		UpdatedAt:   now,
 	})
+	if err != nil {
+		if sqlite.IsUniqueViolation(err) {
+			return domain.Conflict("another occurrence of the series is due at that time")
+		}
+		return err
+	}
+
+	if err := replaceAssignees(ctx, q, current.ID, assignees, now); err != nil {
+		return err
+	}
+
+	if err := replaceTags(ctx, q, current.ID, tags); err != nil {
+		return err
+	}
+
+	if dueAt.Valid && (!current.DueAt.Valid || !dueAt.Time.Equal(current.DueAt.Time)) {
+		if err := rescheduleReminders(ctx, q, current.ID, dueAt.Time); err != nil {
+			return err
+		}
+	}
 
+	after, err := snapshotTask(ctx, q, current.ID)
 	if err != nil {
-		return fmt.Errorf("update task: %w", err)
+		return err
+	}
+
+	if err := recordTaskEvent(ctx, q, current.ID, domain.TaskEventUpdated, before, after, now); err != nil {
+		return err
+	}
+
+	if s.workflow.IsDone(changes.Status) && !s.workflow.IsDone(current.Status) && current.SeriesID.Valid {
+		if err := s.spawnAfterCompletion(ctx, q, current, now); err != nil {
+			return err
+		}
+	}
+
+	if comment != "" {
+		err := q.CreateTaskComment(ctx, sqlc.CreateTaskCommentParams{
+			ID:        uuid.New().String(),
+			TaskID:    current.ID,
+			Author:    domain.ActorFromContext(ctx),
+			Body:      comment,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteTask moves a task to the trash. It is hidden from normal queries
+// until it is restored, or purged once the retention period has passed. When
+// ifMatch is non-empty, the task must be at one of those versions; nil sets
+// no precondition.
+func (s *TaskService) DeleteTask(ctx context.Context, id string, ifMatch []int64) error {
+	err := s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		return s.deleteTask(ctx, q, id, ifMatch)
+	})
+	if err != nil {
+		return fmt.Errorf("delete task: %w", err)
 	}
 
 	return nil
 }
 
-func (s *TaskService) DeleteTask(ctx context.Context, id string) error {
+// deleteTask moves a task to the trash inside a transaction.
+func (s *TaskService) deleteTask(ctx context.Context, q *sqlc.Queries, id string, ifMatch []int64) error {
 	if id == "" {
-		return fmt.Errorf("delete task: id is required")
+		return domain.Invalid("id", "id is required")
+	}
+
+	if err := requireVersion(ctx, q, id, ifMatch); err != nil {
+		return err
 	}
 
-	result, err := s.db.Queries.DeleteTask(ctx, id)
+	before, err := snapshotTask(ctx, q, id)
 	if err != nil {
-		return fmt.Errorf("delete task: %w", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.NotFound("task not found")
+		}
+		return err
 	}
 
-	if result == 0 {
-		return fmt.Errorf("delete task: task not found")
+	now := time.Now().UTC()
+	if _, err := q.SoftDeleteTask(ctx, sqlc.SoftDeleteTaskParams{
+		DeletedAt: sql.NullTime{Time: now, Valid: true},
+		ID:        id,
+	}); err != nil {
+		return err
 	}
 
-	return nil
+	return recordTaskEvent(ctx, q, id, domain.TaskEventDeleted, before, nil, now)
+}
+
+// checkVersion verifies an If-Match precondition; an empty ifMatch always
+// matches.
+func checkVersion(task sqlc.Task, ifMatch []int64) error {
+	if len(ifMatch) == 0 {
+		return nil
+	}
+
+	for _, version := range ifMatch {
+		if version == task.Version {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: task is at version %d", domain.ErrVersionMismatch, task.Version)
+}
+
+// requireVersion loads a task and verifies an If-Match precondition on it.
+func requireVersion(ctx context.Context, q *sqlc.Queries, id string, ifMatch []int64) error {
+	if len(ifMatch) == 0 {
+		return nil
+	}
+
+	task, err := q.GetTask(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.NotFound("task not found")
+		}
+		return err
+	}
+
+	return checkVersion(task, ifMatch)
 }
 
 func toDomain(task sqlc.Task) domain.Task {
 	return domain.Task{
 		ID:          uuid.MustParse(task.ID),
+		ExternalID:  task.ExternalID.String,
+		ProjectID:   uuid.MustParse(task.ProjectID),
 		Title:       task.Title,
 		Description: task.Description.String,
 		Status:      task.Status,
 		Priority:    task.Priority,
+		DueAt:       nullTimePtr(task.DueAt),
+		Estimate:    nullFloatPtr(task.Estimate),
+		Remaining:   nullFloatPtr(task.Remaining),
+		SeriesID:    nullUUIDPtr(task.SeriesID),
 		CreatedAt:   task.CreatedAt,
 		UpdatedAt:   task.UpdatedAt,
+		DeletedAt:   nullTimePtr(task.DeletedAt),
+		Version:     task.Version,
+		Rank:        task.Rank,
+		ParentID:    nullUUIDPtr(task.ParentID),
+		TemplateID:  nullUUIDPtr(task.TemplateID),
+	}
+}
+
+func nullTimePtr(value sql.NullTime) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Time
+}
+
+func nullFloatPtr(value sql.NullFloat64) *float64 {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Float64
+}
+
+func nullFloat(value *float64) sql.NullFloat64 {
+	if value == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *value, Valid: true}
+}
+
+// validateEstimate checks that the estimate and remaining work of a task are
+// not negative. Remaining may exceed the estimate when a task grows.
+func validateEstimate(estimate, remaining *float64) error {
+	if estimate != nil && *estimate < 0 {
+		return domain.Invalid("Estimate", "estimate must not be negative")
+	}
+	if remaining != nil && *remaining < 0 {
+		return domain.Invalid("Remaining", "remaining must not be negative")
+	}
+	return nil
+}
+
+func nullUUIDPtr(value sql.NullString) *uuid.UUID {
+	if !value.Valid {
+		return nil
 	}
+	id := uuid.MustParse(value.String)
+	return &id
+}
+
+// toDomainTask converts a task row and loads its assignees, tags and project
+// key.
+func toDomainTask(ctx context.Context, q *sqlc.Queries, task sqlc.Task) (domain.Task, error) {
+	assignees, err := q.ListTaskAssignees(ctx, task.ID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	tags, err := q.ListTaskTags(ctx, task.ID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	project, err := q.GetProject(ctx, task.ProjectID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	domainTask := toDomain(task)
+	domainTask.Key = domain.TaskKey(project.Key, task.Seq)
+	domainTask.Assignees = assignees
+	domainTask.Tags = tags
+	return domainTask, nil
 }
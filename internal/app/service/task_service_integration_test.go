@@ -9,6 +9,7 @@ import (
 
 	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
 	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/storage"
 	"github.com/google/uuid"
 )
 
@@ -30,7 +31,12 @@ func TestTaskService_CreateTask_Integration(t *testing.T) {
 	}
 
 	logger := log.New(os.Stderr, "INTEGRATION_TEST: ", log.LstdFlags)
-	service := NewTaskService(logger, db)
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+
+	service := NewTaskService(logger, db, blobs, domain.DefaultWorkflow())
 
 	t.Run("create task successfully", func(t *testing.T) {
 		req := &domain.CreateTaskRequest{
@@ -53,6 +53,7 @@ func TestTaskService_GetTask(t *testing.T) {
 		expectedID := uuid.New()
 		expectedTask := sqlc.Task{
 			ID:          expectedID.String(),
+			ProjectID:   domain.DefaultProjectID.String(),
 			Title:       "Test Task",
 			Description: sql.NullString{String: "Test Description", Valid: true},
 			Status:      domain.TaskStatusToDo,
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+)
+
+const (
+	// defaultStatsDays is the length of the daily series without From.
+	defaultStatsDays = 30
+	// maxStatsDays limits the length of the daily series.
+	maxStatsDays = 366
+)
+
+// GetTaskStats counts the tasks matching a filter, in total and per group:
+// how many there are, are done and are overdue, and how long the done ones
+// took. It also counts the tasks created and completed on each day of a
+// range. Everything is aggregated in SQL; only the days without activity are
+// filled in here.
+func (s *TaskService) GetTaskStats(ctx context.Context, filter domain.TaskStatsFilter) (domain.TaskStats, error) {
+	groupBy := filter.GroupBy
+	if groupBy == "" {
+		groupBy = domain.TaskStatsGroupByStatus
+	}
+	if !groupBy.IsValid() {
+		return domain.TaskStats{}, fmt.Errorf("get task stats: %w", domain.Invalid("group_by", "group by must be status, priority, assignee, tag or project"))
+	}
+
+	to := filter.To
+	if to.IsZero() {
+		to = time.Now()
+	}
+	to = utcDate(to)
+
+	from := filter.From
+	if from.IsZero() {
+		from = to.AddDate(0, 0, 1-defaultStatsDays)
+	}
+	from = utcDate(from)
+
+	if to.Before(from) {
+		return domain.TaskStats{}, fmt.Errorf("get task stats: %w", domain.Invalid("to", "to is before from"))
+	}
+
+	days := int(to.Sub(from)/(24*time.Hour)) + 1
+	if days > maxStatsDays {
+		return domain.TaskStats{}, fmt.Errorf("get task stats: %w", domain.Invalid("from", "the daily series covers at most %d days", maxStatsDays))
+	}
+
+	query, err := s.filterQuery(ctx, filter.Tasks)
+	if err != nil {
+		return domain.TaskStats{}, fmt.Errorf("get task stats: %w", err)
+	}
+
+	now := time.Now()
+	total, err := s.db.TaskStats(ctx, query, "", s.workflow.DoneStatuses, now)
+	if err != nil {
+		return domain.TaskStats{}, fmt.Errorf("get task stats: %w", err)
+	}
+
+	groups, err := s.db.TaskStats(ctx, query, groupBy, s.workflow.DoneStatuses, now)
+	if err != nil {
+		return domain.TaskStats{}, fmt.Errorf("get task stats: %w", err)
+	}
+
+	perDay, err := s.db.TaskStatsPerDay(ctx, query, s.workflow.DoneStatuses, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return domain.TaskStats{}, fmt.Errorf("get task stats: %w", err)
+	}
+
+	stats := domain.TaskStats{
+		GroupBy: groupBy,
+		From:    from.Format(time.DateOnly),
+		To:      to.Format(time.DateOnly),
+		Total:   domain.TaskStatsGroup{},
+		Groups:  make([]domain.TaskStatsGroup, len(groups)),
+		Days:    make([]domain.TaskStatsDay, days),
+	}
+
+	if len(total) == 1 {
+		stats.Total = toDomainTaskStatsGroup(total[0])
+	}
+
+	for i, group := range groups {
+		stats.Groups[i] = toDomainTaskStatsGroup(group)
+	}
+
+	counts := make(map[string]sqlite.TaskStatsDay, len(perDay))
+	for _, day := range perDay {
+		counts[day.Date] = day
+	}
+
+	for i := range stats.Days {
+		date := from.AddDate(0, 0, i).Format(time.DateOnly)
+		stats.Days[i] = domain.TaskStatsDay{
+			Date:      date,
+			Created:   counts[date].Created,
+			Completed: counts[date].Completed,
+		}
+	}
+
+	return stats, nil
+}
+
+func toDomainTaskStatsGroup(row sqlite.TaskStatsRow) domain.TaskStatsGroup {
+	group := domain.TaskStatsGroup{
+		Key:     row.Key,
+		Count:   row.Count,
+		Done:    row.Done,
+		Overdue: row.Overdue,
+	}
+
+	if row.AverageSecondsToDone.Valid {
+		group.AverageSecondsToDone = int64(math.Round(row.AverageSecondsToDone.Float64))
+		group.AverageTimeToDone = formatSeconds(group.AverageSecondsToDone)
+	}
+
+	return group
+}
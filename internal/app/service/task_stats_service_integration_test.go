@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite/sqlc"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/storage"
+	"github.com/google/uuid"
+)
+
+func TestTaskStats_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, err := sqlite.NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+
+	logger := log.New(os.Stderr, "INTEGRATION_TEST: ", log.LstdFlags)
+	taskService := NewTaskService(logger, db, blobs, domain.DefaultWorkflow())
+	projectService := NewProjectService(logger, db)
+	ctx := domain.WithActor(context.Background(), "alice")
+
+	project, err := projectService.CreateProject(ctx, &domain.CreateProjectRequest{Key: "OPS", Name: "Operations"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	yesterday := time.Now().Add(-24 * time.Hour)
+	requests := []domain.CreateTaskRequest{
+		{Title: "Fix login", Priority: domain.TaskPriorityHigh, Assignees: []string{"alice"}, Tags: []string{"urgent"}, DueAt: &yesterday},
+		{Title: "Write docs", Priority: domain.TaskPriorityLow, Status: domain.TaskStatusDone},
+		{Title: "Rotate keys", Priority: domain.TaskPriorityMedium, Status: domain.TaskStatusDone, Assignees: []string{"bob"}, Tags: []string{"security", "urgent"}, ProjectID: project.ID.String(), DueAt: &yesterday},
+		{Title: "Plan sprint", Priority: domain.TaskPriorityHigh, Assignees: []string{"alice", "bob"}},
+	}
+
+	tasks := make([]domain.Task, len(requests))
+	for i := range requests {
+		id, err := taskService.CreateTask(ctx, &requests[i])
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+		if tasks[i], err = taskService.GetTask(ctx, id.String()); err != nil {
+			t.Fatalf("Failed to get task: %v", err)
+		}
+	}
+
+	// complete records a later move to done, as if the task had been worked
+	// on for a while before it was finished.
+	complete := func(task domain.Task, after time.Duration) time.Time {
+		t.Helper()
+
+		at := task.CreatedAt.Add(after)
+		data, err := json.Marshal([]domain.FieldChange{{Field: "status", After: json.RawMessage(`"done"`)}})
+		if err != nil {
+			t.Fatalf("Failed to encode changes: %v", err)
+		}
+
+		err = db.Queries.CreateTaskEvent(ctx, sqlc.CreateTaskEventParams{
+			ID:        uuid.New().String(),
+			TaskID:    task.ID.String(),
+			Action:    string(domain.TaskEventUpdated),
+			Actor:     "test",
+			Changes:   string(data),
+			CreatedAt: at,
+		})
+		if err != nil {
+			t.Fatalf("Failed to record event: %v", err)
+		}
+		return at
+	}
+	docsDone := complete(tasks[1], time.Hour)
+	keysDone := complete(tasks[2], 3*time.Hour)
+
+	t.Run("groups count tasks, done and overdue ones", func(t *testing.T) {
+		stats, err := taskService.GetTaskStats(ctx, domain.TaskStatsFilter{})
+		if err != nil {
+			t.Fatalf("Failed to get stats: %v", err)
+		}
+
+		if stats.GroupBy != domain.TaskStatsGroupByStatus {
+			t.Errorf("Expected grouping by status by default, got %q", stats.GroupBy)
+		}
+
+		expected := []domain.TaskStatsGroup{
+			{Key: "done", Count: 2, Done: 2, AverageTimeToDone: "2h0m0s", AverageSecondsToDone: 7200},
+			{Key: "to_do", Count: 2, Overdue: 1},
+		}
+		if len(stats.Groups) != len(expected) {
+			t.Fatalf("Expected %d groups, got %+v", len(expected), stats.Groups)
+		}
+		for i, group := range stats.Groups {
+			if group != expected[i] {
+				t.Errorf("Expected %+v, got %+v", expected[i], group)
+			}
+		}
+
+		total := domain.TaskStatsGroup{Count: 4, Done: 2, Overdue: 1, AverageTimeToDone: "2h0m0s", AverageSecondsToDone: 7200}
+		if stats.Total != total {
+			t.Errorf("Expected total %+v, got %+v", total, stats.Total)
+		}
+	})
+
+	t.Run("tasks count in each of their groups", func(t *testing.T) {
+		tests := map[domain.TaskStatsGroupBy]map[string]int64{
+			domain.TaskStatsGroupByPriority: {"high": 2, "low": 1, "medium": 1},
+			domain.TaskStatsGroupByAssignee: {"": 1, "alice": 2, "bob": 2},
+			domain.TaskStatsGroupByTag:      {"": 2, "security": 1, "urgent": 2},
+			domain.TaskStatsGroupByProject:  {"OPS": 1, "TASK": 3},
+		}
+
+		for groupBy, expected := range tests {
+			stats, err := taskService.GetTaskStats(ctx, domain.TaskStatsFilter{GroupBy: groupBy})
+			if err != nil {
+				t.Fatalf("Failed to get stats by %s: %v", groupBy, err)
+			}
+
+			counts := map[string]int64{}
+			for _, group := range stats.Groups {
+				counts[group.Key] = group.Count
+			}
+			if len(counts) != len(expected) {
+				t.Errorf("Expected groups %v by %s, got %v", expected, groupBy, counts)
+				continue
+			}
+			for key, count := range expected {
+				if counts[key] != count {
+					t.Errorf("Expected %d tasks in %s %q, got %d", count, groupBy, key, counts[key])
+				}
+			}
+			if stats.Total.Count != 4 {
+				t.Errorf("Expected a total of 4 tasks by %s, got %d", groupBy, stats.Total.Count)
+			}
+		}
+	})
+
+	t.Run("the average time to done is per group", func(t *testing.T) {
+		stats, err := taskService.GetTaskStats(ctx, domain.TaskStatsFilter{GroupBy: domain.TaskStatsGroupByAssignee})
+		if err != nil {
+			t.Fatalf("Failed to get stats: %v", err)
+		}
+
+		averages := map[string]string{}
+		for _, group := range stats.Groups {
+			averages[group.Key] = group.AverageTimeToDone
+		}
+		if averages[""] != "1h0m0s" || averages["bob"] != "3h0m0s" || averages["alice"] != "" {
+			t.Errorf("Expected 1h unassigned, 3h for bob and none for alice, got %v", averages)
+		}
+	})
+
+	t.Run("filters narrow the tasks", func(t *testing.T) {
+		stats, err := taskService.GetTaskStats(ctx, domain.TaskStatsFilter{
+			Tasks: domain.TaskFilter{Assignee: "bob", Expression: `priority >= medium`},
+		})
+		if err != nil {
+			t.Fatalf("Failed to get stats: %v", err)
+		}
+
+		if stats.Total.Count != 2 || stats.Total.Done != 1 || len(stats.Groups) != 2 {
+			t.Errorf("Expected bob's two tasks, one done, got %+v", stats)
+		}
+
+		_, err = taskService.GetTaskStats(ctx, domain.TaskStatsFilter{Tasks: domain.TaskFilter{Expression: `stat = done`}})
+		if !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("Expected invalid expressions to be refused, got %v", err)
+		}
+	})
+
+	t.Run("days count created and completed tasks", func(t *testing.T) {
+		from := time.Now().AddDate(0, 0, -1)
+		to := time.Now().AddDate(0, 0, 1)
+		stats, err := taskService.GetTaskStats(ctx, domain.TaskStatsFilter{From: from, To: to})
+		if err != nil {
+			t.Fatalf("Failed to get stats: %v", err)
+		}
+
+		created := map[string]int64{}
+		for _, task := range tasks {
+			created[task.CreatedAt.UTC().Format(time.DateOnly)]++
+		}
+
+		// Finished tasks are moved to done on creation and again later.
+		completed := map[string]map[string]bool{}
+		for task, times := range map[*domain.Task][]time.Time{
+			&tasks[1]: {tasks[1].CreatedAt, docsDone},
+			&tasks[2]: {tasks[2].CreatedAt, keysDone},
+		} {
+			for _, at := range times {
+				day := at.UTC().Format(time.DateOnly)
+				if completed[day] == nil {
+					completed[day] = map[string]bool{}
+				}
+				completed[day][task.Title] = true
+			}
+		}
+
+		if len(stats.Days) != 3 || stats.From != from.UTC().Format(time.DateOnly) || stats.To != to.UTC().Format(time.DateOnly) {
+			t.Fatalf("Expected three days from %s, got %+v", stats.From, stats.Days)
+		}
+		for _, day := range stats.Days {
+			if day.Created != created[day.Date] || day.Completed != int64(len(completed[day.Date])) {
+				t.Errorf("Expected %d created and %d completed on %s, got %+v", created[day.Date], len(completed[day.Date]), day.Date, day)
+			}
+		}
+	})
+
+	t.Run("invalid groupings and ranges are refused", func(t *testing.T) {
+		now := time.Now()
+		filters := []domain.TaskStatsFilter{
+			{GroupBy: "owner"},
+			{From: now, To: now.AddDate(0, 0, -1)},
+			{From: now.AddDate(-2, 0, 0), To: now},
+		}
+
+		for _, filter := range filters {
+			if _, err := taskService.GetTaskStats(ctx, filter); !errors.Is(err, domain.ErrValidation) {
+				t.Errorf("Expected %+v to be refused, got %v", filter, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite/sqlc"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+)
+
+// maxTagLength bounds the length of a single tag.
+const maxTagLength = 64
+
+// normalizeTags lower-cases and trims tags, sorts them and drops duplicates.
+// Tags must not be empty or contain whitespace.
+func normalizeTags(tags []string) ([]string, error) {
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			return nil, domain.Invalid("Tags", "tag must not be empty")
+		}
+
+		if len(tag) > maxTagLength {
+			return nil, domain.Invalid("Tags", "tag %q is longer than %d characters", tag, maxTagLength)
+		}
+
+		if strings.IndexFunc(tag, unicode.IsSpace) >= 0 {
+			return nil, domain.Invalid("Tags", "tag %q must not contain whitespace", tag)
+		}
+
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+
+	slices.Sort(normalized)
+	return normalized, nil
+}
+
+// normalizeFilterTag normalizes the tag of a filter like the tags of a task,
+// so that every way of listing tasks matches the same ones. An empty tag
+// stays empty.
+func normalizeFilterTag(tag string) (string, error) {
+	if tag == "" {
+		return "", nil
+	}
+
+	tags, err := normalizeTags([]string{tag})
+	if err != nil {
+		return "", err
+	}
+	return tags[0], nil
+}
+
+// replaceTags makes the task's tags match the given list.
+func replaceTags(ctx context.Context, q *sqlc.Queries, taskID string, tags []string) error {
+	if err := q.DeleteTaskTags(ctx, taskID); err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		if err := q.CreateTaskTag(ctx, sqlc.CreateTaskTagParams{TaskID: taskID, Tag: tag}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite/sqlc"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+)
+
+const (
+	// purgeBatchSize caps how many expired tasks are purged per run.
+	purgeBatchSize = 100
+	// retentionActor is recorded as the actor of purges made by the scheduler.
+	retentionActor = "system:retention"
+)
+
+// ListTrash returns the deleted tasks that have not been purged yet, most
+// recently deleted first.
+func (s *TaskService) ListTrash(ctx context.Context) ([]domain.Task, error) {
+	tasks, err := s.db.Queries.ListDeletedTasks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list trash: %w", err)
+	}
+
+	domainTasks := make([]domain.Task, len(tasks))
+	for i, task := range tasks {
+		domainTasks[i], err = toDomainTask(ctx, s.db.Queries, task)
+		if err != nil {
+			return nil, fmt.Errorf("list trash: %w", err)
+		}
+	}
+
+	return domainTasks, nil
+}
+
+// GetTrashedTask returns a task that is in the trash.
+func (s *TaskService) GetTrashedTask(ctx context.Context, id string) (domain.Task, error) {
+	if id == "" {
+		return domain.Task{}, fmt.Errorf("get trashed task: %w", domain.Invalid("id", "id is required"))
+	}
+
+	task, err := s.db.Queries.GetTaskIncludingDeleted(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Task{}, fmt.Errorf("get trashed task: %w", domain.NotFound("task not found"))
+		}
+		return domain.Task{}, fmt.Errorf("get trashed task: %w", err)
+	}
+	if !task.DeletedAt.Valid {
+		return domain.Task{}, fmt.Errorf("get trashed task: %w", domain.NotFound("task is not in the trash"))
+	}
+
+	domainTask, err := toDomainTask(ctx, s.db.Queries, task)
+	if err != nil {
+		return domain.Task{}, fmt.Errorf("get trashed task: %w", err)
+	}
+
+	return domainTask, nil
+}
+
+// RestoreTask takes a task out of the trash and puts it at the end of its
+// status, as the rank it had may have been given to another task meanwhile.
+func (s *TaskService) RestoreTask(ctx context.Context, id string) (domain.Task, error) {
+	if id == "" {
+		return domain.Task{}, fmt.Errorf("restore task: %w", domain.Invalid("id", "id is required"))
+	}
+
+	var restored domain.Task
+	err := s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		trashed, err := q.GetTaskIncludingDeleted(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("restore task: %w", domain.NotFound("task not found"))
+			}
+			return fmt.Errorf("restore task: %w", err)
+		}
+
+		if !trashed.DeletedAt.Valid {
+			return fmt.Errorf("restore task: %w", domain.Conflict("task is not in the trash"))
+		}
+
+		key, err := endRank(ctx, q, trashed.Status, id)
+		if err != nil {
+			return fmt.Errorf("restore task: %w", err)
+		}
+
+		now := time.Now().UTC()
+		if _, err := q.RestoreTask(ctx, sqlc.RestoreTaskParams{Rank: key, UpdatedAt: now, ID: id}); err != nil {
+			return fmt.Errorf("restore task: %w", err)
+		}
+
+		after, err := snapshotTask(ctx, q, id)
+		if err != nil {
+			return fmt.Errorf("restore task: %w", err)
+		}
+
+		if err := recordTaskEvent(ctx, q, id, domain.TaskEventRestored, nil, after, now); err != nil {
+			return fmt.Errorf("restore task: %w", err)
+		}
+
+		task, err := q.GetTask(ctx, id)
+		if err != nil {
+			return fmt.Errorf("restore task: %w", err)
+		}
+
+		restored, err = toDomainTask(ctx, q, task)
+		if err != nil {
+			return fmt.Errorf("restore task: %w", err)
+		}
+
+		return nil
+	})
+
+	return restored, err
+}
+
+// PurgeTask permanently deletes a task, whether or not it is in the trash,
+// together with its comments, attachments and other data. Its history is kept.
+func (s *TaskService) PurgeTask(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("purge task: %w", domain.Invalid("id", "id is required"))
+	}
+
+	if err := s.purge(ctx, id); err != nil {
+		return fmt.Errorf("purge task: %w", err)
+	}
+
+	return nil
+}
+
+// PurgeDeletedTasks permanently deletes the tasks that were moved to the trash
+// before the given time and returns how many were purged. It is run
+// periodically by the application.
+func (s *TaskService) PurgeDeletedTasks(ctx context.Context, deletedBefore time.Time) (int, error) {
+	ctx = domain.WithActor(ctx, retentionActor)
+
+	ids, err := s.db.Queries.ListPurgeableTaskIDs(ctx, sqlc.ListPurgeableTaskIDsParams{
+		DeletedBefore: sql.NullTime{Time: deletedBefore.UTC(), Valid: true},
+		Limit:         purgeBatchSize,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("purge deleted tasks: %w", err)
+	}
+
+	purged := 0
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if err := s.purge(ctx, id); err != nil {
+			return purged, fmt.Errorf("purge deleted tasks: task %s: %w", id, err)
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+func (s *TaskService) purge(ctx context.Context, id string) error {
+	var blobKeys []string
+	err := s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		task, err := q.GetTaskIncludingDeleted(ctx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return domain.NotFound("task not found")
+			}
+			return err
+		}
+
+		var before taskSnapshot
+		if !task.DeletedAt.Valid {
+			before, err = snapshotTask(ctx, q, id)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Attachment metadata is removed by the cascade, so collect the blob keys first.
+		blobKeys, err = q.ListTaskAttachmentStorageKeys(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if _, err := q.DeleteTask(ctx, id); err != nil {
+			return err
+		}
+
+		return recordTaskEvent(ctx, q, id, domain.TaskEventPurged, before, nil, time.Now().UTC())
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, key := range blobKeys {
+		if err := s.blobs.Delete(ctx, key); err != nil {
+			s.logger.Printf("failed to delete attachment blob %s: %v", key, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/storage"
+)
+
+func TestTaskTrash_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, err := sqlite.NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+
+	logger := log.New(os.Stderr, "INTEGRATION_TEST: ", log.LstdFlags)
+	taskService := NewTaskService(logger, db, blobs, domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	id, err := taskService.CreateTask(ctx, &domain.CreateTaskRequest{Title: "Draft announcement"})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	t.Run("deleted tasks are hidden and listed in the trash", func(t *testing.T) {
+		if err := taskService.DeleteTask(ctx, id.String(), nil); err != nil {
+			t.Fatalf("Failed to delete task: %v", err)
+		}
+
+		if _, err := taskService.GetTask(ctx, id.String()); err == nil {
+			t.Error("Expected deleted task to be hidden, got nil error")
+		}
+
+		tasks, err := taskService.GetTasks(ctx, domain.TaskFilter{})
+		if err != nil {
+			t.Fatalf("Failed to get tasks: %v", err)
+		}
+		if len(tasks) != 0 {
+			t.Errorf("Expected no visible tasks, got %d", len(tasks))
+		}
+
+		trash, err := taskService.ListTrash(ctx)
+		if err != nil {
+			t.Fatalf("Failed to list trash: %v", err)
+		}
+		if len(trash) != 1 || trash[0].ID != id || trash[0].DeletedAt == nil {
+			t.Errorf("Expected the task in the trash, got %+v", trash)
+		}
+
+		trashed, err := taskService.GetTrashedTask(ctx, id.String())
+		if err != nil || trashed.ID != id || trashed.DeletedAt == nil {
+			t.Errorf("Expected the trashed task, got %+v (%v)", trashed, err)
+		}
+
+		if err := taskService.DeleteTask(ctx, id.String(), nil); err == nil {
+			t.Error("Expected error deleting a task twice, got nil")
+		}
+	})
+
+	t.Run("restore brings the task back", func(t *testing.T) {
+		restored, err := taskService.RestoreTask(ctx, id.String())
+		if err != nil {
+			t.Fatalf("Failed to restore task: %v", err)
+		}
+
+		if restored.DeletedAt != nil || restored.Key != "TASK-1" {
+			t.Errorf("Expected a live TASK-1, got %+v", restored)
+		}
+
+		if _, err := taskService.RestoreTask(ctx, id.String()); err == nil {
+			t.Error("Expected error restoring a task that is not in the trash, got nil")
+		}
+
+		if _, err := taskService.GetTrashedTask(ctx, id.String()); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Expected a live task not to be found in the trash, got %v", err)
+		}
+
+		events, err := taskService.GetTaskHistory(ctx, id.String())
+		if err != nil {
+			t.Fatalf("Failed to get history: %v", err)
+		}
+
+		last := events[len(events)-1]
+		if last.Action != domain.TaskEventRestored {
+			t.Errorf("Expected a restored event, got %s", last.Action)
+		}
+
+		current, err := taskService.GetTaskAt(ctx, id.String(), time.Now())
+		if err != nil || current.Title != "Draft announcement" || !current.CreatedAt.Equal(events[0].CreatedAt) {
+			t.Errorf("Expected the restored task with its original creation time, got %+v (%v)", current, err)
+		}
+	})
+
+	t.Run("retention purges tasks deleted long enough ago", func(t *testing.T) {
+		if err := taskService.DeleteTask(ctx, id.String(), nil); err != nil {
+			t.Fatalf("Failed to delete task: %v", err)
+		}
+
+		purged, err := taskService.PurgeDeletedTasks(ctx, time.Now().Add(-time.Hour))
+		if err != nil {
+			t.Fatalf("Failed to purge: %v", err)
+		}
+		if purged != 0 {
+			t.Errorf("Expected nothing to be purged yet, got %d", purged)
+		}
+
+		purged, err = taskService.PurgeDeletedTasks(ctx, time.Now())
+		if err != nil {
+			t.Fatalf("Failed to purge: %v", err)
+		}
+		if purged != 1 {
+			t.Errorf("Expected 1 purged task, got %d", purged)
+		}
+
+		if _, err := taskService.RestoreTask(ctx, id.String()); err == nil {
+			t.Error("Expected purged task to be gone, got nil error")
+		}
+
+		events, err := taskService.GetTaskHistory(ctx, id.String())
+		if err != nil {
+			t.Fatalf("Failed to get history: %v", err)
+		}
+
+		last := events[len(events)-1]
+		if last.Action != domain.TaskEventPurged || last.Actor != retentionActor {
+			t.Errorf("Expected a purge by %s, got %+v", retentionActor, last)
+		}
+	})
+
+	t.Run("live tasks can be purged on demand", func(t *testing.T) {
+		live, err := taskService.CreateTask(ctx, &domain.CreateTaskRequest{Title: "Leaked credentials"})
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+
+		if err := taskService.PurgeTask(ctx, live.String()); err != nil {
+			t.Fatalf("Failed to purge task: %v", err)
+		}
+
+		trash, err := taskService.ListTrash(ctx)
+		if err != nil {
+			t.Fatalf("Failed to list trash: %v", err)
+		}
+		if len(trash) != 0 {
+			t.Errorf("Expected an empty trash, got %d task(s)", len(trash))
+		}
+
+		if err := taskService.PurgeTask(ctx, live.String()); err == nil {
+			t.Error("Expected error purging a task twice, got nil")
+		}
+	})
+}
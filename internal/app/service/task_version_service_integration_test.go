@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/storage"
+)
+
+func TestTaskVersion_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, err := sqlite.NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+
+	logger := log.New(os.Stderr, "INTEGRATION_TEST: ", log.LstdFlags)
+	taskService := NewTaskService(logger, db, blobs, domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	id, err := taskService.CreateTask(ctx, &domain.CreateTaskRequest{Title: "Rotate keys"})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	t.Run("every write increments the version", func(t *testing.T) {
+		task, err := taskService.GetTask(ctx, id.String())
+		if err != nil {
+			t.Fatalf("Failed to get task: %v", err)
+		}
+		if task.Version != 1 {
+			t.Errorf("Expected version 1, got %d", task.Version)
+		}
+
+		title := "Rotate signing keys"
+		if err := taskService.UpdateTask(ctx, id.String(), &domain.UpdateTaskRequest{Title: &title}, []int64{1}); err != nil {
+			t.Fatalf("Failed to update task: %v", err)
+		}
+
+		task, err = taskService.GetTask(ctx, id.String())
+		if err != nil {
+			t.Fatalf("Failed to get task: %v", err)
+		}
+		if task.Version != 2 {
+			t.Errorf("Expected version 2, got %d", task.Version)
+		}
+	})
+
+	t.Run("stale versions are rejected", func(t *testing.T) {
+		title := "Lost update"
+		err := taskService.UpdateTask(ctx, id.String(), &domain.UpdateTaskRequest{Title: &title}, []int64{1})
+		if !errors.Is(err, domain.ErrVersionMismatch) {
+			t.Fatalf("Expected version mismatch, got %v", err)
+		}
+
+		task, err := taskService.GetTask(ctx, id.String())
+		if err != nil {
+			t.Fatalf("Failed to get task: %v", err)
+		}
+		if task.Title != "Rotate signing keys" {
+			t.Errorf("Expected the rejected update not to apply, got title %q", task.Title)
+		}
+
+		if err := taskService.DeleteTask(ctx, id.String(), []int64{1}); !errors.Is(err, domain.ErrVersionMismatch) {
+			t.Errorf("Expected version mismatch on delete, got %v", err)
+		}
+	})
+
+	t.Run("any listed version matches", func(t *testing.T) {
+		if err := taskService.DeleteTask(ctx, id.String(), []int64{1, 2}); err != nil {
+			t.Fatalf("Failed to delete task: %v", err)
+		}
+
+		restored, err := taskService.RestoreTask(ctx, id.String())
+		if err != nil {
+			t.Fatalf("Failed to restore task: %v", err)
+		}
+		if restored.Version != 4 {
+			t.Errorf("Expected version 4 after delete and restore, got %d", restored.Version)
+		}
+	})
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"encoding/json"
+
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+)
+
+func (s *TaskService) GetWorkflow() domain.Workflow {
+	return s.workflow
+}
+
+// doneStatuses encodes the done statuses of the workflow as the JSON array
+// queries take them as.
+func (s *TaskService) doneStatuses() string {
+	// Encoding a slice of strings cannot fail.
+	data, _ := json.Marshal(s.workflow.DoneStatuses)
+	return string(data)
+}
+
+// checkTransition enforces the workflow for a status change, returning a
+// *domain.TransitionError that lists the permitted targets when it is refused.
+func (s *TaskService) checkTransition(from domain.TaskStatus, to domain.TaskStatus, comment string) error {
+	transition, ok := s.workflow.Transition(from, to)
+	if !ok {
+		return &domain.TransitionError{
+			From:    from,
+			To:      to,
+			Allowed: s.workflow.AllowedFrom(from),
+			Reason:  "no such transition in the workflow",
+		}
+	}
+
+	for _, guard := range transition.Guards {
+		if guard == domain.WorkflowGuardCommentRequired && comment == "" {
+			return &domain.TransitionError{
+				From:    from,
+				To:      to,
+				Allowed: s.workflow.AllowedFrom(from),
+				Reason:  "a comment is required",
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/storage"
+)
+
+func TestTaskService_Workflow_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, err := sqlite.NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+
+	workflow, err := domain.ParseWorkflow([]byte(`{
+		"InitialStatus": "to_do",
+		"Statuses": ["to_do", "in_progress", "review", "done"],
+		"DoneStatuses": ["done"],
+		"StartedStatuses": ["in_progress"],
+		"Transitions": [
+			{"From": "to_do", "To": "in_progress"},
+			{"From": "in_progress", "To": "review"},
+			{"From": "review", "To": "done"},
+			{"From": "done", "To": "to_do", "Guards": ["comment_required"]}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("Failed to parse workflow: %v", err)
+	}
+
+	logger := log.New(os.Stderr, "INTEGRATION_TEST: ", log.LstdFlags)
+	service := NewTaskService(logger, db, blobs, workflow)
+	commentService := NewCommentService(logger, db)
+	ctx := domain.WithActor(context.Background(), "client-reviewer")
+
+	taskID, err := service.CreateTask(ctx, &domain.CreateTaskRequest{Title: "Ship release"})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	setStatus := func(status domain.TaskStatus, comment *string) error {
+		return service.UpdateTask(ctx, taskID.String(), &domain.UpdateTaskRequest{Status: &status, Comment: comment}, nil)
+	}
+
+	t.Run("skipping a step is refused", func(t *testing.T) {
+		err := setStatus(domain.TaskStatusDone, nil)
+
+		var transitionErr *domain.TransitionError
+		if !errors.As(err, &transitionErr) {
+			t.Fatalf("Expected transition error, got %v", err)
+		}
+
+		if len(transitionErr.Allowed) != 1 || transitionErr.Allowed[0] != domain.TaskStatusInProgress {
+			t.Errorf("Expected allowed [in_progress], got %v", transitionErr.Allowed)
+		}
+	})
+
+	t.Run("custom status is accepted", func(t *testing.T) {
+		for _, status := range []domain.TaskStatus{domain.TaskStatusInProgress, "review", domain.TaskStatusDone} {
+			if err := setStatus(status, nil); err != nil {
+				t.Fatalf("Failed to move task to %s: %v", status, err)
+			}
+		}
+
+		task, err := service.GetTask(ctx, taskID.String())
+		if err != nil {
+			t.Fatalf("Failed to get task: %v", err)
+		}
+
+		if task.Status != domain.TaskStatusDone {
+			t.Errorf("Expected status done, got %s", task.Status)
+		}
+	})
+
+	t.Run("unknown status is rejected", func(t *testing.T) {
+		err := setStatus("archived", nil)
+		if err == nil {
+			t.Fatal("Expected error for unknown status, got nil")
+		}
+
+		expectedError := "update task: invalid status"
+		if err.Error() != expectedError {
+			t.Errorf("Expected error %q, got %q", expectedError, err.Error())
+		}
+	})
+
+	t.Run("reopening requires a comment", func(t *testing.T) {
+		err := setStatus(domain.TaskStatusToDo, nil)
+
+		var transitionErr *domain.TransitionError
+		if !errors.As(err, &transitionErr) {
+			t.Fatalf("Expected transition error, got %v", err)
+		}
+
+		if transitionErr.Reason != "a comment is required" {
+			t.Errorf("Expected comment guard to fail, got %q", transitionErr.Reason)
+		}
+
+		comment := "Regression found in staging"
+		if err := setStatus(domain.TaskStatusToDo, &comment); err != nil {
+			t.Fatalf("Failed to reopen task: %v", err)
+		}
+
+		page, err := commentService.ListComments(ctx, taskID.String(), 0, 0)
+		if err != nil {
+			t.Fatalf("Failed to list comments: %v", err)
+		}
+
+		if len(page.Comments) != 1 || page.Comments[0].Body != comment || page.Comments[0].Author != "client-reviewer" {
+			t.Errorf("Expected the transition comment to be stored, got %+v", page.Comments)
+		}
+	})
+
+	t.Run("done and started statuses are validated", func(t *testing.T) {
+		definitions := map[string]string{
+			"no done status":         `{"InitialStatus": "open", "Statuses": ["open", "closed"]}`,
+			"unknown done status":    `{"InitialStatus": "open", "Statuses": ["open", "closed"], "DoneStatuses": ["done"]}`,
+			"unknown started status": `{"InitialStatus": "open", "Statuses": ["open", "closed"], "DoneStatuses": ["closed"], "StartedStatuses": ["doing"]}`,
+			"started and done":       `{"InitialStatus": "open", "Statuses": ["open", "closed"], "DoneStatuses": ["closed"], "StartedStatuses": ["closed"]}`,
+		}
+
+		for name, definition := range definitions {
+			if _, err := domain.ParseWorkflow([]byte(definition)); err == nil {
+				t.Errorf("Expected a workflow with %s to be refused", name)
+			}
+		}
+	})
+}
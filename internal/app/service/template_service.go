@@ -0,0 +1,454 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite/sqlc"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	maxTemplateNameLength = 100
+	maxTemplateTasks      = 200
+	// maxTemplateDepth limits how deep subtasks nest; top-level tasks are at
+	// depth 1.
+	maxTemplateDepth = 5
+)
+
+// templatePlaceholder matches a {{variable}} placeholder, allowing spaces
+// inside the braces.
+var templatePlaceholder = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// TemplateService keeps task templates and creates tasks from them.
+type TemplateService struct {
+	logger *log.Logger
+	db     *sqlite.Database
+	tasks  *TaskService
+}
+
+func NewTemplateService(logger *log.Logger, db *sqlite.Database, tasks *TaskService) *TemplateService {
+	return &TemplateService{
+		logger: logger,
+		db:     db,
+		tasks:  tasks,
+	}
+}
+
+// CreateTemplate saves a template.
+func (s *TemplateService) CreateTemplate(ctx context.Context, req *domain.CreateTemplateRequest) (domain.Template, error) {
+	name, err := validateTemplateName(req.Name)
+	if err != nil {
+		return domain.Template{}, fmt.Errorf("create template: %w", err)
+	}
+
+	if err := s.validateTemplateTasks(req.Tasks); err != nil {
+		return domain.Template{}, fmt.Errorf("create template: %w", err)
+	}
+
+	data, err := json.Marshal(req.Tasks)
+	if err != nil {
+		return domain.Template{}, fmt.Errorf("create template: %w", err)
+	}
+
+	now := time.Now().UTC()
+	template := sqlc.TaskTemplate{
+		ID:          uuid.New().String(),
+		Name:        name,
+		Description: sql.NullString{String: req.Description, Valid: req.Description != ""},
+		Definition:  string(data),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := s.db.Queries.CreateTaskTemplate(ctx, sqlc.CreateTaskTemplateParams(template)); err != nil {
+		if sqlite.IsUniqueViolation(err) {
+			return domain.Template{}, fmt.Errorf("create template: %w", domain.Conflict("a template named %q already exists", name))
+		}
+		return domain.Template{}, fmt.Errorf("create template: %w", err)
+	}
+
+	return templateToDomain(template)
+}
+
+// ListTemplates returns every template, by name.
+func (s *TemplateService) ListTemplates(ctx context.Context) ([]domain.Template, error) {
+	templates, err := s.db.Queries.ListTaskTemplates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list templates: %w", err)
+	}
+
+	result := make([]domain.Template, 0, len(templates))
+	for _, template := range templates {
+		domainTemplate, err := templateToDomain(template)
+		if err != nil {
+			return nil, fmt.Errorf("list templates: %w", err)
+		}
+		result = append(result, domainTemplate)
+	}
+
+	return result, nil
+}
+
+func (s *TemplateService) GetTemplate(ctx context.Context, id string) (domain.Template, error) {
+	template, err := getTemplate(ctx, s.db.Queries, id)
+	if err != nil {
+		return domain.Template{}, fmt.Errorf("get template: %w", err)
+	}
+
+	return templateToDomain(template)
+}
+
+// UpdateTemplate changes a template. Tasks already created from it are left
+// as they are.
+func (s *TemplateService) UpdateTemplate(ctx context.Context, id string, req *domain.UpdateTemplateRequest) (domain.Template, error) {
+	var updated sqlc.TaskTemplate
+	err := s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		template, err := getTemplate(ctx, q, id)
+		if err != nil {
+			return err
+		}
+
+		if req.Name != nil {
+			if template.Name, err = validateTemplateName(*req.Name); err != nil {
+				return err
+			}
+		}
+
+		if req.Description != nil {
+			template.Description = sql.NullString{String: *req.Description, Valid: *req.Description != ""}
+		}
+
+		if req.Tasks != nil {
+			if err := s.validateTemplateTasks(*req.Tasks); err != nil {
+				return err
+			}
+
+			data, err := json.Marshal(*req.Tasks)
+			if err != nil {
+				return err
+			}
+			template.Definition = string(data)
+		}
+
+		template.UpdatedAt = time.Now().UTC()
+		err = q.UpdateTaskTemplate(ctx, sqlc.UpdateTaskTemplateParams{
+			Name:        template.Name,
+			Description: template.Description,
+			Definition:  template.Definition,
+			UpdatedAt:   template.UpdatedAt,
+			ID:          template.ID,
+		})
+		if err != nil {
+			if sqlite.IsUniqueViolation(err) {
+				return domain.Conflict("a template named %q already exists", template.Name)
+			}
+			return err
+		}
+
+		updated = template
+		return nil
+	})
+	if err != nil {
+		return domain.Template{}, fmt.Errorf("update template: %w", err)
+	}
+
+	return templateToDomain(updated)
+}
+
+// DeleteTemplate removes a template. Tasks created from it remain, no longer
+// linked to it.
+func (s *TemplateService) DeleteTemplate(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("delete template: %w", domain.Invalid("id", "id is required"))
+	}
+
+	rows, err := s.db.Queries.DeleteTaskTemplate(ctx, id)
+	if err != nil {
+		return fmt.Errorf("delete template: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("delete template: %w", domain.NotFound("template not found"))
+	}
+
+	return nil
+}
+
+// InstantiateTemplate creates the tasks of a template in one transaction:
+// either all of them are created or none. Placeholders are replaced with the
+// given variables, which must cover every placeholder and nothing else, and
+// due offsets count from req.StartAt. Every task records the template it
+// came from.
+func (s *TemplateService) InstantiateTemplate(ctx context.Context, id string, req *domain.InstantiateTemplateRequest) (domain.TemplateInstance, error) {
+	start := time.Now().UTC()
+	if req.StartAt != nil {
+		start = req.StartAt.UTC()
+	}
+
+	var instance domain.TemplateInstance
+	err := s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		template, err := getTemplate(ctx, q, id)
+		if err != nil {
+			return err
+		}
+
+		var tasks []domain.TemplateTask
+		if err := json.Unmarshal([]byte(template.Definition), &tasks); err != nil {
+			return fmt.Errorf("template %s: %w", template.ID, err)
+		}
+
+		variables := templateVariables(tasks)
+		for _, name := range variables {
+			if _, ok := req.Variables[name]; !ok {
+				return domain.Invalid("Variables", "no value for {{%s}}", name)
+			}
+		}
+		for name := range req.Variables {
+			if !slices.Contains(variables, name) {
+				return domain.Invalid("Variables", "the template has no {{%s}} placeholder", name)
+			}
+		}
+
+		instance = domain.TemplateInstance{TemplateID: uuid.MustParse(template.ID), TaskIDs: []uuid.UUID{}}
+		templateID := sql.NullString{String: template.ID, Valid: true}
+
+		// create creates tasks, each followed by its subtasks, as subtasks
+		// of parentID when it is set. path locates the tasks in the template
+		// for error messages.
+		var create func(tasks []domain.TemplateTask, parentID string, path string) error
+		create = func(tasks []domain.TemplateTask, parentID string, path string) error {
+			for i, task := range tasks {
+				taskPath := fmt.Sprintf("%s[%d]", path, i)
+
+				taskReq, err := templateTaskRequest(task, req, start)
+				if err != nil {
+					return fmt.Errorf("%s: %w", taskPath, err)
+				}
+				taskReq.ParentID = parentID
+
+				id, err := s.tasks.insertTask(ctx, q, taskReq, templateID)
+				if err != nil {
+					return fmt.Errorf("%s: %w", taskPath, err)
+				}
+				instance.TaskIDs = append(instance.TaskIDs, id)
+
+				if err := create(task.Subtasks, id.String(), taskPath+".Subtasks"); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		return create(tasks, "", "Tasks")
+	})
+	if err != nil {
+		return domain.TemplateInstance{}, fmt.Errorf("instantiate template: %w", err)
+	}
+
+	return instance, nil
+}
+
+// templateTaskRequest fills in the placeholders and due offset of a template
+// task.
+func templateTaskRequest(task domain.TemplateTask, req *domain.InstantiateTemplateRequest, start time.Time) (*domain.CreateTaskRequest, error) {
+	create := &domain.CreateTaskRequest{
+		ProjectID:   req.ProjectID,
+		Title:       fillPlaceholders(task.Title, req.Variables),
+		Description: fillPlaceholders(task.Description, req.Variables),
+		Status:      task.Status,
+		Priority:    task.Priority,
+		Assignees:   make([]string, len(task.Assignees)),
+		Tags:        make([]string, len(task.Tags)),
+		Estimate:    task.Estimate,
+	}
+
+	for i, assignee := range task.Assignees {
+		create.Assignees[i] = fillPlaceholders(assignee, req.Variables)
+	}
+
+	for i, tag := range task.Tags {
+		create.Tags[i] = fillPlaceholders(tag, req.Variables)
+	}
+
+	if task.DueOffset != "" {
+		days, duration, err := parseDueOffset(task.DueOffset)
+		if err != nil {
+			return nil, err
+		}
+
+		dueAt := start.AddDate(0, 0, days).Add(duration)
+		create.DueAt = &dueAt
+	}
+
+	return create, nil
+}
+
+// validateTemplateTasks checks what can be checked before placeholders are
+// filled in. Assignees and tags are checked when the tasks are created.
+func (s *TemplateService) validateTemplateTasks(tasks []domain.TemplateTask) error {
+	if len(tasks) == 0 {
+		return domain.Invalid("Tasks", "a template needs at least one task")
+	}
+
+	count := 0
+	return s.validateTemplateLevel(tasks, "Tasks", 1, &count)
+}
+
+func (s *TemplateService) validateTemplateLevel(tasks []domain.TemplateTask, path string, depth int, count *int) error {
+	if depth > maxTemplateDepth {
+		return domain.Invalid(path, "subtasks nest at most %d levels deep", maxTemplateDepth)
+	}
+
+	for i, task := range tasks {
+		taskPath := fmt.Sprintf("%s[%d]", path, i)
+
+		*count++
+		if *count > maxTemplateTasks {
+			return domain.Invalid("Tasks", "a template holds at most %d tasks", maxTemplateTasks)
+		}
+
+		if strings.TrimSpace(task.Title) == "" {
+			return domain.Invalid(taskPath+".Title", "title is required")
+		}
+
+		if task.Status != "" && !s.tasks.workflow.HasStatus(task.Status) {
+			return domain.Invalid(taskPath+".Status", "invalid status")
+		}
+
+		if !task.Priority.IsValid() {
+			return domain.Invalid(taskPath+".Priority", "invalid priority")
+		}
+
+		if task.DueOffset != "" {
+			if _, _, err := parseDueOffset(task.DueOffset); err != nil {
+				return fmt.Errorf("%s: %w", taskPath, err)
+			}
+		}
+
+		if task.Estimate != nil && *task.Estimate < 0 {
+			return domain.Invalid(taskPath+".Estimate", "estimate must not be negative")
+		}
+
+		texts := append([]string{task.Title, task.Description}, task.Assignees...)
+		for _, text := range append(texts, task.Tags...) {
+			if strings.Contains(templatePlaceholder.ReplaceAllString(text, ""), "{{") {
+				return domain.Invalid(taskPath, "malformed placeholder in %q, use {{name}} with letters, digits and underscores", text)
+			}
+		}
+
+		if err := s.validateTemplateLevel(task.Subtasks, taskPath+".Subtasks", depth+1, count); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseDueOffset reads a due offset: whole days such as "3d", or a duration
+// such as "36h".
+func parseDueOffset(offset string) (int, time.Duration, error) {
+	if days, ok := strings.CutSuffix(offset, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, 0, domain.Invalid("DueOffset", "invalid due offset %q, use days such as \"3d\" or a duration such as \"36h\"", offset)
+		}
+		return n, 0, nil
+	}
+
+	duration, err := time.ParseDuration(offset)
+	if err != nil {
+		return 0, 0, domain.Invalid("DueOffset", "invalid due offset %q, use days such as \"3d\" or a duration such as \"36h\"", offset)
+	}
+
+	return 0, duration, nil
+}
+
+// templateVariables returns the names of the placeholders in tasks and their
+// subtasks, sorted.
+func templateVariables(tasks []domain.TemplateTask) []string {
+	names := []string{}
+
+	var collect func(tasks []domain.TemplateTask)
+	collect = func(tasks []domain.TemplateTask) {
+		for _, task := range tasks {
+			texts := append([]string{task.Title, task.Description}, task.Assignees...)
+			for _, text := range append(texts, task.Tags...) {
+				for _, match := range templatePlaceholder.FindAllStringSubmatch(text, -1) {
+					if !slices.Contains(names, match[1]) {
+						names = append(names, match[1])
+					}
+				}
+			}
+			collect(task.Subtasks)
+		}
+	}
+	collect(tasks)
+
+	slices.Sort(names)
+	return names
+}
+
+// fillPlaceholders replaces the placeholders in text with their values.
+func fillPlaceholders(text string, values map[string]string) string {
+	return templatePlaceholder.ReplaceAllStringFunc(text, func(placeholder string) string {
+		return values[templatePlaceholder.FindStringSubmatch(placeholder)[1]]
+	})
+}
+
+func getTemplate(ctx context.Context, q *sqlc.Queries, id string) (sqlc.TaskTemplate, error) {
+	if id == "" {
+		return sqlc.TaskTemplate{}, domain.Invalid("id", "id is required")
+	}
+
+	template, err := q.GetTaskTemplate(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sqlc.TaskTemplate{}, domain.NotFound("template not found")
+		}
+		return sqlc.TaskTemplate{}, err
+	}
+
+	return template, nil
+}
+
+func validateTemplateName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", domain.Invalid("Name", "name is required")
+	}
+
+	if len(name) > maxTemplateNameLength {
+		return "", domain.Invalid("Name", "name is longer than %d characters", maxTemplateNameLength)
+	}
+
+	return name, nil
+}
+
+func templateToDomain(template sqlc.TaskTemplate) (domain.Template, error) {
+	var tasks []domain.TemplateTask
+	if err := json.Unmarshal([]byte(template.Definition), &tasks); err != nil {
+		return domain.Template{}, fmt.Errorf("template %s: %w", template.ID, err)
+	}
+
+	return domain.Template{
+		ID:          uuid.MustParse(template.ID),
+		Name:        template.Name,
+		Description: template.Description.String,
+		Tasks:       tasks,
+		Variables:   templateVariables(tasks),
+		CreatedAt:   template.CreatedAt,
+		UpdatedAt:   template.UpdatedAt,
+	}, nil
+}
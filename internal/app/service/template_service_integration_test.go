@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/storage"
+)
+
+func TestTemplates_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, err := sqlite.NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+
+	logger := log.New(os.Stderr, "INTEGRATION_TEST: ", log.LstdFlags)
+	taskService := NewTaskService(logger, db, blobs, domain.DefaultWorkflow())
+	templateService := NewTemplateService(logger, db, taskService)
+	projectService := NewProjectService(logger, db)
+	ctx := domain.WithActor(context.Background(), "alice")
+
+	project, err := projectService.CreateProject(ctx, &domain.CreateProjectRequest{Key: "PART", Name: "Partners"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	template, err := templateService.CreateTemplate(ctx, &domain.CreateTemplateRequest{
+		Name: "Partner onboarding",
+		Tasks: []domain.TemplateTask{
+			{
+				Title:     "Onboard {{partner_name}}",
+				Priority:  domain.TaskPriorityHigh,
+				Assignees: []string{"{{ manager }}"},
+				Tags:      []string{"onboarding", "partner-{{partner_name}}"},
+				DueOffset: "14d",
+				Subtasks: []domain.TemplateTask{
+					{Title: "Sign contract with {{partner_name}}", DueOffset: "3d"},
+					{Title: "Set up accounts", DueOffset: "36h", Subtasks: []domain.TemplateTask{
+						{Title: "Create SSO login for {{partner_name}}"},
+					}},
+				},
+			},
+			{Title: "Send welcome pack", Description: "Address it to {{partner_name}}"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create template: %v", err)
+	}
+
+	t.Run("templates list their variables", func(t *testing.T) {
+		if !slices.Equal(template.Variables, []string{"manager", "partner_name"}) {
+			t.Errorf("Expected variables manager and partner_name, got %v", template.Variables)
+		}
+
+		got, err := templateService.GetTemplate(ctx, template.ID.String())
+		if err != nil {
+			t.Fatalf("Failed to get template: %v", err)
+		}
+		if len(got.Tasks) != 2 || len(got.Tasks[0].Subtasks) != 2 || len(got.Tasks[0].Subtasks[1].Subtasks) != 1 {
+			t.Errorf("Expected the tasks to be kept with their subtasks, got %+v", got.Tasks)
+		}
+	})
+
+	t.Run("instantiating creates every task with its subtasks", func(t *testing.T) {
+		start := time.Date(2025, time.March, 3, 9, 0, 0, 0, time.UTC)
+		instance, err := templateService.InstantiateTemplate(ctx, template.ID.String(), &domain.InstantiateTemplateRequest{
+			ProjectID: project.ID.String(),
+			Variables: map[string]string{"partner_name": "Acme", "manager": "bob"},
+			StartAt:   &start,
+		})
+		if err != nil {
+			t.Fatalf("Failed to instantiate template: %v", err)
+		}
+
+		if instance.TemplateID != template.ID || len(instance.TaskIDs) != 5 {
+			t.Fatalf("Expected five tasks from the template, got %+v", instance)
+		}
+
+		tasks := make([]domain.Task, len(instance.TaskIDs))
+		for i, id := range instance.TaskIDs {
+			if tasks[i], err = taskService.GetTask(ctx, id.String()); err != nil {
+				t.Fatalf("Failed to get task: %v", err)
+			}
+			if tasks[i].TemplateID == nil || *tasks[i].TemplateID != template.ID {
+				t.Errorf("Expected %q to record the template, got %v", tasks[i].Title, tasks[i].TemplateID)
+			}
+			if tasks[i].ProjectID != project.ID {
+				t.Errorf("Expected %q in the project, got %s", tasks[i].Title, tasks[i].ProjectID)
+			}
+		}
+
+		titles := []string{}
+		for _, task := range tasks {
+			titles = append(titles, task.Title)
+		}
+		expected := []string{"Onboard Acme", "Sign contract with Acme", "Set up accounts", "Create SSO login for Acme", "Send welcome pack"}
+		if !slices.Equal(titles, expected) {
+			t.Errorf("Expected tasks %v, got %v", expected, titles)
+		}
+
+		parents := []int{-1, 0, 0, 2, -1}
+		for i, parent := range parents {
+			switch {
+			case parent < 0 && tasks[i].ParentID != nil:
+				t.Errorf("Expected %q to be a top-level task, got parent %s", tasks[i].Title, tasks[i].ParentID)
+			case parent >= 0 && (tasks[i].ParentID == nil || *tasks[i].ParentID != tasks[parent].ID):
+				t.Errorf("Expected %q to be a subtask of %q, got %v", tasks[i].Title, tasks[parent].Title, tasks[i].ParentID)
+			}
+		}
+
+		root := tasks[0]
+		if !slices.Equal(root.Assignees, []string{"bob"}) || !slices.Equal(root.Tags, []string{"onboarding", "partner-acme"}) {
+			t.Errorf("Expected placeholders filled in assignees and tags, got %v and %v", root.Assignees, root.Tags)
+		}
+		if root.Priority != domain.TaskPriorityHigh || tasks[4].Description != "Address it to Acme" {
+			t.Errorf("Expected the fields of the template, got %+v and %+v", root, tasks[4])
+		}
+
+		// A zero time stands for no due date.
+		dues := []time.Time{start.AddDate(0, 0, 14), start.AddDate(0, 0, 3), start.Add(36 * time.Hour), {}, {}}
+		for i, due := range dues {
+			switch {
+			case due.IsZero() && tasks[i].DueAt != nil:
+				t.Errorf("Expected %q without a due date, got %v", tasks[i].Title, tasks[i].DueAt)
+			case !due.IsZero() && (tasks[i].DueAt == nil || !tasks[i].DueAt.Equal(due)):
+				t.Errorf("Expected %q due at %v, got %v", tasks[i].Title, due, tasks[i].DueAt)
+			}
+		}
+	})
+
+	t.Run("variables must match the placeholders", func(t *testing.T) {
+		requests := []map[string]string{
+			{"partner_name": "Acme"},
+			{"partner_name": "Acme", "manager": "bob", "region": "EU"},
+		}
+
+		for _, variables := range requests {
+			_, err := templateService.InstantiateTemplate(ctx, template.ID.String(), &domain.InstantiateTemplateRequest{Variables: variables})
+			if !errors.Is(err, domain.ErrValidation) {
+				t.Errorf("Expected %v to be refused, got %v", variables, err)
+			}
+		}
+	})
+
+	t.Run("a failing task creates none of them", func(t *testing.T) {
+		failing, err := templateService.CreateTemplate(ctx, &domain.CreateTemplateRequest{
+			Name: "Fails halfway",
+			Tasks: []domain.TemplateTask{
+				{Title: "Kickoff"},
+				{Title: "{{step}}"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Failed to create template: %v", err)
+		}
+
+		before, err := taskService.GetTasks(ctx, domain.TaskFilter{})
+		if err != nil {
+			t.Fatalf("Failed to get tasks: %v", err)
+		}
+
+		_, err = templateService.InstantiateTemplate(ctx, failing.ID.String(), &domain.InstantiateTemplateRequest{Variables: map[string]string{"step": ""}})
+		if !errors.Is(err, domain.ErrValidation) {
+			t.Fatalf("Expected the empty title to be refused, got %v", err)
+		}
+
+		after, err := taskService.GetTasks(ctx, domain.TaskFilter{})
+		if err != nil {
+			t.Fatalf("Failed to get tasks: %v", err)
+		}
+		if len(after) != len(before) {
+			t.Errorf("Expected no task to be created, got %d more", len(after)-len(before))
+		}
+	})
+
+	t.Run("invalid templates are refused", func(t *testing.T) {
+		requests := map[string]domain.CreateTemplateRequest{
+			"no tasks":              {Name: "Empty"},
+			"no title":              {Name: "Untitled", Tasks: []domain.TemplateTask{{Title: " "}}},
+			"malformed placeholder": {Name: "Malformed", Tasks: []domain.TemplateTask{{Title: "Call {{partner name}}"}}},
+			"invalid offset":        {Name: "Offset", Tasks: []domain.TemplateTask{{Title: "Call", Subtasks: []domain.TemplateTask{{Title: "Dial", DueOffset: "soon"}}}}},
+			"invalid status":        {Name: "Status", Tasks: []domain.TemplateTask{{Title: "Call", Status: "archived"}}},
+		}
+
+		for name, req := range requests {
+			if _, err := templateService.CreateTemplate(ctx, &req); !errors.Is(err, domain.ErrValidation) {
+				t.Errorf("Expected a template with %s to be refused, got %v", name, err)
+			}
+		}
+
+		_, err := templateService.CreateTemplate(ctx, &domain.CreateTemplateRequest{Name: "Partner onboarding", Tasks: []domain.TemplateTask{{Title: "Call"}}})
+		if !errors.Is(err, domain.ErrConflict) {
+			t.Errorf("Expected a duplicate name to conflict, got %v", err)
+		}
+	})
+
+	t.Run("subtasks stay in the project of their parent", func(t *testing.T) {
+		parent, err := taskService.CreateTask(ctx, &domain.CreateTaskRequest{Title: "Audit", ProjectID: project.ID.String()})
+		if err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+
+		id, err := taskService.CreateTask(ctx, &domain.CreateTaskRequest{Title: "Audit logs", ParentID: parent.String()})
+		if err != nil {
+			t.Fatalf("Failed to create subtask: %v", err)
+		}
+
+		subtask, err := taskService.GetTask(ctx, id.String())
+		if err != nil {
+			t.Fatalf("Failed to get subtask: %v", err)
+		}
+		if subtask.ProjectID != project.ID || subtask.ParentID == nil || *subtask.ParentID != parent {
+			t.Errorf("Expected a subtask in the parent's project, got %+v", subtask)
+		}
+
+		_, err = taskService.CreateTask(ctx, &domain.CreateTaskRequest{Title: "Elsewhere", ParentID: parent.String(), ProjectID: domain.DefaultProjectID.String()})
+		if !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("Expected a subtask in another project to be refused, got %v", err)
+		}
+	})
+
+	t.Run("deleting a template keeps its tasks", func(t *testing.T) {
+		instance, err := templateService.InstantiateTemplate(ctx, template.ID.String(), &domain.InstantiateTemplateRequest{
+			Variables: map[string]string{"partner_name": "Globex", "manager": "carol"},
+		})
+		if err != nil {
+			t.Fatalf("Failed to instantiate template: %v", err)
+		}
+
+		if err := templateService.DeleteTemplate(ctx, template.ID.String()); err != nil {
+			t.Fatalf("Failed to delete template: %v", err)
+		}
+
+		task, err := taskService.GetTask(ctx, instance.TaskIDs[1].String())
+		if err != nil {
+			t.Fatalf("Expected the task to remain, got %v", err)
+		}
+		if task.TemplateID != nil || task.ParentID == nil || *task.ParentID != instance.TaskIDs[0] {
+			t.Errorf("Expected the subtask to lose its template but keep its parent, got %+v", task)
+		}
+
+		_, err = templateService.GetTemplate(ctx, template.ID.String())
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Expected the template to be gone, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,522 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite/sqlc"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultViewPageSize = 50
+	maxViewPageSize     = 200
+	maxViewNameLength   = 100
+)
+
+// ViewService keeps the named task filters clients save, and runs them.
+type ViewService struct {
+	logger   *log.Logger
+	db       *sqlite.Database
+	workflow domain.Workflow
+}
+
+func NewViewService(logger *log.Logger, db *sqlite.Database, workflow domain.Workflow) *ViewService {
+	return &ViewService{
+		logger:   logger,
+		db:       db,
+		workflow: workflow,
+	}
+}
+
+// viewDefinition is what a view selects, stored as JSON with the view and
+// copied into the cursors of its pages.
+type viewDefinition struct {
+	Filter  domain.ViewFilter
+	Sort    []domain.TaskSort
+	Columns []string
+}
+
+// viewCursor continues running a view after the last task of a page. It
+// carries the definition the first page ran with, so that every page of a
+// listing uses the same filter and sort even when the view is edited.
+type viewCursor struct {
+	View       string
+	Definition viewDefinition
+	Keys       []string
+	ID         string
+}
+
+// CreateView saves a view owned by the given client.
+func (s *ViewService) CreateView(ctx context.Context, owner string, req *domain.CreateViewRequest) (domain.View, error) {
+	name, err := validateViewName(req.Name)
+	if err != nil {
+		return domain.View{}, fmt.Errorf("create view: %w", err)
+	}
+
+	definition := viewDefinition{Filter: req.Filter, Sort: req.Sort, Columns: req.Columns}
+	if err := s.normalizeDefinition(&definition); err != nil {
+		return domain.View{}, fmt.Errorf("create view: %w", err)
+	}
+
+	data, err := json.Marshal(definition)
+	if err != nil {
+		return domain.View{}, fmt.Errorf("create view: %w", err)
+	}
+
+	now := time.Now().UTC()
+	view := sqlc.SavedView{
+		ID:         uuid.New().String(),
+		Owner:      owner,
+		Name:       name,
+		Shared:     req.Shared,
+		Definition: string(data),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := s.db.Queries.CreateSavedView(ctx, sqlc.CreateSavedViewParams(view)); err != nil {
+		if sqlite.IsUniqueViolation(err) {
+			return domain.View{}, fmt.Errorf("create view: %w", domain.Conflict("you already have a view named %q", name))
+		}
+		return domain.View{}, fmt.Errorf("create view: %w", err)
+	}
+
+	return viewToDomain(view)
+}
+
+// ListViews returns the views of a client and the views shared with every
+// client, by name.
+func (s *ViewService) ListViews(ctx context.Context, client string) ([]domain.View, error) {
+	views, err := s.db.Queries.ListSavedViews(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("list views: %w", err)
+	}
+
+	result := make([]domain.View, 0, len(views))
+	for _, view := range views {
+		domainView, err := viewToDomain(view)
+		if err != nil {
+			return nil, fmt.Errorf("list views: %w", err)
+		}
+		result = append(result, domainView)
+	}
+
+	return result, nil
+}
+
+// GetView returns a view the client owns or that is shared. Views of other
+// clients that are not shared are reported as not found.
+func (s *ViewService) GetView(ctx context.Context, id string, client string) (domain.View, error) {
+	view, err := getVisibleView(ctx, s.db.Queries, id, client)
+	if err != nil {
+		return domain.View{}, fmt.Errorf("get view: %w", err)
+	}
+
+	return viewToDomain(view)
+}
+
+// UpdateView changes a view. Only its owner may change it. Cursors handed out
+// before the change keep running the old definition.
+func (s *ViewService) UpdateView(ctx context.Context, id string, client string, req *domain.UpdateViewRequest) (domain.View, error) {
+	var updated sqlc.SavedView
+	err := s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		view, err := getVisibleView(ctx, q, id, client)
+		if err != nil {
+			return err
+		}
+
+		if view.Owner != client {
+			return domain.Forbidden("only the owner can change a view")
+		}
+
+		var definition viewDefinition
+		if err := json.Unmarshal([]byte(view.Definition), &definition); err != nil {
+			return err
+		}
+
+		if req.Name != nil {
+			if view.Name, err = validateViewName(*req.Name); err != nil {
+				return err
+			}
+		}
+
+		if req.Shared != nil {
+			view.Shared = *req.Shared
+		}
+
+		if req.Filter != nil {
+			definition.Filter = *req.Filter
+		}
+
+		if req.Sort != nil {
+			definition.Sort = *req.Sort
+		}
+
+		if req.Columns != nil {
+			definition.Columns = *req.Columns
+		}
+
+		if err := s.normalizeDefinition(&definition); err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(definition)
+		if err != nil {
+			return err
+		}
+
+		view.Definition = string(data)
+		view.UpdatedAt = time.Now().UTC()
+		err = q.UpdateSavedView(ctx, sqlc.UpdateSavedViewParams{
+			Name:       view.Name,
+			Shared:     view.Shared,
+			Definition: view.Definition,
+			UpdatedAt:  view.UpdatedAt,
+			ID:         view.ID,
+		})
+		if err != nil {
+			if sqlite.IsUniqueViolation(err) {
+				return domain.Conflict("you already have a view named %q", view.Name)
+			}
+			return err
+		}
+
+		updated = view
+		return nil
+	})
+	if err != nil {
+		return domain.View{}, fmt.Errorf("update view: %w", err)
+	}
+
+	return viewToDomain(updated)
+}
+
+// DeleteView removes a view. Only its owner may remove it.
+func (s *ViewService) DeleteView(ctx context.Context, id string, client string) error {
+	err := s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		view, err := getVisibleView(ctx, q, id, client)
+		if err != nil {
+			return err
+		}
+
+		if view.Owner != client {
+			return domain.Forbidden("only the owner can delete a view")
+		}
+
+		_, err = q.DeleteSavedView(ctx, view.ID)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("delete view: %w", err)
+	}
+
+	return nil
+}
+
+// RunView returns a page of the tasks a view selects, in its sort order. An
+// empty cursor starts at the first task; otherwise the listing continues
+// after the page the cursor was handed out with, using the definition the
+// listing started with. "me" in the filter stands for the client.
+func (s *ViewService) RunView(ctx context.Context, id string, client string, cursor string, limit int) (domain.ViewPage, error) {
+	if limit <= 0 {
+		limit = defaultViewPageSize
+	}
+
+	if limit > maxViewPageSize {
+		limit = maxViewPageSize
+	}
+
+	view, err := getVisibleView(ctx, s.db.Queries, id, client)
+	if err != nil {
+		return domain.ViewPage{}, fmt.Errorf("run view: %w", err)
+	}
+
+	var position viewCursor
+	if cursor == "" {
+		if err := json.Unmarshal([]byte(view.Definition), &position.Definition); err != nil {
+			return domain.ViewPage{}, fmt.Errorf("run view: %w", err)
+		}
+		if position.Definition.Filter.Assignee == "me" {
+			position.Definition.Filter.Assignee = client
+		}
+	} else {
+		position, err = s.decodeViewCursor(cursor, view.ID)
+		if err != nil {
+			return domain.ViewPage{}, fmt.Errorf("run view: %w", err)
+		}
+	}
+
+	query := taskQuery(position.Definition).Limit(limit + 1)
+	if position.ID != "" {
+		query.After(position.Keys, position.ID)
+	}
+
+	rows, err := s.db.QueryTasks(ctx, query)
+	if err != nil {
+		return domain.ViewPage{}, fmt.Errorf("run view: %w", err)
+	}
+
+	page := domain.ViewPage{Tasks: []map[string]any{}}
+	if len(rows) > limit {
+		rows = rows[:limit]
+		last := rows[len(rows)-1]
+
+		next, err := encodeViewCursor(viewCursor{
+			View:       view.ID,
+			Definition: position.Definition,
+			Keys:       last.Keys,
+			ID:         last.ID,
+		})
+		if err != nil {
+			return domain.ViewPage{}, fmt.Errorf("run view: %w", err)
+		}
+		page.NextCursor = next
+	}
+
+	for _, row := range rows {
+		task, err := s.db.Queries.GetTask(ctx, row.ID)
+		if err != nil {
+			// Deleted between the listing and now.
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			return domain.ViewPage{}, fmt.Errorf("run view: %w", err)
+		}
+
+		domainTask, err := toDomainTask(ctx, s.db.Queries, task)
+		if err != nil {
+			return domain.ViewPage{}, fmt.Errorf("run view: %w", err)
+		}
+
+		columns, err := selectColumns(domainTask, position.Definition.Columns)
+		if err != nil {
+			return domain.ViewPage{}, fmt.Errorf("run view: %w", err)
+		}
+		page.Tasks = append(page.Tasks, columns)
+	}
+
+	return page, nil
+}
+
+// normalizeDefinition validates a view definition and brings it into the
+// form it is stored in.
+func (s *ViewService) normalizeDefinition(definition *viewDefinition) error {
+	filter := &definition.Filter
+	filter.ProjectID = strings.TrimSpace(filter.ProjectID)
+	filter.Assignee = strings.TrimSpace(filter.Assignee)
+	filter.Search = strings.TrimSpace(filter.Search)
+
+	tags, err := normalizeTags(filter.Tags)
+	if err != nil {
+		return err
+	}
+	filter.Tags = tags
+
+	for _, status := range filter.Statuses {
+		if !s.workflow.HasStatus(status) {
+			return domain.Invalid("Filter.Statuses", "invalid status %q", status)
+		}
+	}
+
+	for _, priority := range filter.Priorities {
+		if priority == "" || !priority.IsValid() {
+			return domain.Invalid("Filter.Priorities", "invalid priority %q", priority)
+		}
+	}
+
+	if filter.DueFrom != nil && filter.DueBefore != nil && !filter.DueFrom.Before(*filter.DueBefore) {
+		return domain.Invalid("Filter.DueBefore", "due before must be after due from")
+	}
+
+	if len(definition.Sort) == 0 {
+		definition.Sort = domain.DefaultTaskSort
+	}
+
+	seen := make(map[domain.TaskSortField]bool, len(definition.Sort))
+	for _, sort := range definition.Sort {
+		if !sort.Field.IsValid() {
+			return domain.Invalid("Sort", "cannot sort by %q", sort.Field)
+		}
+		if seen[sort.Field] {
+			return domain.Invalid("Sort", "%s is sorted by more than once", sort.Field)
+		}
+		seen[sort.Field] = true
+	}
+
+	columns := make([]string, 0, len(definition.Columns))
+	for _, column := range definition.Columns {
+		if !slices.Contains(domain.ViewColumns, column) {
+			return domain.Invalid("Columns", "unknown column %q", column)
+		}
+		if !slices.Contains(columns, column) {
+			columns = append(columns, column)
+		}
+	}
+	definition.Columns = columns
+
+	return nil
+}
+
+// taskQuery builds the query that lists the tasks of a view definition.
+func taskQuery(definition viewDefinition) *sqlite.TaskQuery {
+	filter := definition.Filter
+	query := sqlite.NewTaskQuery().OrderBy(definition.Sort...)
+
+	if filter.ProjectID != "" {
+		query.InProject(filter.ProjectID)
+	}
+
+	if filter.Assignee != "" {
+		query.AssignedTo(filter.Assignee)
+	}
+
+	for _, tag := range filter.Tags {
+		query.Tagged(tag)
+	}
+
+	if len(filter.Statuses) > 0 {
+		query.StatusIn(filter.Statuses...)
+	}
+
+	if len(filter.Priorities) > 0 {
+		query.PriorityIn(filter.Priorities...)
+	}
+
+	if filter.DueFrom != nil {
+		query.DueFrom(*filter.DueFrom)
+	}
+
+	if filter.DueBefore != nil {
+		query.DueBefore(*filter.DueBefore)
+	}
+
+	if filter.Search != "" {
+		query.Matching(filter.Search)
+	}
+
+	return query
+}
+
+// selectColumns returns the given fields of a task, keyed as in its JSON
+// form, or every field when columns is empty. The ID is always included.
+func selectColumns(task domain.Task, columns []string) (map[string]any, error) {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	if len(columns) == 0 {
+		return fields, nil
+	}
+
+	selected := map[string]any{"ID": fields["ID"]}
+	for _, column := range columns {
+		selected[column] = fields[column]
+	}
+
+	return selected, nil
+}
+
+func encodeViewCursor(cursor viewCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeViewCursor reads a cursor handed out for the given view. Cursors are
+// not signed, so the definition they carry is validated again.
+func (s *ViewService) decodeViewCursor(raw string, viewID string) (viewCursor, error) {
+	invalid := domain.Invalid("cursor", "invalid cursor")
+
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return viewCursor{}, invalid
+	}
+
+	var cursor viewCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return viewCursor{}, invalid
+	}
+
+	if cursor.View != viewID || cursor.ID == "" || len(cursor.Keys) != len(cursor.Definition.Sort) {
+		return viewCursor{}, invalid
+	}
+
+	if err := s.normalizeDefinition(&cursor.Definition); err != nil {
+		return viewCursor{}, invalid
+	}
+
+	return cursor, nil
+}
+
+// getVisibleView loads a view the client owns or that is shared.
+func getVisibleView(ctx context.Context, q *sqlc.Queries, id string, client string) (sqlc.SavedView, error) {
+	if id == "" {
+		return sqlc.SavedView{}, domain.Invalid("id", "id is required")
+	}
+
+	view, err := q.GetSavedView(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sqlc.SavedView{}, domain.NotFound("view not found")
+		}
+		return sqlc.SavedView{}, err
+	}
+
+	if view.Owner != client && !view.Shared {
+		return sqlc.SavedView{}, domain.NotFound("view not found")
+	}
+
+	return view, nil
+}
+
+func validateViewName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", domain.Invalid("Name", "name is required")
+	}
+
+	if len(name) > maxViewNameLength {
+		return "", domain.Invalid("Name", "name is longer than %d characters", maxViewNameLength)
+	}
+
+	return name, nil
+}
+
+func viewToDomain(view sqlc.SavedView) (domain.View, error) {
+	var definition viewDefinition
+	if err := json.Unmarshal([]byte(view.Definition), &definition); err != nil {
+		return domain.View{}, fmt.Errorf("view %s: %w", view.ID, err)
+	}
+
+	return domain.View{
+		ID:        uuid.MustParse(view.ID),
+		Owner:     view.Owner,
+		Name:      view.Name,
+		Shared:    view.Shared,
+		Filter:    definition.Filter,
+		Sort:      definition.Sort,
+		Columns:   definition.Columns,
+		CreatedAt: view.CreatedAt,
+		UpdatedAt: view.UpdatedAt,
+	}, nil
+}
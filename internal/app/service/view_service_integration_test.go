@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/storage"
+)
+
+func TestViewService_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, err := sqlite.NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+
+	logger := log.New(os.Stderr, "INTEGRATION_TEST: ", log.LstdFlags)
+	taskService := NewTaskService(logger, db, blobs, domain.DefaultWorkflow())
+	viewService := NewViewService(logger, db, domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	due := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tasks := []domain.CreateTaskRequest{
+		{Title: "Fix login", Description: "100% broken", Priority: domain.TaskPriorityHigh, Assignees: []string{"alice"}, Tags: []string{"ops"}, DueAt: &due},
+		{Title: "Write docs", Priority: domain.TaskPriorityLow, Assignees: []string{"alice"}, Tags: []string{"ops"}},
+		{Title: "Rotate keys", Priority: domain.TaskPriorityMedium, Assignees: []string{"alice", "bob"}, Tags: []string{"ops", "security"}},
+		{Title: "Plan sprint", Priority: domain.TaskPriorityHigh, Assignees: []string{"bob"}, Tags: []string{"ops"}},
+		{Title: "Update deps", Priority: domain.TaskPriorityMedium, Assignees: []string{"alice"}, Tags: []string{"ops"}},
+	}
+	for i := range tasks {
+		if _, err := taskService.CreateTask(ctx, &tasks[i]); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+
+	// titles runs a view to the end and returns the titles of every page.
+	titles := func(t *testing.T, id, client string, limit int) [][]string {
+		t.Helper()
+
+		pages := [][]string{}
+		cursor := ""
+		for {
+			page, err := viewService.RunView(ctx, id, client, cursor, limit)
+			if err != nil {
+				t.Fatalf("Failed to run view: %v", err)
+			}
+
+			titles := []string{}
+			for _, task := range page.Tasks {
+				titles = append(titles, task["Title"].(string))
+			}
+			pages = append(pages, titles)
+
+			if page.NextCursor == "" {
+				return pages
+			}
+			cursor = page.NextCursor
+		}
+	}
+
+	var mine domain.View
+
+	t.Run("create view", func(t *testing.T) {
+		mine, err = viewService.CreateView(ctx, "alice", &domain.CreateViewRequest{
+			Name:    "My ops",
+			Filter:  domain.ViewFilter{Assignee: "me", Tags: []string{"OPS"}},
+			Sort:    []domain.TaskSort{{Field: domain.TaskSortPriority, Descending: true}, {Field: domain.TaskSortTitle}},
+			Columns: []string{"Title", "Priority"},
+		})
+		if err != nil {
+			t.Fatalf("Failed to create view: %v", err)
+		}
+
+		if mine.Owner != "alice" || len(mine.Filter.Tags) != 1 || mine.Filter.Tags[0] != "ops" {
+			t.Errorf("Expected an owned view with normalized tags, got %+v", mine)
+		}
+
+		_, err := viewService.CreateView(ctx, "alice", &domain.CreateViewRequest{Name: "My ops"})
+		if !errors.Is(err, domain.ErrConflict) {
+			t.Errorf("Expected a duplicate name to conflict, got %v", err)
+		}
+
+		if _, err := viewService.CreateView(ctx, "bob", &domain.CreateViewRequest{Name: "My ops"}); err != nil {
+			t.Errorf("Expected names to be unique per client only, got %v", err)
+		}
+	})
+
+	t.Run("run view with sort and columns", func(t *testing.T) {
+		pages := titles(t, mine.ID.String(), "alice", 50)
+		expected := []string{"Fix login", "Rotate keys", "Update deps", "Write docs"}
+		if len(pages) != 1 || len(pages[0]) != len(expected) {
+			t.Fatalf("Expected %v, got %v", expected, pages)
+		}
+		for i := range expected {
+			if pages[0][i] != expected[i] {
+				t.Fatalf("Expected %v, got %v", expected, pages[0])
+			}
+		}
+
+		page, err := viewService.RunView(ctx, mine.ID.String(), "alice", "", 1)
+		if err != nil {
+			t.Fatalf("Failed to run view: %v", err)
+		}
+		if len(page.Tasks[0]) != 3 || page.Tasks[0]["ID"] == nil || page.Tasks[0]["Priority"] != "high" {
+			t.Errorf("Expected the ID and the selected columns, got %v", page.Tasks[0])
+		}
+	})
+
+	t.Run("pages cover every task once", func(t *testing.T) {
+		pages := titles(t, mine.ID.String(), "alice", 1)
+		if len(pages) != 4 {
+			t.Fatalf("Expected 4 pages, got %v", pages)
+		}
+		if pages[0][0] != "Fix login" || pages[3][0] != "Write docs" {
+			t.Errorf("Expected pages in sort order, got %v", pages)
+		}
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		from := due.Add(-time.Hour)
+		tests := map[string]struct {
+			filter   domain.ViewFilter
+			expected int
+		}{
+			"tags must all match":   {domain.ViewFilter{Tags: []string{"ops", "security"}}, 1},
+			"any listed priority":   {domain.ViewFilter{Priorities: []domain.TaskPriority{domain.TaskPriorityHigh, domain.TaskPriorityLow}}, 3},
+			"assignee":              {domain.ViewFilter{Assignee: "bob"}, 2},
+			"due range":             {domain.ViewFilter{DueFrom: &from}, 1},
+			"search ignores case":   {domain.ViewFilter{Search: "DOCS"}, 1},
+			"search escapes LIKE":   {domain.ViewFilter{Search: "100%"}, 1},
+			"status":                {domain.ViewFilter{Statuses: []domain.TaskStatus{domain.TaskStatusDone}}, 0},
+			"unmatched combination": {domain.ViewFilter{Assignee: "bob", Tags: []string{"security"}, Search: "plan"}, 0},
+		}
+
+		for name, tt := range tests {
+			view, err := viewService.CreateView(ctx, "carol", &domain.CreateViewRequest{Name: name, Filter: tt.filter})
+			if err != nil {
+				t.Fatalf("Failed to create view %s: %v", name, err)
+			}
+
+			page, err := viewService.RunView(ctx, view.ID.String(), "carol", "", 0)
+			if err != nil {
+				t.Fatalf("Failed to run view %s: %v", name, err)
+			}
+			if len(page.Tasks) != tt.expected {
+				t.Errorf("Expected %s to select %d tasks, got %d", name, tt.expected, len(page.Tasks))
+			}
+		}
+	})
+
+	t.Run("sharing", func(t *testing.T) {
+		if _, err := viewService.GetView(ctx, mine.ID.String(), "bob"); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Expected a private view to be hidden, got %v", err)
+		}
+
+		shared := true
+		if _, err := viewService.UpdateView(ctx, mine.ID.String(), "alice", &domain.UpdateViewRequest{Shared: &shared}); err != nil {
+			t.Fatalf("Failed to share view: %v", err)
+		}
+
+		views, err := viewService.ListViews(ctx, "bob")
+		if err != nil {
+			t.Fatalf("Failed to list views: %v", err)
+		}
+		if len(views) != 2 {
+			t.Errorf("Expected bob's view and the shared one, got %+v", views)
+		}
+
+		// "me" stands for the client running the view.
+		pages := titles(t, mine.ID.String(), "bob", 50)
+		if len(pages[0]) != 2 || pages[0][0] != "Plan sprint" || pages[0][1] != "Rotate keys" {
+			t.Errorf("Expected bob's tasks, got %v", pages)
+		}
+
+		name := "Taken over"
+		if _, err := viewService.UpdateView(ctx, mine.ID.String(), "bob", &domain.UpdateViewRequest{Name: &name}); !errors.Is(err, domain.ErrForbidden) {
+			t.Errorf("Expected only the owner to change a view, got %v", err)
+		}
+		if err := viewService.DeleteView(ctx, mine.ID.String(), "bob"); !errors.Is(err, domain.ErrForbidden) {
+			t.Errorf("Expected only the owner to delete a view, got %v", err)
+		}
+	})
+
+	t.Run("cursors survive edits", func(t *testing.T) {
+		first, err := viewService.RunView(ctx, mine.ID.String(), "alice", "", 2)
+		if err != nil {
+			t.Fatalf("Failed to run view: %v", err)
+		}
+
+		sort := []domain.TaskSort{{Field: domain.TaskSortCreatedAt}}
+		filter := domain.ViewFilter{Tags: []string{"security"}}
+		if _, err := viewService.UpdateView(ctx, mine.ID.String(), "alice", &domain.UpdateViewRequest{Filter: &filter, Sort: &sort}); err != nil {
+			t.Fatalf("Failed to update view: %v", err)
+		}
+
+		second, err := viewService.RunView(ctx, mine.ID.String(), "alice", first.NextCursor, 2)
+		if err != nil {
+			t.Fatalf("Expected the cursor to keep working, got %v", err)
+		}
+		if len(second.Tasks) != 2 || second.Tasks[0]["Title"] != "Update deps" || second.Tasks[1]["Title"] != "Write docs" {
+			t.Errorf("Expected the rest of the old listing, got %v", second.Tasks)
+		}
+
+		fresh, err := viewService.RunView(ctx, mine.ID.String(), "alice", "", 2)
+		if err != nil {
+			t.Fatalf("Failed to run view: %v", err)
+		}
+		if len(fresh.Tasks) != 1 || fresh.Tasks[0]["Title"] != "Rotate keys" {
+			t.Errorf("Expected a new listing to use the new definition, got %v", fresh.Tasks)
+		}
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		tests := map[string]domain.CreateViewRequest{
+			"empty name":       {Name: " "},
+			"unknown sort":     {Name: "a", Sort: []domain.TaskSort{{Field: "Seq"}}},
+			"duplicate sort":   {Name: "b", Sort: []domain.TaskSort{{Field: domain.TaskSortTitle}, {Field: domain.TaskSortTitle}}},
+			"unknown column":   {Name: "c", Columns: []string{"Secret"}},
+			"unknown status":   {Name: "d", Filter: domain.ViewFilter{Statuses: []domain.TaskStatus{"archived"}}},
+			"unknown priority": {Name: "e", Filter: domain.ViewFilter{Priorities: []domain.TaskPriority{"urgent"}}},
+		}
+
+		for name, req := range tests {
+			if _, err := viewService.CreateView(ctx, "alice", &req); !errors.Is(err, domain.ErrValidation) {
+				t.Errorf("Expected %s to be refused, got %v", name, err)
+			}
+		}
+
+		for _, cursor := range []string{"not-a-cursor", "e30"} {
+			if _, err := viewService.RunView(ctx, mine.ID.String(), "alice", cursor, 0); !errors.Is(err, domain.ErrValidation) {
+				t.Errorf("Expected cursor %q to be refused, got %v", cursor, err)
+			}
+		}
+
+		other, err := viewService.CreateView(ctx, "alice", &domain.CreateViewRequest{Name: "Other"})
+		if err != nil {
+			t.Fatalf("Failed to create view: %v", err)
+		}
+		page, err := viewService.RunView(ctx, other.ID.String(), "alice", "", 1)
+		if err != nil {
+			t.Fatalf("Failed to run view: %v", err)
+		}
+		if _, err := viewService.RunView(ctx, mine.ID.String(), "alice", page.NextCursor, 1); !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("Expected a cursor of another view to be refused, got %v", err)
+		}
+	})
+
+	t.Run("delete view", func(t *testing.T) {
+		if err := viewService.DeleteView(ctx, mine.ID.String(), "alice"); err != nil {
+			t.Fatalf("Failed to delete view: %v", err)
+		}
+		if _, err := viewService.RunView(ctx, mine.ID.String(), "alice", "", 0); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Expected a deleted view to be gone, got %v", err)
+		}
+	})
+}
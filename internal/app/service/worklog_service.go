@@ -0,0 +1,378 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite/sqlc"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/google/uuid"
+)
+
+// WorklogService records the time clients spend on tasks, either logged
+// afterwards or measured with a timer, and adds it up for reports.
+type WorklogService struct {
+	logger *log.Logger
+	db     *sqlite.Database
+}
+
+func NewWorklogService(logger *log.Logger, db *sqlite.Database) *WorklogService {
+	return &WorklogService{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// LogWork records time spent on a task that was not measured with a timer.
+func (s *WorklogService) LogWork(ctx context.Context, taskID string, author string, req *domain.CreateWorklogRequest) (domain.Worklog, error) {
+	if taskID == "" {
+		return domain.Worklog{}, fmt.Errorf("log work: %w", domain.Invalid("id", "task id is required"))
+	}
+
+	if req.StartedAt == nil {
+		return domain.Worklog{}, fmt.Errorf("log work: %w", domain.Invalid("StartedAt", "started at is required"))
+	}
+
+	if (req.EndedAt == nil) == (req.Duration == "") {
+		return domain.Worklog{}, fmt.Errorf("log work: %w", domain.Invalid("Duration", "give either an end time or a duration"))
+	}
+
+	startedAt := req.StartedAt.UTC()
+	var duration time.Duration
+	if req.EndedAt != nil {
+		duration = req.EndedAt.Sub(startedAt)
+		if duration <= 0 {
+			return domain.Worklog{}, fmt.Errorf("log work: %w", domain.Invalid("EndedAt", "end time must be after the start time"))
+		}
+	} else {
+		var err error
+		duration, err = time.ParseDuration(req.Duration)
+		if err != nil || duration <= 0 {
+			return domain.Worklog{}, fmt.Errorf("log work: %w", domain.Invalid("Duration", "duration must be a positive duration such as 1h30m"))
+		}
+	}
+
+	if _, err := s.db.Queries.GetTask(ctx, taskID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Worklog{}, fmt.Errorf("log work: %w", domain.NotFound("task not found"))
+		}
+		return domain.Worklog{}, fmt.Errorf("log work: %w", err)
+	}
+
+	endedAt := startedAt.Add(duration)
+	worklog := sqlc.TaskWorklog{
+		ID:              uuid.New().String(),
+		TaskID:          taskID,
+		Author:          author,
+		StartedAt:       startedAt,
+		EndedAt:         sql.NullTime{Time: endedAt, Valid: true},
+		DurationSeconds: sql.NullInt64{Int64: int64(duration / time.Second), Valid: true},
+		Note:            strings.TrimSpace(req.Note),
+		CreatedAt:       time.Now().UTC(),
+	}
+
+	if err := createWorklog(ctx, s.db.Queries, worklog); err != nil {
+		return domain.Worklog{}, fmt.Errorf("log work: %w", err)
+	}
+
+	return worklogToDomain(worklog), nil
+}
+
+// ListWorklogs returns the time logged on a task, including running timers,
+// oldest first.
+func (s *WorklogService) ListWorklogs(ctx context.Context, taskID string) ([]domain.Worklog, error) {
+	if taskID == "" {
+		return nil, fmt.Errorf("list worklogs: %w", domain.Invalid("id", "task id is required"))
+	}
+
+	if _, err := s.db.Queries.GetTask(ctx, taskID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("list worklogs: %w", domain.NotFound("task not found"))
+		}
+		return nil, fmt.Errorf("list worklogs: %w", err)
+	}
+
+	worklogs, err := s.db.Queries.ListTaskWorklogs(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("list worklogs: %w", err)
+	}
+
+	domainWorklogs := make([]domain.Worklog, len(worklogs))
+	for i, worklog := range worklogs {
+		domainWorklogs[i] = worklogToDomain(worklog)
+	}
+
+	return domainWorklogs, nil
+}
+
+// GetWorklog returns a worklog of a task.
+func (s *WorklogService) GetWorklog(ctx context.Context, taskID string, worklogID string) (domain.Worklog, error) {
+	if taskID == "" || worklogID == "" {
+		return domain.Worklog{}, fmt.Errorf("get worklog: %w", domain.Invalid("id", "task id and worklog id are required"))
+	}
+
+	worklog, err := s.db.Queries.GetTaskWorklog(ctx, sqlc.GetTaskWorklogParams{ID: worklogID, TaskID: taskID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Worklog{}, fmt.Errorf("get worklog: %w", domain.NotFound("worklog not found"))
+		}
+		return domain.Worklog{}, fmt.Errorf("get worklog: %w", err)
+	}
+
+	return worklogToDomain(worklog), nil
+}
+
+// DeleteWorklog removes a worklog, or discards a running timer. Only the
+// author may delete a worklog.
+func (s *WorklogService) DeleteWorklog(ctx context.Context, taskID string, worklogID string, actor string) error {
+	if taskID == "" || worklogID == "" {
+		return fmt.Errorf("delete worklog: %w", domain.Invalid("id", "task id and worklog id are required"))
+	}
+
+	return s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		worklog, err := q.GetTaskWorklog(ctx, sqlc.GetTaskWorklogParams{ID: worklogID, TaskID: taskID})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("delete worklog: %w", domain.NotFound("worklog not found"))
+			}
+			return fmt.Errorf("delete worklog: %w", err)
+		}
+
+		if worklog.Author != actor {
+			return fmt.Errorf("delete worklog: %w", domain.Forbidden("only the author can delete a worklog"))
+		}
+
+		if _, err := q.DeleteTaskWorklog(ctx, sqlc.DeleteTaskWorklogParams{ID: worklogID, TaskID: taskID}); err != nil {
+			return fmt.Errorf("delete worklog: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// StartTimer starts measuring the time a client spends on a task. A client
+// runs one timer at a time, so a timer running on another task is stopped
+// first. It returns false, with the running timer, when the client's timer
+// was already running on this task.
+func (s *WorklogService) StartTimer(ctx context.Context, taskID string, author string, req *domain.TimerRequest) (domain.Worklog, bool, error) {
+	if taskID == "" {
+		return domain.Worklog{}, false, fmt.Errorf("start timer: %w", domain.Invalid("id", "task id is required"))
+	}
+
+	var result sqlc.TaskWorklog
+	started := false
+
+	err := s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		if _, err := q.GetTask(ctx, taskID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return domain.NotFound("task not found")
+			}
+			return err
+		}
+
+		now := time.Now().UTC()
+
+		running, err := q.GetRunningWorklog(ctx, author)
+		switch {
+		case err == nil && running.TaskID == taskID:
+			result = running
+			return nil
+		case err == nil:
+			if err := stopWorklog(ctx, q, &running, now, ""); err != nil {
+				return err
+			}
+		case !errors.Is(err, sql.ErrNoRows):
+			return err
+		}
+
+		result = sqlc.TaskWorklog{
+			ID:        uuid.New().String(),
+			TaskID:    taskID,
+			Author:    author,
+			StartedAt: now,
+			Note:      strings.TrimSpace(req.Note),
+			CreatedAt: now,
+		}
+		if err := createWorklog(ctx, q, result); err != nil {
+			return err
+		}
+
+		started = true
+		return nil
+	})
+	if err != nil {
+		return domain.Worklog{}, false, fmt.Errorf("start timer: %w", err)
+	}
+
+	return worklogToDomain(result), started, nil
+}
+
+// StopTimer stops the client's timer on a task and records the time measured.
+func (s *WorklogService) StopTimer(ctx context.Context, taskID string, author string, req *domain.TimerRequest) (domain.Worklog, error) {
+	if taskID == "" {
+		return domain.Worklog{}, fmt.Errorf("stop timer: %w", domain.Invalid("id", "task id is required"))
+	}
+
+	var result sqlc.TaskWorklog
+
+	err := s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		running, err := q.GetRunningWorklog(ctx, author)
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && running.TaskID != taskID) {
+			return domain.NotFound("no timer is running on this task")
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := stopWorklog(ctx, q, &running, time.Now().UTC(), strings.TrimSpace(req.Note)); err != nil {
+			return err
+		}
+
+		result = running
+		return nil
+	})
+	if err != nil {
+		return domain.Worklog{}, fmt.Errorf("stop timer: %w", err)
+	}
+
+	return worklogToDomain(result), nil
+}
+
+// Report adds up the time logged with finished worklogs that match the
+// filter, in total and per task, assignee or date. The assignee of a worklog
+// is the client that logged it.
+func (s *WorklogService) Report(ctx context.Context, filter domain.WorklogFilter) (domain.WorklogReport, error) {
+	if filter.GroupBy == "" {
+		filter.GroupBy = domain.WorklogGroupTask
+	}
+	if !filter.GroupBy.IsValid() {
+		return domain.WorklogReport{}, fmt.Errorf("worklog report: %w", domain.Invalid("group_by", "group by must be task, assignee or date"))
+	}
+	if filter.From != nil && filter.To != nil && !filter.To.After(*filter.From) {
+		return domain.WorklogReport{}, fmt.Errorf("worklog report: %w", domain.Invalid("to", "to must be after from"))
+	}
+
+	params := sqlc.SumTaskWorklogsParams{
+		TaskID: sql.NullString{String: filter.TaskID, Valid: filter.TaskID != ""},
+		Author: sql.NullString{String: filter.Assignee, Valid: filter.Assignee != ""},
+	}
+	if filter.From != nil {
+		params.StartedFrom = sql.NullTime{Time: filter.From.UTC(), Valid: true}
+	}
+	if filter.To != nil {
+		params.StartedBefore = sql.NullTime{Time: filter.To.UTC(), Valid: true}
+	}
+
+	sums, err := s.db.Queries.SumTaskWorklogs(ctx, params)
+	if err != nil {
+		return domain.WorklogReport{}, fmt.Errorf("worklog report: %w", err)
+	}
+
+	report := domain.WorklogReport{
+		GroupBy: filter.GroupBy,
+		From:    filter.From,
+		To:      filter.To,
+		Groups:  []domain.WorklogTotal{},
+	}
+
+	groups := map[string]*domain.WorklogTotal{}
+	for _, sum := range sums {
+		key := sum.TaskID
+		switch filter.GroupBy {
+		case domain.WorklogGroupAssignee:
+			key = sum.Author
+		case domain.WorklogGroupDate:
+			key = sum.Day
+		}
+
+		group, ok := groups[key]
+		if !ok {
+			group = &domain.WorklogTotal{Key: key}
+			groups[key] = group
+		}
+		group.Seconds += sum.Seconds
+		group.Entries += sum.Entries
+
+		report.Seconds += sum.Seconds
+		report.Entries += sum.Entries
+	}
+
+	for _, group := range groups {
+		group.Duration = formatSeconds(group.Seconds)
+		report.Groups = append(report.Groups, *group)
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		return report.Groups[i].Key < report.Groups[j].Key
+	})
+	report.Duration = formatSeconds(report.Seconds)
+
+	return report, nil
+}
+
+func createWorklog(ctx context.Context, q *sqlc.Queries, worklog sqlc.TaskWorklog) error {
+	return q.CreateTaskWorklog(ctx, sqlc.CreateTaskWorklogParams{
+		ID:              worklog.ID,
+		TaskID:          worklog.TaskID,
+		Author:          worklog.Author,
+		StartedAt:       worklog.StartedAt,
+		EndedAt:         worklog.EndedAt,
+		DurationSeconds: worklog.DurationSeconds,
+		Note:            worklog.Note,
+		CreatedAt:       worklog.CreatedAt,
+	})
+}
+
+// stopWorklog ends a running worklog at the given time. A non-empty note
+// replaces the one given when the timer was started.
+func stopWorklog(ctx context.Context, q *sqlc.Queries, worklog *sqlc.TaskWorklog, now time.Time, note string) error {
+	if now.Before(worklog.StartedAt) {
+		now = worklog.StartedAt
+	}
+	if note != "" {
+		worklog.Note = note
+	}
+
+	worklog.EndedAt = sql.NullTime{Time: now, Valid: true}
+	worklog.DurationSeconds = sql.NullInt64{Int64: int64(now.Sub(worklog.StartedAt) / time.Second), Valid: true}
+
+	_, err := q.StopTaskWorklog(ctx, sqlc.StopTaskWorklogParams{
+		EndedAt:         worklog.EndedAt,
+		DurationSeconds: worklog.DurationSeconds,
+		Note:            worklog.Note,
+		ID:              worklog.ID,
+	})
+	return err
+}
+
+func formatSeconds(seconds int64) string {
+	return (time.Duration(seconds) * time.Second).String()
+}
+
+func worklogToDomain(worklog sqlc.TaskWorklog) domain.Worklog {
+	result := domain.Worklog{
+		ID:        uuid.MustParse(worklog.ID),
+		TaskID:    uuid.MustParse(worklog.TaskID),
+		Author:    worklog.Author,
+		StartedAt: worklog.StartedAt,
+		Note:      worklog.Note,
+		CreatedAt: worklog.CreatedAt,
+	}
+
+	if worklog.EndedAt.Valid {
+		endedAt := worklog.EndedAt.Time
+		result.EndedAt = &endedAt
+	}
+	if worklog.DurationSeconds.Valid {
+		result.Seconds = worklog.DurationSeconds.Int64
+		result.Duration = formatSeconds(result.Seconds)
+	}
+
+	return result
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/storage"
+)
+
+func TestWorklogService_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, err := sqlite.NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+
+	logger := log.New(os.Stderr, "INTEGRATION_TEST: ", log.LstdFlags)
+	taskService := NewTaskService(logger, db, blobs, domain.DefaultWorkflow())
+	worklogService := NewWorklogService(logger, db)
+	ctx := context.Background()
+
+	design, err := taskService.CreateTask(ctx, &domain.CreateTaskRequest{Title: "Design API"})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	build, err := taskService.CreateTask(ctx, &domain.CreateTaskRequest{Title: "Build API"})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	monday := time.Date(2025, time.March, 3, 9, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+
+	t.Run("work is logged with an end time or a duration", func(t *testing.T) {
+		ended := monday.Add(90 * time.Minute)
+		worklog, err := worklogService.LogWork(ctx, design.String(), "alice", &domain.CreateWorklogRequest{StartedAt: &monday, EndedAt: &ended, Note: "Draft"})
+		if err != nil {
+			t.Fatalf("Failed to log work: %v", err)
+		}
+		if worklog.Seconds != 5400 || worklog.Duration != "1h30m0s" || worklog.IsRunning() {
+			t.Errorf("Expected 1h30m of finished work, got %+v", worklog)
+		}
+
+		if _, err := worklogService.LogWork(ctx, build.String(), "alice", &domain.CreateWorklogRequest{StartedAt: &tuesday, Duration: "2h"}); err != nil {
+			t.Fatalf("Failed to log work: %v", err)
+		}
+		if _, err := worklogService.LogWork(ctx, design.String(), "bob", &domain.CreateWorklogRequest{StartedAt: &tuesday, Duration: "45m"}); err != nil {
+			t.Fatalf("Failed to log work: %v", err)
+		}
+	})
+
+	t.Run("invalid worklogs are refused", func(t *testing.T) {
+		before := monday.Add(-time.Hour)
+		for name, req := range map[string]*domain.CreateWorklogRequest{
+			"no start":        {Duration: "1h"},
+			"end and length":  {StartedAt: &monday, EndedAt: &tuesday, Duration: "1h"},
+			"end before":      {StartedAt: &monday, EndedAt: &before},
+			"negative length": {StartedAt: &monday, Duration: "-1h"},
+		} {
+			if _, err := worklogService.LogWork(ctx, design.String(), "alice", req); !errors.Is(err, domain.ErrValidation) {
+				t.Errorf("Expected %s to be refused, got %v", name, err)
+			}
+		}
+	})
+
+	t.Run("starting a timer stops the client's other timer", func(t *testing.T) {
+		first, started, err := worklogService.StartTimer(ctx, design.String(), "carol", &domain.TimerRequest{Note: "Review"})
+		if err != nil || !started || !first.IsRunning() {
+			t.Fatalf("Expected a running timer, got %+v, %v, %v", first, started, err)
+		}
+
+		again, started, err := worklogService.StartTimer(ctx, design.String(), "carol", &domain.TimerRequest{})
+		if err != nil || started || again.ID != first.ID {
+			t.Errorf("Expected the running timer back, got %+v, %v, %v", again, started, err)
+		}
+
+		if _, _, err := worklogService.StartTimer(ctx, build.String(), "carol", &domain.TimerRequest{}); err != nil {
+			t.Fatalf("Failed to start timer: %v", err)
+		}
+
+		worklogs, err := worklogService.ListWorklogs(ctx, design.String())
+		if err != nil {
+			t.Fatalf("Failed to list worklogs: %v", err)
+		}
+		for _, worklog := range worklogs {
+			if worklog.ID == first.ID && (worklog.IsRunning() || worklog.Note != "Review") {
+				t.Errorf("Expected the first timer to be stopped, got %+v", worklog)
+			}
+		}
+
+		if _, err := worklogService.StopTimer(ctx, design.String(), "carol", &domain.TimerRequest{}); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Expected no timer on the first task, got %v", err)
+		}
+
+		stopped, err := worklogService.StopTimer(ctx, build.String(), "carol", &domain.TimerRequest{Note: "Scaffolding"})
+		if err != nil {
+			t.Fatalf("Failed to stop timer: %v", err)
+		}
+		if stopped.IsRunning() || stopped.Note != "Scaffolding" {
+			t.Errorf("Expected a finished worklog with the new note, got %+v", stopped)
+		}
+	})
+
+	t.Run("only the author deletes a worklog", func(t *testing.T) {
+		worklog, err := worklogService.LogWork(ctx, design.String(), "dave", &domain.CreateWorklogRequest{StartedAt: &monday, Duration: "10m"})
+		if err != nil {
+			t.Fatalf("Failed to log work: %v", err)
+		}
+
+		if err := worklogService.DeleteWorklog(ctx, design.String(), worklog.ID.String(), "alice"); !errors.Is(err, domain.ErrForbidden) {
+			t.Errorf("Expected a forbidden error, got %v", err)
+		}
+		if err := worklogService.DeleteWorklog(ctx, design.String(), worklog.ID.String(), "dave"); err != nil {
+			t.Errorf("Failed to delete worklog: %v", err)
+		}
+	})
+
+	t.Run("reports add up time per group", func(t *testing.T) {
+		from := monday.Truncate(24 * time.Hour)
+		to := from.AddDate(0, 0, 7)
+
+		report, err := worklogService.Report(ctx, domain.WorklogFilter{From: &from, To: &to, GroupBy: domain.WorklogGroupAssignee})
+		if err != nil {
+			t.Fatalf("Failed to build report: %v", err)
+		}
+		if report.Seconds != 5400+7200+2700 || report.Entries != 3 {
+			t.Errorf("Expected 4h15m in 3 entries, got %s in %d", report.Duration, report.Entries)
+		}
+		if len(report.Groups) != 2 || report.Groups[0].Key != "alice" || report.Groups[0].Duration != "3h30m0s" {
+			t.Errorf("Expected alice's 3h30m first, got %+v", report.Groups)
+		}
+
+		report, err = worklogService.Report(ctx, domain.WorklogFilter{TaskID: design.String(), From: &from, To: &to, GroupBy: domain.WorklogGroupDate})
+		if err != nil {
+			t.Fatalf("Failed to build report: %v", err)
+		}
+		if len(report.Groups) != 2 || report.Groups[0].Key != "2025-03-03" || report.Groups[1].Seconds != 2700 {
+			t.Errorf("Expected the design work per day, got %+v", report.Groups)
+		}
+
+		report, err = worklogService.Report(ctx, domain.WorklogFilter{Assignee: "alice", To: &tuesday})
+		if err != nil {
+			t.Fatalf("Failed to build report: %v", err)
+		}
+		if len(report.Groups) != 1 || report.Groups[0].Key != design.String() || report.Seconds != 5400 {
+			t.Errorf("Expected alice's Monday work on one task, got %+v", report)
+		}
+
+		if _, err := worklogService.Report(ctx, domain.WorklogFilter{GroupBy: "week"}); !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("Expected an unknown grouping to be refused, got %v", err)
+		}
+	})
+}
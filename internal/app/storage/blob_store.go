@@ -0,0 +1,16 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore keeps opaque binary content addressed by a key chosen by the caller.
+type BlobStore interface {
+	Put(ctx context.Context, key string, content io.Reader) (int64, error)
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
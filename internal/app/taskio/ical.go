@@ -0,0 +1,477 @@
+package taskio
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+)
+
+// iCalendar (RFC 5545) content lines are folded after this many octets.
+const icsLineLength = 75
+
+const (
+	icsDateTime     = "20060102T150405Z"
+	icsLocalTime    = "20060102T150405"
+	icsDate         = "20060102"
+	icsProductID    = "-//ishare-task//Tasks//EN"
+	icsCalendar     = "VCALENDAR"
+	icsTodo         = "VTODO"
+	icsCalendarName = "Tasks"
+)
+
+// icsWriter writes tasks as the VTODO components of a calendar.
+type icsWriter struct {
+	w             *bufio.Writer
+	workflow      domain.Workflow
+	headerWritten bool
+}
+
+func (c *icsWriter) writeHeader() error {
+	c.headerWritten = true
+	return c.lines(
+		"BEGIN:"+icsCalendar,
+		"VERSION:2.0",
+		"PRODID:"+icsProductID,
+		"CALSCALE:GREGORIAN",
+		"X-WR-CALNAME:"+icsCalendarName,
+	)
+}
+
+func (c *icsWriter) Write(task domain.Task) error {
+	if !c.headerWritten {
+		if err := c.writeHeader(); err != nil {
+			return err
+		}
+	}
+
+	lines := []string{
+		"BEGIN:" + icsTodo,
+		"UID:" + task.ID.String(),
+		"DTSTAMP:" + task.UpdatedAt.UTC().Format(icsDateTime),
+		"CREATED:" + task.CreatedAt.UTC().Format(icsDateTime),
+		"LAST-MODIFIED:" + task.UpdatedAt.UTC().Format(icsDateTime),
+		"SEQUENCE:" + strconv.FormatInt(max(task.Version-1, 0), 10),
+		"SUMMARY:" + icsEscaper.Replace(task.Title),
+	}
+	if task.Description != "" {
+		lines = append(lines, "DESCRIPTION:"+icsEscaper.Replace(task.Description))
+	}
+	lines = append(lines,
+		"STATUS:"+icsStatus(c.workflow, task.Status),
+		"PRIORITY:"+strconv.Itoa(icsPriority(task.Priority)),
+	)
+	if task.DueAt != nil {
+		lines = append(lines, "DUE:"+task.DueAt.UTC().Format(icsDateTime))
+	}
+	if len(task.Tags) > 0 {
+		tags := make([]string, len(task.Tags))
+		for i, tag := range task.Tags {
+			tags[i] = icsEscaper.Replace(tag)
+		}
+		lines = append(lines, "CATEGORIES:"+strings.Join(tags, ","))
+	}
+	lines = append(lines, "END:"+icsTodo)
+
+	return c.lines(lines...)
+}
+
+func (c *icsWriter) Close() error {
+	if !c.headerWritten {
+		if err := c.writeHeader(); err != nil {
+			return err
+		}
+	}
+	if err := c.lines("END:" + icsCalendar); err != nil {
+		return err
+	}
+	return c.w.Flush()
+}
+
+// lines writes content lines, folding those longer than icsLineLength octets
+// without splitting UTF-8 sequences.
+func (c *icsWriter) lines(lines ...string) error {
+	for _, line := range lines {
+		length := 0
+		for _, r := range line {
+			size := utf8.RuneLen(r)
+			if length+size > icsLineLength {
+				if _, err := c.w.WriteString("\r\n "); err != nil {
+					return err
+				}
+				length = 1
+			}
+			if _, err := c.w.WriteRune(r); err != nil {
+				return err
+			}
+			length += size
+		}
+		if _, err := c.w.WriteString("\r\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// icsEscaper escapes TEXT values.
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// icsStatus maps a task status to a VTODO status: the done statuses of the
+// workflow are completed, its started statuses in process and any other
+// status is still an open to-do.
+func icsStatus(workflow domain.Workflow, status domain.TaskStatus) string {
+	switch {
+	case workflow.IsDone(status):
+		return "COMPLETED"
+	case workflow.IsStarted(status):
+		return "IN-PROCESS"
+	default:
+		return "NEEDS-ACTION"
+	}
+}
+
+// icsPriority maps a task priority to the 1 (highest) to 9 (lowest) scale of
+// iCalendar, using the middle of each band; 0 means undefined.
+func icsPriority(priority domain.TaskPriority) int {
+	switch priority {
+	case domain.TaskPriorityHigh:
+		return 1
+	case domain.TaskPriorityMedium:
+		return 5
+	case domain.TaskPriorityLow:
+		return 9
+	default:
+		return 0
+	}
+}
+
+// taskStatus maps a VTODO status back to a task status: the first done or
+// started status of the workflow, or its initial status. Workflows without a
+// started status import to-dos in process as not started.
+func taskStatus(workflow domain.Workflow, status string) (string, error) {
+	switch strings.ToUpper(status) {
+	case "NEEDS-ACTION":
+		return string(workflow.InitialStatus), nil
+	case "IN-PROCESS":
+		if len(workflow.StartedStatuses) == 0 {
+			return string(workflow.InitialStatus), nil
+		}
+		return string(workflow.StartedStatuses[0]), nil
+	case "COMPLETED":
+		return string(workflow.DoneStatuses[0]), nil
+	case "CANCELLED":
+		return "", errors.New("cancelled to-dos are not imported")
+	default:
+		return "", fmt.Errorf("unknown status %q", status)
+	}
+}
+
+// taskPriority maps an iCalendar priority back to a task priority: 1-4 are
+// high, 5 is medium and 6-9 are low.
+func taskPriority(value string) (string, error) {
+	priority, err := strconv.Atoi(value)
+	switch {
+	case err != nil || priority < 0 || priority > 9:
+		return "", fmt.Errorf("invalid priority %q", value)
+	case priority == 0:
+		return "", nil
+	case priority < 5:
+		return string(domain.TaskPriorityHigh), nil
+	case priority == 5:
+		return string(domain.TaskPriorityMedium), nil
+	default:
+		return string(domain.TaskPriorityLow), nil
+	}
+}
+
+// newICSReader reads the to-dos of an iCalendar file as rows. Events and
+// other components are skipped; statuses are mapped to those of the workflow.
+func newICSReader(r io.Reader, mapping map[string]string, workflow domain.Workflow) (*Reader, error) {
+	if len(mapping) > 0 {
+		return nil, domain.Invalid("Mapping", "iCalendar files have fixed properties and take no mapping")
+	}
+
+	lines := &contentLines{scanner: bufio.NewScanner(r)}
+	lines.scanner.Buffer(make([]byte, 0, 64*1024), maxLineLength)
+
+	first, _, err := lines.next()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, domain.Invalid("file", "read file: %v", err)
+	}
+	if !strings.EqualFold(first, "BEGIN:"+icsCalendar) {
+		return nil, domain.Invalid("file", "not an iCalendar file: it must start with BEGIN:%s", icsCalendar)
+	}
+
+	components := []string{icsCalendar}
+
+	return &Reader{read: func() (Row, error) {
+		if len(components) == 0 {
+			// Anything after the calendar is ignored.
+			return Row{}, io.EOF
+		}
+
+		var row Row
+		for {
+			line, number, err := lines.next()
+			if errors.Is(err, io.EOF) {
+				return Row{}, fmt.Errorf("the file ends inside %s", components[len(components)-1])
+			}
+			if err != nil {
+				return Row{}, err
+			}
+
+			name, params, value, err := parseContentLine(line)
+			if err != nil {
+				if row.Fields != nil {
+					row.Err = errors.Join(row.Err, fmt.Errorf("line %d: %v", number, err))
+					continue
+				}
+				return Row{}, fmt.Errorf("line %d: %v", number, err)
+			}
+
+			switch name {
+			case "BEGIN":
+				component := strings.ToUpper(value)
+				if component == icsTodo && len(components) == 1 {
+					row = Row{Line: number, Fields: map[string]string{}}
+				}
+				components = append(components, component)
+				continue
+			case "END":
+				component := strings.ToUpper(value)
+				if components[len(components)-1] != component {
+					return Row{}, fmt.Errorf("line %d: END:%s does not close %s", number, value, components[len(components)-1])
+				}
+				components = components[:len(components)-1]
+
+				if component == icsTodo && len(components) == 1 {
+					return row, nil
+				}
+				if len(components) == 0 {
+					return Row{}, io.EOF
+				}
+				continue
+			}
+
+			// Properties of nested components such as alarms are not task
+			// fields.
+			if row.Fields == nil || len(components) != 2 {
+				continue
+			}
+			if err := setTodoProperty(row.Fields, workflow, name, params, value); err != nil {
+				row.Err = errors.Join(row.Err, fmt.Errorf("%s: %v", name, err))
+			}
+		}
+	}}, nil
+}
+
+// setTodoProperty stores a VTODO property in the fields of a row.
+func setTodoProperty(fields map[string]string, workflow domain.Workflow, name string, params map[string]string, value string) error {
+	switch name {
+	case "UID":
+		fields["ExternalID"] = strings.TrimSpace(unescapeText(value))
+	case "SUMMARY":
+		fields["Title"] = strings.TrimSpace(unescapeText(value))
+	case "DESCRIPTION":
+		fields["Description"] = strings.TrimSpace(unescapeText(value))
+	case "STATUS":
+		status, err := taskStatus(workflow, value)
+		if err != nil {
+			return err
+		}
+		fields["Status"] = status
+	case "PRIORITY":
+		priority, err := taskPriority(value)
+		if err != nil {
+			return err
+		}
+		fields["Priority"] = priority
+	case "CATEGORIES":
+		// CATEGORIES may be repeated; every occurrence adds tags.
+		tags := splitText(value)
+		if existing := fields["Tags"]; existing != "" {
+			tags = append([]string{existing}, tags...)
+		}
+		fields["Tags"] = strings.Join(tags, ListSeparator)
+	case "DUE":
+		due, err := parseICSTime(value, params)
+		if err != nil {
+			return err
+		}
+		fields["DueAt"] = due
+	}
+	return nil
+}
+
+// parseICSTime converts a DATE or DATE-TIME value into the forms an import
+// accepts. Local times use their TZID, or UTC when they have none.
+func parseICSTime(value string, params map[string]string) (string, error) {
+	if strings.EqualFold(params["VALUE"], "DATE") || len(value) == len(icsDate) {
+		date, err := time.Parse(icsDate, value)
+		if err != nil {
+			return "", fmt.Errorf("invalid date %q", value)
+		}
+		return date.Format(time.DateOnly), nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(icsDateTime, value)
+		if err != nil {
+			return "", fmt.Errorf("invalid date-time %q", value)
+		}
+		return t.Format(time.RFC3339), nil
+	}
+
+	location := time.UTC
+	if tzid := params["TZID"]; tzid != "" {
+		var err error
+		if location, err = time.LoadLocation(tzid); err != nil {
+			return "", fmt.Errorf("unknown time zone %q", tzid)
+		}
+	}
+
+	t, err := time.ParseInLocation(icsLocalTime, value, location)
+	if err != nil {
+		return "", fmt.Errorf("invalid date-time %q", value)
+	}
+	return t.UTC().Format(time.RFC3339), nil
+}
+
+// parseContentLine splits a content line into its upper-cased name, its
+// parameters and its raw value.
+func parseContentLine(line string) (string, map[string]string, string, error) {
+	end := strings.IndexAny(line, ";:")
+	if end <= 0 {
+		return "", nil, "", fmt.Errorf("invalid content line %q", line)
+	}
+	name := strings.ToUpper(line[:end])
+	params := map[string]string{}
+
+	rest := line[end:]
+	for strings.HasPrefix(rest, ";") {
+		rest = rest[1:]
+
+		key, value, ok := strings.Cut(rest, "=")
+		if !ok || key == "" {
+			return "", nil, "", fmt.Errorf("invalid parameter in %s", name)
+		}
+
+		if strings.HasPrefix(value, `"`) {
+			closing := strings.IndexByte(value[1:], '"')
+			if closing < 0 {
+				return "", nil, "", fmt.Errorf("unterminated quote in %s", name)
+			}
+			params[strings.ToUpper(key)] = value[1 : closing+1]
+			rest = value[closing+2:]
+		} else {
+			end := strings.IndexAny(value, ";:")
+			if end < 0 {
+				return "", nil, "", fmt.Errorf("missing value in %s", name)
+			}
+			params[strings.ToUpper(key)] = value[:end]
+			rest = value[end:]
+		}
+	}
+
+	if !strings.HasPrefix(rest, ":") {
+		return "", nil, "", fmt.Errorf("missing value in %s", name)
+	}
+	return name, params, rest[1:], nil
+}
+
+// unescapeText reverses the escaping of TEXT values.
+func unescapeText(value string) string {
+	var b strings.Builder
+	escaped := false
+	for _, r := range value {
+		switch {
+		case escaped && (r == 'n' || r == 'N'):
+			b.WriteByte('\n')
+		case escaped:
+			b.WriteRune(r)
+		case r == '\\':
+			escaped = true
+			continue
+		default:
+			b.WriteRune(r)
+		}
+		escaped = false
+	}
+	return b.String()
+}
+
+// splitText splits a list of TEXT values on unescaped commas and unescapes
+// every item, dropping empty ones.
+func splitText(value string) []string {
+	var items []string
+	start := 0
+	escaped := false
+	for i, r := range value + "," {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ',':
+			if item := strings.TrimSpace(unescapeText(value[start:i])); item != "" {
+				items = append(items, item)
+			}
+			start = i + 1
+		}
+	}
+	return items
+}
+
+// contentLines reads the unfolded content lines of an iCalendar file.
+type contentLines struct {
+	scanner  *bufio.Scanner
+	line     int
+	pending  string
+	buffered bool
+}
+
+// next returns the next content line and the line it starts on, skipping
+// blank lines.
+func (c *contentLines) next() (string, int, error) {
+	for !c.buffered || c.pending == "" {
+		if !c.scan() {
+			if err := c.scanner.Err(); err != nil {
+				return "", 0, err
+			}
+			return "", 0, io.EOF
+		}
+	}
+
+	line, start := c.pending, c.line
+	c.buffered = false
+
+	// Lines starting with a space or a tab continue the previous one.
+	for c.scan() {
+		if c.pending == "" || (c.pending[0] != ' ' && c.pending[0] != '\t') {
+			return line, start, nil
+		}
+		line += c.pending[1:]
+		c.buffered = false
+	}
+	if err := c.scanner.Err(); err != nil {
+		return "", 0, err
+	}
+	return line, start, nil
+}
+
+func (c *contentLines) scan() bool {
+	if !c.scanner.Scan() {
+		c.buffered = false
+		return false
+	}
+	c.line++
+	c.pending = c.scanner.Text()
+	c.buffered = true
+	return true
+}
@@ -0,0 +1,210 @@
+package taskio
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+)
+
+// maxLineLength bounds a single JSON Lines record.
+const maxLineLength = 1 << 20
+
+// Row is one record of an import file.
+type Row struct {
+	// Line is where the row starts in the file, counting from 1.
+	Line int
+	// Fields holds the raw value of each task field present in the row, keyed
+	// by the names in domain.ImportFields. List values are joined with
+	// ListSeparator.
+	Fields map[string]string
+	// Err reports a row that could not be read; the rest of the file can
+	// still be.
+	Err error
+}
+
+// Reader reads the rows of an import file.
+type Reader struct {
+	read func() (Row, error)
+}
+
+// NewReader returns a reader for the given import format. mapping names the
+// column or member each task field is read from; unmapped fields use the
+// field name, compared case-insensitively. Statuses of formats with their own,
+// such as iCalendar, are mapped to those of the workflow.
+func NewReader(format domain.ImportFormat, r io.Reader, mapping map[string]string, workflow domain.Workflow) (*Reader, error) {
+	for field := range mapping {
+		if !slices.Contains(domain.ImportFields, field) {
+			return nil, domain.Invalid("Mapping", "unknown field %q; fields are %s", field, strings.Join(domain.ImportFields, ", "))
+		}
+	}
+
+	switch format {
+	case domain.ImportFormatCSV:
+		return newCSVReader(r, mapping)
+	case domain.ImportFormatJSONL:
+		return newJSONLReader(r, mapping), nil
+	case domain.ImportFormatICS:
+		return newICSReader(r, mapping, workflow)
+	default:
+		return nil, domain.Invalid("Format", "unsupported import format %q", format)
+	}
+}
+
+// Read returns the next row, or io.EOF after the last one. Other errors mean
+// the file cannot be read any further.
+func (r *Reader) Read() (Row, error) {
+	return r.read()
+}
+
+// source returns the column or member a field is read from.
+func source(mapping map[string]string, field string) string {
+	if name, ok := mapping[field]; ok {
+		return name
+	}
+	return field
+}
+
+func newCSVReader(r io.Reader, mapping map[string]string) (*Reader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, domain.Invalid("file", "the file is empty; a header row is required")
+	}
+	if err != nil {
+		return nil, domain.Invalid("file", "invalid header row: %v", err)
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+
+	columns := map[string]int{}
+	for _, field := range domain.ImportFields {
+		name := source(mapping, field)
+		index := slices.IndexFunc(header, func(column string) bool {
+			return strings.EqualFold(strings.TrimSpace(column), name)
+		})
+
+		switch {
+		case index >= 0:
+			columns[field] = index
+		case mapping[field] != "":
+			return nil, domain.Invalid("Mapping", "column %q mapped to %s is not in the header", name, field)
+		}
+	}
+
+	return &Reader{read: func() (Row, error) {
+		record, err := reader.Read()
+
+		var parseErr *csv.ParseError
+		switch {
+		case errors.As(err, &parseErr):
+			return Row{Line: parseErr.StartLine, Err: parseErr.Err}, nil
+		case err != nil:
+			return Row{}, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := Row{Line: line, Fields: map[string]string{}}
+		for field, index := range columns {
+			if index < len(record) {
+				row.Fields[field] = strings.TrimSpace(record[index])
+			}
+		}
+		return row, nil
+	}}, nil
+}
+
+func newJSONLReader(r io.Reader, mapping map[string]string) *Reader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineLength)
+	line := 0
+
+	return &Reader{read: func() (Row, error) {
+		for scanner.Scan() {
+			line++
+			data := bytes.TrimSpace(scanner.Bytes())
+			if len(data) == 0 {
+				continue
+			}
+
+			var members map[string]json.RawMessage
+			if err := json.Unmarshal(data, &members); err != nil {
+				return Row{Line: line, Err: fmt.Errorf("invalid JSON object: %v", err)}, nil
+			}
+
+			row := Row{Line: line, Fields: map[string]string{}}
+			for _, field := range domain.ImportFields {
+				raw, ok := member(members, source(mapping, field))
+				if !ok {
+					continue
+				}
+
+				value, err := jsonValue(raw)
+				if err != nil {
+					return Row{Line: line, Err: fmt.Errorf("%s: %v", field, err)}, nil
+				}
+				row.Fields[field] = value
+			}
+			return row, nil
+		}
+
+		if err := scanner.Err(); err != nil {
+			return Row{}, err
+		}
+		return Row{}, io.EOF
+	}}
+}
+
+// member looks a member up by name, preferring an exact match.
+func member(members map[string]json.RawMessage, name string) (json.RawMessage, bool) {
+	if raw, ok := members[name]; ok {
+		return raw, true
+	}
+	for key, raw := range members {
+		if strings.EqualFold(key, name) {
+			return raw, true
+		}
+	}
+	return nil, false
+}
+
+// jsonValue turns a JSON value into the raw string form of a field: strings
+// as they are, lists of strings joined with ListSeparator, null as empty.
+func jsonValue(raw json.RawMessage) (string, error) {
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", err
+	}
+
+	switch value := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return strings.TrimSpace(value), nil
+	case float64, bool:
+		return string(raw), nil
+	case []any:
+		items := make([]string, 0, len(value))
+		for _, item := range value {
+			text, ok := item.(string)
+			if !ok {
+				return "", errors.New("list items must be strings")
+			}
+			items = append(items, text)
+		}
+		return strings.Join(items, ListSeparator), nil
+	default:
+		return "", errors.New("objects are not supported")
+	}
+}
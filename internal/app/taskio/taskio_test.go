@@ -0,0 +1,326 @@
+package taskio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/google/uuid"
+)
+
+func readAll(t *testing.T, reader *Reader) []Row {
+	t.Helper()
+
+	var rows []Row
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows
+		}
+		if err != nil {
+			t.Fatalf("Failed to read row: %v", err)
+		}
+		rows = append(rows, row)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	dueAt := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	task := domain.Task{
+		ID:          uuid.MustParse("6b1f2a9e-4d4b-4c8e-9f55-0b7c1d2e3f40"),
+		Key:         "OPS-7",
+		ProjectID:   domain.DefaultProjectID,
+		Title:       "Rotate keys | staging",
+		Description: "First line\nsecond line",
+		Status:      domain.TaskStatusToDo,
+		Priority:    domain.TaskPriorityHigh,
+		Assignees:   []string{"alice", "bob"},
+		Tags:        []string{"security"},
+		DueAt:       &dueAt,
+		CreatedAt:   dueAt,
+		UpdatedAt:   dueAt,
+		ExternalID:  "SHEET-12",
+	}
+
+	t.Run("csv round-trips through the reader", func(t *testing.T) {
+		var buf bytes.Buffer
+		writer, err := NewWriter(domain.ExportFormatCSV, &buf, domain.DefaultWorkflow())
+		if err != nil {
+			t.Fatalf("Failed to create writer: %v", err)
+		}
+		if err := writer.Write(task); err != nil {
+			t.Fatalf("Failed to write task: %v", err)
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatalf("Failed to close writer: %v", err)
+		}
+
+		reader, err := NewReader(domain.ImportFormatCSV, &buf, nil, domain.DefaultWorkflow())
+		if err != nil {
+			t.Fatalf("Failed to create reader: %v", err)
+		}
+
+		rows := readAll(t, reader)
+		if len(rows) != 1 {
+			t.Fatalf("Expected 1 row, got %d", len(rows))
+		}
+
+		expected := map[string]string{
+			"ExternalID":  "SHEET-12",
+			"ProjectID":   domain.DefaultProjectID.String(),
+			"Title":       "Rotate keys | staging",
+			"Description": "First line\nsecond line",
+			"Status":      "to_do",
+			"Priority":    "high",
+			"Assignees":   "alice;bob",
+			"Tags":        "security",
+			"DueAt":       "2025-03-01T12:00:00Z",
+		}
+		if !reflect.DeepEqual(rows[0].Fields, expected) || rows[0].Line != 2 {
+			t.Errorf("Expected %v on line 2, got %v on line %d", expected, rows[0].Fields, rows[0].Line)
+		}
+	})
+
+	t.Run("markdown escapes table syntax", func(t *testing.T) {
+		var buf bytes.Buffer
+		writer, _ := NewWriter(domain.ExportFormatMarkdown, &buf, domain.DefaultWorkflow())
+		_ = writer.Write(task)
+		_ = writer.Close()
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != 3 {
+			t.Fatalf("Expected a header, a separator and a row, got %q", buf.String())
+		}
+		if !strings.Contains(lines[2], `Rotate keys \| staging`) || !strings.Contains(lines[2], "alice, bob") {
+			t.Errorf("Expected the row to be escaped, got %q", lines[2])
+		}
+	})
+
+	t.Run("jsonl writes one task per line", func(t *testing.T) {
+		var buf bytes.Buffer
+		writer, _ := NewWriter(domain.ExportFormatJSONL, &buf, domain.DefaultWorkflow())
+		_ = writer.Write(task)
+		_ = writer.Write(task)
+		_ = writer.Close()
+
+		if lines := strings.Count(buf.String(), "\n"); lines != 2 {
+			t.Errorf("Expected 2 lines, got %d", lines)
+		}
+	})
+
+	t.Run("empty csv exports keep their header", func(t *testing.T) {
+		var buf bytes.Buffer
+		writer, _ := NewWriter(domain.ExportFormatCSV, &buf, domain.DefaultWorkflow())
+		_ = writer.Close()
+
+		if !strings.HasPrefix(buf.String(), "ID,Key,ExternalID,") {
+			t.Errorf("Expected a header row, got %q", buf.String())
+		}
+	})
+}
+
+func TestReader(t *testing.T) {
+	t.Run("csv columns are mapped", func(t *testing.T) {
+		data := "Ref,Summary,State,Owner\nA-1,Buy milk,done,alice; bob\n"
+		reader, err := NewReader(domain.ImportFormatCSV, strings.NewReader(data), map[string]string{
+			"ExternalID": "Ref",
+			"Title":      "summary",
+			"Status":     "State",
+			"Assignees":  "Owner",
+		}, domain.DefaultWorkflow())
+		if err != nil {
+			t.Fatalf("Failed to create reader: %v", err)
+		}
+
+		rows := readAll(t, reader)
+		expected := map[string]string{"ExternalID": "A-1", "Title": "Buy milk", "Status": "done", "Assignees": "alice; bob"}
+		if len(rows) != 1 || !reflect.DeepEqual(rows[0].Fields, expected) {
+			t.Errorf("Expected %v, got %+v", expected, rows)
+		}
+	})
+
+	t.Run("bad mappings are refused", func(t *testing.T) {
+		for name, mapping := range map[string]map[string]string{
+			"unknown field":  {"Owner": "Assignee"},
+			"missing column": {"Title": "Summary"},
+		} {
+			if _, err := NewReader(domain.ImportFormatCSV, strings.NewReader("Title\nx\n"), mapping, domain.DefaultWorkflow()); !errors.Is(err, domain.ErrValidation) {
+				t.Errorf("Expected %s to be refused, got %v", name, err)
+			}
+		}
+	})
+
+	t.Run("jsonl rows report their own errors", func(t *testing.T) {
+		data := `{"Title": "Plan", "Tags": ["q1", "planning"], "DueAt": null}` + "\n\n" +
+			`{"Title": ` + "\n" +
+			`{"summary": "Ship", "Tags": [1]}` + "\n"
+		reader, err := NewReader(domain.ImportFormatJSONL, strings.NewReader(data), map[string]string{"Title": "summary"}, domain.DefaultWorkflow())
+		if err != nil {
+			t.Fatalf("Failed to create reader: %v", err)
+		}
+
+		rows := readAll(t, reader)
+		if len(rows) != 3 {
+			t.Fatalf("Expected 3 rows, got %d", len(rows))
+		}
+
+		// The first row has no "summary" member, so it has no title.
+		if rows[0].Err != nil || rows[0].Fields["Tags"] != "q1;planning" || rows[0].Fields["DueAt"] != "" {
+			t.Errorf("Expected the first row to be read, got %+v", rows[0])
+		}
+		if rows[1].Err == nil || rows[1].Line != 3 {
+			t.Errorf("Expected invalid JSON on line 3, got %+v", rows[1])
+		}
+		if rows[2].Err == nil || rows[2].Line != 4 {
+			t.Errorf("Expected a list of numbers to be refused on line 4, got %+v", rows[2])
+		}
+	})
+}
+
+func TestICS(t *testing.T) {
+	dueAt := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	task := domain.Task{
+		ID:          uuid.MustParse("6b1f2a9e-4d4b-4c8e-9f55-0b7c1d2e3f40"),
+		Title:       "Rotate keys; then, " + strings.Repeat("verify every service ", 4),
+		Description: "First line\nsecond line",
+		Status:      domain.TaskStatusInProgress,
+		Priority:    domain.TaskPriorityMedium,
+		Tags:        []string{"security", "ops,infra"},
+		DueAt:       &dueAt,
+		CreatedAt:   dueAt,
+		UpdatedAt:   dueAt,
+		Version:     3,
+	}
+
+	var buf bytes.Buffer
+	writer, err := NewWriter(domain.ExportFormatICS, &buf, domain.DefaultWorkflow())
+	if err != nil {
+		t.Fatalf("Failed to create writer: %v", err)
+	}
+	if err := writer.Write(task); err != nil {
+		t.Fatalf("Failed to write task: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to close writer: %v", err)
+	}
+
+	t.Run("content lines are folded", func(t *testing.T) {
+		output := buf.String()
+		if !strings.HasPrefix(output, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(output, "END:VCALENDAR\r\n") {
+			t.Fatalf("Expected a calendar, got %q", output)
+		}
+		for _, line := range strings.Split(output, "\r\n") {
+			if len(line) > 75 {
+				t.Errorf("Expected lines of at most 75 octets, got %q", line)
+			}
+		}
+		for _, property := range []string{"STATUS:IN-PROCESS", "PRIORITY:5", "DUE:20250301T120000Z", "SEQUENCE:2", `CATEGORIES:security,ops\,infra`} {
+			if !strings.Contains(output, "\r\n"+property+"\r\n") {
+				t.Errorf("Expected %s, got %q", property, output)
+			}
+		}
+	})
+
+	t.Run("to-dos round-trip through the reader", func(t *testing.T) {
+		reader, err := NewReader(domain.ImportFormatICS, &buf, nil, domain.DefaultWorkflow())
+		if err != nil {
+			t.Fatalf("Failed to create reader: %v", err)
+		}
+
+		rows := readAll(t, reader)
+		if len(rows) != 1 || rows[0].Err != nil {
+			t.Fatalf("Expected 1 row, got %+v", rows)
+		}
+
+		expected := map[string]string{
+			"ExternalID":  task.ID.String(),
+			"Title":       strings.TrimSpace(task.Title),
+			"Description": "First line\nsecond line",
+			"Status":      "in_progress",
+			"Priority":    "medium",
+			"Tags":        "security;ops,infra",
+			"DueAt":       "2025-03-01T12:00:00Z",
+		}
+		if !reflect.DeepEqual(rows[0].Fields, expected) || rows[0].Line != 6 {
+			t.Errorf("Expected %v on line 6, got %v on line %d", expected, rows[0].Fields, rows[0].Line)
+		}
+	})
+
+	t.Run("other components and bad to-dos", func(t *testing.T) {
+		data := strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"BEGIN:VEVENT",
+			"SUMMARY:Standup",
+			"END:VEVENT",
+			"BEGIN:VTODO",
+			"UID:a-1",
+			"SUMMARY:Call the",
+			"  bank",
+			"DUE;TZID=Europe/Amsterdam:20250301T090000",
+			"PRIORITY:3",
+			"BEGIN:VALARM",
+			"SUMMARY:Alarm",
+			"END:VALARM",
+			"END:VTODO",
+			"BEGIN:VTODO",
+			"SUMMARY:Dropped",
+			"STATUS:CANCELLED",
+			"DUE;VALUE=DATE:20250302",
+			"END:VTODO",
+			"END:VCALENDAR",
+		}, "\r\n")
+
+		reader, err := NewReader(domain.ImportFormatICS, strings.NewReader(data), nil, domain.DefaultWorkflow())
+		if err != nil {
+			t.Fatalf("Failed to create reader: %v", err)
+		}
+
+		rows := readAll(t, reader)
+		if len(rows) != 2 {
+			t.Fatalf("Expected 2 rows, got %+v", rows)
+		}
+
+		expected := map[string]string{"ExternalID": "a-1", "Title": "Call the bank", "DueAt": "2025-03-01T08:00:00Z", "Priority": "high"}
+		if rows[0].Err != nil || !reflect.DeepEqual(rows[0].Fields, expected) {
+			t.Errorf("Expected %v, got %+v", expected, rows[0])
+		}
+		if rows[1].Err == nil || rows[1].Line != 15 || rows[1].Fields["DueAt"] != "2025-03-02" {
+			t.Errorf("Expected the cancelled to-do on line 15 to fail, got %+v", rows[1])
+		}
+	})
+
+	t.Run("files that are not calendars are refused", func(t *testing.T) {
+		if _, err := NewReader(domain.ImportFormatICS, strings.NewReader("Title\nx\n"), nil, domain.DefaultWorkflow()); !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("Expected a validation error, got %v", err)
+		}
+		if _, err := NewReader(domain.ImportFormatICS, strings.NewReader("BEGIN:VCALENDAR\n"), map[string]string{"Title": "X"}, domain.DefaultWorkflow()); !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("Expected a mapping to be refused, got %v", err)
+		}
+	})
+
+	t.Run("statuses follow the workflow", func(t *testing.T) {
+		workflow := domain.Workflow{
+			InitialStatus:   "backlog",
+			Statuses:        []domain.TaskStatus{"backlog", "review", "shipped"},
+			DoneStatuses:    []domain.TaskStatus{"shipped"},
+			StartedStatuses: []domain.TaskStatus{"review"},
+		}
+
+		statuses := map[domain.TaskStatus]string{"backlog": "NEEDS-ACTION", "review": "IN-PROCESS", "shipped": "COMPLETED"}
+		for status, expected := range statuses {
+			if got := icsStatus(workflow, status); got != expected {
+				t.Errorf("Expected %s for %s, got %s", expected, status, got)
+			}
+
+			got, err := taskStatus(workflow, expected)
+			if err != nil || got != string(status) {
+				t.Errorf("Expected %s for %s, got %q (%v)", status, expected, got, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,176 @@
+// Package taskio reads and writes tasks as CSV, JSON Lines, Markdown and
+// iCalendar files.
+package taskio
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+)
+
+// ListSeparator separates the values of list fields such as Assignees and
+// Tags in a single column.
+const ListSeparator = ";"
+
+// Writer writes tasks one at a time. Close must be called to flush the output.
+type Writer interface {
+	Write(task domain.Task) error
+	Close() error
+}
+
+// NewWriter returns a writer for the given export format. Formats with
+// statuses of their own, such as iCalendar, map the workflow's to them.
+func NewWriter(format domain.ExportFormat, w io.Writer, workflow domain.Workflow) (Writer, error) {
+	switch format {
+	case domain.ExportFormatCSV:
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	case domain.ExportFormatJSONL:
+		buffered := bufio.NewWriter(w)
+		return &jsonlWriter{w: buffered, encoder: json.NewEncoder(buffered)}, nil
+	case domain.ExportFormatMarkdown:
+		return &markdownWriter{w: bufio.NewWriter(w)}, nil
+	case domain.ExportFormatICS:
+		return &icsWriter{w: bufio.NewWriter(w), workflow: workflow}, nil
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// ContentType returns the media type of an export format.
+func ContentType(format domain.ExportFormat) string {
+	switch format {
+	case domain.ExportFormatCSV:
+		return "text/csv; charset=utf-8"
+	case domain.ExportFormatJSONL:
+		return "application/jsonl; charset=utf-8"
+	case domain.ExportFormatMarkdown:
+		return "text/markdown; charset=utf-8"
+	case domain.ExportFormatICS:
+		return "text/calendar; charset=utf-8"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// csvColumns are the columns of a CSV export. The fields an import reads come
+// back under the same names.
+var csvColumns = []string{
+	"ID", "Key", "ExternalID", "ProjectID", "Title", "Description", "Status", "Priority",
+	"Assignees", "Tags", "DueAt", "CreatedAt", "UpdatedAt",
+}
+
+type csvWriter struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func (c *csvWriter) Write(task domain.Task) error {
+	if !c.headerWritten {
+		if err := c.w.Write(csvColumns); err != nil {
+			return err
+		}
+		c.headerWritten = true
+	}
+
+	return c.w.Write([]string{
+		task.ID.String(),
+		task.Key,
+		task.ExternalID,
+		task.ProjectID.String(),
+		task.Title,
+		task.Description,
+		string(task.Status),
+		string(task.Priority),
+		strings.Join(task.Assignees, ListSeparator),
+		strings.Join(task.Tags, ListSeparator),
+		formatTime(task.DueAt),
+		task.CreatedAt.UTC().Format(time.RFC3339),
+		task.UpdatedAt.UTC().Format(time.RFC3339),
+	})
+}
+
+func (c *csvWriter) Close() error {
+	// An empty export still gets its header, so it can be imported.
+	if !c.headerWritten {
+		if err := c.w.Write(csvColumns); err != nil {
+			return err
+		}
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type jsonlWriter struct {
+	w       *bufio.Writer
+	encoder *json.Encoder
+}
+
+func (j *jsonlWriter) Write(task domain.Task) error {
+	// Encode terminates every value with a newline.
+	return j.encoder.Encode(task)
+}
+
+func (j *jsonlWriter) Close() error {
+	return j.w.Flush()
+}
+
+type markdownWriter struct {
+	w             *bufio.Writer
+	headerWritten bool
+}
+
+func (m *markdownWriter) writeHeader() error {
+	m.headerWritten = true
+	_, err := m.w.WriteString("| Key | Title | Status | Priority | Assignees | Tags | Due |\n" +
+		"| --- | --- | --- | --- | --- | --- | --- |\n")
+	return err
+}
+
+func (m *markdownWriter) Write(task domain.Task) error {
+	if !m.headerWritten {
+		if err := m.writeHeader(); err != nil {
+			return err
+		}
+	}
+
+	cells := []string{
+		task.Key,
+		task.Title,
+		string(task.Status),
+		string(task.Priority),
+		strings.Join(task.Assignees, ", "),
+		strings.Join(task.Tags, ", "),
+		formatTime(task.DueAt),
+	}
+	for i, cell := range cells {
+		cells[i] = markdownEscaper.Replace(cell)
+	}
+
+	_, err := m.w.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	return err
+}
+
+func (m *markdownWriter) Close() error {
+	if !m.headerWritten {
+		if err := m.writeHeader(); err != nil {
+			return err
+		}
+	}
+	return m.w.Flush()
+}
+
+// markdownEscaper keeps cell contents from breaking the table.
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>", "\r", "<br>")
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
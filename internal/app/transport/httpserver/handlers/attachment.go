@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/alexgolang/ishare-task/internal/app/common/server"
+	"github.com/alexgolang/ishare-task/internal/app/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type AttachmentHandler struct {
+	logger            *log.Logger
+	attachmentService *service.AttachmentService
+}
+
+func NewAttachmentHandler(logger *log.Logger, attachmentService *service.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{
+		logger:            logger,
+		attachmentService: attachmentService,
+	}
+}
+
+// UploadAttachment godoc
+// @Summary Attach a file to a task
+// @Description Upload a file as the "file" field of a multipart form; size and content type are limited by configuration
+// @Tags attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Task ID (UUID)"
+// @Param file formData file true "File to attach"
+// @Success 201 {object} domain.Attachment "Attachment stored; Location points to it"
+// @Failure 400 {object} server.Problem "Bad request"
+// @Failure 404 {object} server.Problem "Task not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/attachments [post]
+func (h *AttachmentHandler) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		server.RespondBadRequest("Expected a multipart/form-data body", w, r)
+		return
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			server.RespondBadRequest(`Multipart field "file" is required`, w, r)
+			return
+		}
+		if err != nil {
+			server.RespondBadRequest("Invalid multipart body: "+err.Error(), w, r)
+			return
+		}
+
+		if part.FormName() != "file" {
+			_ = part.Close()
+			continue
+		}
+
+		attachment, err := h.attachmentService.UploadAttachment(r.Context(), taskID, clientID(r), part.FileName(), part)
+		_ = part.Close()
+
+		if err != nil {
+			server.RespondError(h.logger, err, w, r)
+			return
+		}
+
+		server.RespondCreated("/tasks/"+attachment.TaskID.String()+"/attachments/"+attachment.ID.String(), attachment, w, r)
+		return
+	}
+}
+
+// ListAttachments godoc
+// @Summary List attachments of a task
+// @Description Get the metadata of all files attached to a task
+// @Tags attachments
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID (UUID)"
+// @Success 200 {array} domain.Attachment "List of attachments"
+// @Failure 404 {object} server.Problem "Task not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/attachments [get]
+func (h *AttachmentHandler) ListAttachments(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+
+	attachments, err := h.attachmentService.ListAttachments(r.Context(), taskID)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(attachments, w, r)
+}
+
+// DownloadAttachment godoc
+// @Summary Download an attachment
+// @Description Stream the content of an attachment with its original filename
+// @Tags attachments
+// @Produce octet-stream
+// @Param id path string true "Task ID (UUID)"
+// @Param attachmentId path string true "Attachment ID (UUID)"
+// @Success 200 {file} file "Attachment content"
+// @Failure 404 {object} server.Problem "Attachment not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/attachments/{attachmentId} [get]
+func (h *AttachmentHandler) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+	attachmentID := chi.URLParam(r, "attachmentId")
+
+	attachment, content, err := h.attachmentService.OpenAttachment(r.Context(), taskID, attachmentID)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.SizeBytes, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("ETag", fmt.Sprintf("%q", attachment.SHA256))
+	w.WriteHeader(http.StatusOK)
+
+	_, _ = io.Copy(w, content)
+}
+
+// DeleteAttachment godoc
+// @Summary Delete an attachment
+// @Description Remove an attachment and its stored content
+// @Tags attachments
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID (UUID)"
+// @Param attachmentId path string true "Attachment ID (UUID)"
+// @Success 200 {object} map[string]string "Attachment deleted successfully"
+// @Failure 404 {object} server.Problem "Attachment not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/attachments/{attachmentId} [delete]
+func (h *AttachmentHandler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+	attachmentID := chi.URLParam(r, "attachmentId")
+
+	err := h.attachmentService.DeleteAttachment(r.Context(), taskID, attachmentID)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(fmt.Sprintf("Attachment %s deleted", attachmentID), w, r)
+}
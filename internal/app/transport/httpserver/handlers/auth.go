@@ -1,8 +1,8 @@
 package handlers
 
 import (
-	"errors"
 	"fmt"
+	"log"
 	"net/http"
 
 	"github.com/alexgolang/ishare-task/internal/app/auth"
@@ -11,36 +11,39 @@ import (
 )
 
 type AuthHandler struct {
+	logger     *log.Logger
 	JWTService *auth.JWTService
 }
 
-func NewAuthHandler(jwtService *auth.JWTService) *AuthHandler {
+func NewAuthHandler(logger *log.Logger, jwtService *auth.JWTService) *AuthHandler {
 	return &AuthHandler{
+		logger:     logger,
 		JWTService: jwtService,
 	}
 }
 
 func (h *AuthHandler) GetToken(w http.ResponseWriter, r *http.Request) {
 	if err := r.ParseForm(); err != nil {
-		server.RespondError(err, w, r)
+		server.RespondBadRequest(err.Error(), w, r)
 		return
 	}
 
 	grantType := r.FormValue("grant_type")
 	if grantType != "client_credentials" {
-		server.RespondError(errors.New("unsupported grant_type"), w, r)
+		server.RespondBadRequest("unsupported grant_type", w, r)
 		return
 	}
 
 	clientAssertion := r.FormValue("client_assertion")
 	if clientAssertion == "" {
-		server.RespondError(errors.New("client_assertion is required"), w, r)
+		server.RespondBadRequest("client_assertion is required", w, r)
 		return
 	}
 
 	clientAssertionType := r.FormValue("client_assertion_type")
 	if clientAssertionType == "" {
-		server.RespondError(errors.New("client_assertion_type is required"), w, r)
+		server.RespondBadRequest("client_assertion_type is required", w, r)
+		return
 	}
 
 	claims, err := h.JWTService.ValidateClientAssertion(clientAssertion, clientAssertionType)
@@ -57,7 +60,7 @@ func (h *AuthHandler) GetToken(w http.ResponseWriter, r *http.Request) {
 
 	accessToken, err := h.JWTService.CreateAccessToken(clientID)
 	if err != nil {
-		server.RespondError(fmt.Errorf("failed to create access token: %w", err), w, r)
+		server.RespondError(h.logger, fmt.Errorf("failed to create access token: %w", err), w, r)
 		return
 	}
 
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/alexgolang/ishare-task/internal/app/common/server"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/service"
+)
+
+type BulkHandler struct {
+	logger      *log.Logger
+	bulkService *service.BulkService
+}
+
+func NewBulkHandler(logger *log.Logger, bulkService *service.BulkService) *BulkHandler {
+	return &BulkHandler{
+		logger:      logger,
+		bulkService: bulkService,
+	}
+}
+
+// BulkTasks godoc
+// @Summary Apply bulk task operations
+// @Description Create, update and delete many tasks in one transaction, or apply a patch to every task matching a filter (e.g. set status=done where tag=release-1.4). In atomic mode (default) a failing operation rolls back the whole request; in best_effort mode only the failing operations are skipped. The response holds one result per operation.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param request body domain.BulkRequest true "Operations, or a filter and a patch"
+// @Success 200 {object} domain.BulkResult "Per-operation results"
+// @Failure 400 {object} server.Problem "Bad request"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/bulk [post]
+func (h *BulkHandler) BulkTasks(w http.ResponseWriter, r *http.Request) {
+	var req domain.BulkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		server.RespondBadRequest(err.Error(), w, r)
+		return
+	}
+
+	result, err := h.bulkService.Apply(r.Context(), &req)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(result, w, r)
+}
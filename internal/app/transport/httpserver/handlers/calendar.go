@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/alexgolang/ishare-task/internal/app/common/server"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type CalendarHandler struct {
+	logger          *log.Logger
+	calendarService *service.CalendarService
+	// publicURL is the address clients reach the API on, used in feed URLs.
+	// When empty it is taken from the request.
+	publicURL string
+}
+
+func NewCalendarHandler(logger *log.Logger, calendarService *service.CalendarService, publicURL string) *CalendarHandler {
+	return &CalendarHandler{
+		logger:          logger,
+		calendarService: calendarService,
+		publicURL:       strings.TrimSuffix(publicURL, "/"),
+	}
+}
+
+// CreateFeed godoc
+// @Summary Create calendar feed
+// @Description Issue a feed URL listing the tasks assigned to the authenticated client as iCalendar to-dos. Calendar apps subscribe to the URL without a bearer token, so keep it secret. Creating the feed again replaces the URL; the token is only returned here.
+// @Tags calendar
+// @Produce json
+// @Success 201 {object} domain.CalendarFeed "Feed with its URL"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /calendar/feed [post]
+func (h *CalendarHandler) CreateFeed(w http.ResponseWriter, r *http.Request) {
+	feed, err := h.calendarService.CreateFeed(r.Context(), clientID(r))
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	feed.URL = h.baseURL(r) + "/calendar/" + feed.Token + ".ics"
+	server.RespondCreated("/calendar/feed", feed, w, r)
+}
+
+// GetFeed godoc
+// @Summary Get calendar feed
+// @Description Get when the feed of the authenticated client was created. The feed URL is not returned again.
+// @Tags calendar
+// @Produce json
+// @Success 200 {object} domain.CalendarFeed "Feed"
+// @Failure 404 {object} server.Problem "No feed"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /calendar/feed [get]
+func (h *CalendarHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
+	feed, err := h.calendarService.GetFeed(r.Context(), clientID(r))
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(feed, w, r)
+}
+
+// DeleteFeed godoc
+// @Summary Delete calendar feed
+// @Description Revoke the feed URL of the authenticated client
+// @Tags calendar
+// @Success 204 "Feed deleted"
+// @Failure 404 {object} server.Problem "No feed"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /calendar/feed [delete]
+func (h *CalendarHandler) DeleteFeed(w http.ResponseWriter, r *http.Request) {
+	err := h.calendarService.DeleteFeed(r.Context(), clientID(r))
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondNoContent(w, r)
+}
+
+// GetFeedCalendar godoc
+// @Summary Calendar feed
+// @Description The tasks assigned to the owner of the feed token, as iCalendar to-dos. Authenticated by the token in the URL instead of a bearer token.
+// @Tags calendar
+// @Produce text/calendar
+// @Param token path string true "Feed token"
+// @Success 200 {file} file "Calendar"
+// @Failure 404 {object} server.Problem "Unknown or revoked token"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /calendar/{token}.ics [get]
+func (h *CalendarHandler) GetFeedCalendar(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+
+	streamTasks(h.logger, w, r, domain.ExportFormatICS, h.calendarService.GetWorkflow(), func(fn func(task domain.Task) error) error {
+		return h.calendarService.FeedTasks(r.Context(), token, fn)
+	})
+}
+
+// baseURL returns the address of the API as seen by the client.
+func (h *CalendarHandler) baseURL(r *http.Request) string {
+	if h.publicURL != "" {
+		return h.publicURL
+	}
+
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/alexgolang/ishare-task/internal/app/common/server"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type CommentHandler struct {
+	logger         *log.Logger
+	commentService *service.CommentService
+}
+
+func NewCommentHandler(logger *log.Logger, commentService *service.CommentService) *CommentHandler {
+	return &CommentHandler{
+		logger:         logger,
+		commentService: commentService,
+	}
+}
+
+// CreateComment godoc
+// @Summary Comment on a task
+// @Description Add a comment to a task; the author is the authenticated client
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID (UUID)"
+// @Param comment body domain.CreateCommentRequest true "Comment data"
+// @Success 201 {object} domain.Comment "Comment created; Location points to it"
+// @Failure 400 {object} server.Problem "Bad request"
+// @Failure 404 {object} server.Problem "Task not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/comments [post]
+func (h *CommentHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+
+	var req domain.CreateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		server.RespondBadRequest(err.Error(), w, r)
+		return
+	}
+
+	comment, err := h.commentService.CreateComment(r.Context(), taskID, clientID(r), &req)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondCreated("/tasks/"+comment.TaskID.String()+"/comments/"+comment.ID.String(), comment, w, r)
+}
+
+// ListComments godoc
+// @Summary List comments on a task
+// @Description Get a page of comments on a task, oldest first
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID (UUID)"
+// @Param limit query int false "Page size (default 50, max 200)"
+// @Param offset query int false "Number of comments to skip"
+// @Success 200 {object} domain.CommentPage "Page of comments"
+// @Failure 400 {object} server.Problem "Bad request"
+// @Failure 404 {object} server.Problem "Task not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/comments [get]
+func (h *CommentHandler) ListComments(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+
+	limit, err := queryInt(r, "limit")
+	if err != nil {
+		server.RespondBadRequest(err.Error(), w, r)
+		return
+	}
+
+	offset, err := queryInt(r, "offset")
+	if err != nil {
+		server.RespondBadRequest(err.Error(), w, r)
+		return
+	}
+
+	page, err := h.commentService.ListComments(r.Context(), taskID, limit, offset)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(page, w, r)
+}
+
+// GetComment godoc
+// @Summary Get a comment
+// @Description Get a comment on a task
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID (UUID)"
+// @Param commentId path string true "Comment ID (UUID)"
+// @Success 200 {object} domain.Comment "Comment details"
+// @Failure 404 {object} server.Problem "Comment not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/comments/{commentId} [get]
+func (h *CommentHandler) GetComment(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+	commentID := chi.URLParam(r, "commentId")
+
+	comment, err := h.commentService.GetComment(r.Context(), taskID, commentID)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(comment, w, r)
+}
+
+// UpdateComment godoc
+// @Summary Edit a comment
+// @Description Replace the body of a comment; the previous body is kept in its edit history
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID (UUID)"
+// @Param commentId path string true "Comment ID (UUID)"
+// @Param comment body domain.UpdateCommentRequest true "Comment data"
+// @Success 200 {object} domain.Comment "Comment updated"
+// @Failure 400 {object} server.Problem "Bad request"
+// @Failure 403 {object} server.Problem "Not the author"
+// @Failure 404 {object} server.Problem "Comment not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/comments/{commentId} [patch]
+func (h *CommentHandler) UpdateComment(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+	commentID := chi.URLParam(r, "commentId")
+
+	var req domain.UpdateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		server.RespondBadRequest(err.Error(), w, r)
+		return
+	}
+
+	comment, err := h.commentService.UpdateComment(r.Context(), taskID, commentID, clientID(r), &req)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(comment, w, r)
+}
+
+// DeleteComment godoc
+// @Summary Delete a comment
+// @Description Soft delete a comment; it is hidden from listings but its history is kept
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID (UUID)"
+// @Param commentId path string true "Comment ID (UUID)"
+// @Success 200 {object} map[string]string "Comment deleted successfully"
+// @Failure 403 {object} server.Problem "Not the author"
+// @Failure 404 {object} server.Problem "Comment not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/comments/{commentId} [delete]
+func (h *CommentHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+	commentID := chi.URLParam(r, "commentId")
+
+	err := h.commentService.DeleteComment(r.Context(), taskID, commentID, clientID(r))
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(fmt.Sprintf("Comment %s deleted", commentID), w, r)
+}
+
+// GetCommentHistory godoc
+// @Summary Get comment edit history
+// @Description Get the previous bodies of a comment, oldest first
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID (UUID)"
+// @Param commentId path string true "Comment ID (UUID)"
+// @Success 200 {array} domain.CommentRevision "Edit history"
+// @Failure 404 {object} server.Problem "Comment not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/comments/{commentId}/history [get]
+func (h *CommentHandler) GetCommentHistory(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+	commentID := chi.URLParam(r, "commentId")
+
+	revisions, err := h.commentService.GetCommentHistory(r.Context(), taskID, commentID)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(revisions, w, r)
+}
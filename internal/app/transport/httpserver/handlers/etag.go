@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/alexgolang/ishare-task/internal/app/common/server"
+)
+
+// taskETag formats a task version as a strong entity tag.
+func taskETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// parseETags splits an If-Match or If-None-Match header into its entity tags.
+func parseETags(header string) []string {
+	var tags []string
+	for _, tag := range strings.Split(header, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// notModified reports whether If-None-Match matches the current version.
+// Weak comparison is used, as RFC 9110 requires for If-None-Match.
+func notModified(r *http.Request, version int64) bool {
+	current := taskETag(version)
+	for _, tag := range parseETags(r.Header.Get("If-None-Match")) {
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == current {
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatchVersions reads the If-Match header of a write request and returns the
+// task versions it accepts; none means the write is unconditional. It writes
+// the response and returns false when the request cannot proceed: 428 when
+// the header is missing in strict mode, 412 when no tag could ever match.
+func ifMatchVersions(w http.ResponseWriter, r *http.Request, required bool) ([]int64, bool) {
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		if required {
+			server.RespondPreconditionRequired("If-Match header is required", w, r)
+			return nil, false
+		}
+		return nil, true
+	}
+
+	tags := parseETags(header)
+	for _, tag := range tags {
+		if tag == "*" {
+			return nil, true
+		}
+	}
+
+	// Strong comparison: weak tags and tags that are not versions never match.
+	var versions []int64
+	for _, tag := range tags {
+		if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			continue
+		}
+		if version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64); err == nil {
+			versions = append(versions, version)
+		}
+	}
+
+	if len(versions) == 0 {
+		server.RespondPreconditionFailed("If-Match does not match the current version", w, r)
+		return nil, false
+	}
+
+	return versions, true
+}
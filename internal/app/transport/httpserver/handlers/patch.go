@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/alexgolang/ishare-task/internal/app/common/server"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+)
+
+// patchFormat returns the patch format named by the request's Content-Type,
+// or an empty format for plain JSON updates. Other media types are refused
+// with 415.
+func patchFormat(w http.ResponseWriter, r *http.Request) (domain.PatchFormat, bool) {
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		return "", true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		server.RespondBadRequest(fmt.Sprintf("invalid Content-Type %q", contentType), w, r)
+		return "", false
+	}
+
+	switch format := domain.PatchFormat(mediaType); format {
+	case domain.PatchFormatMergePatch, domain.PatchFormatJSONPatch:
+		return format, true
+	case "application/json":
+		return "", true
+	default:
+		w.Header().Set("Accept-Patch", acceptPatch)
+		server.RespondUnsupportedMediaType(fmt.Sprintf("unsupported Content-Type %q", mediaType), w, r)
+		return "", false
+	}
+}
+
+// acceptPatch lists the media types PATCH /tasks/{id} accepts.
+const acceptPatch = "application/json, application/merge-patch+json, application/json-patch+json"
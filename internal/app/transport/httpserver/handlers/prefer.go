@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+)
+
+// wantsRepresentation reads the return preference of a write request from the
+// Prefer header (RFC 7240): return=representation asks for the resource in the
+// response, return=minimal for an empty body. Without a preference the
+// handler's default applies. An honoured preference is echoed in
+// Preference-Applied.
+func wantsRepresentation(w http.ResponseWriter, r *http.Request, fallback bool) bool {
+	w.Header().Add("Vary", "Prefer")
+
+	for _, header := range r.Header.Values("Prefer") {
+		for _, preference := range strings.Split(header, ",") {
+			// Parameters after ";" do not apply to return.
+			preference, _, _ = strings.Cut(preference, ";")
+			name, value, _ := strings.Cut(strings.TrimSpace(preference), "=")
+			if !strings.EqualFold(strings.TrimSpace(name), "return") {
+				continue
+			}
+
+			switch value = strings.Trim(strings.TrimSpace(value), `"`); value {
+			case "minimal":
+				w.Header().Set("Preference-Applied", "return=minimal")
+				return false
+			case "representation":
+				w.Header().Set("Preference-Applied", "return=representation")
+				return true
+			}
+		}
+	}
+
+	return fallback
+}
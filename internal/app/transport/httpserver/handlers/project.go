@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/alexgolang/ishare-task/internal/app/common/server"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type ProjectHandler struct {
+	logger         *log.Logger
+	projectService *service.ProjectService
+	taskService    *service.TaskService
+}
+
+func NewProjectHandler(logger *log.Logger, projectService *service.ProjectService, taskService *service.TaskService) *ProjectHandler {
+	return &ProjectHandler{
+		logger:         logger,
+		projectService: projectService,
+		taskService:    taskService,
+	}
+}
+
+// CreateProject godoc
+// @Summary Create a project
+// @Description Create a project; its key prefixes the human-friendly task references (e.g. OPS-42)
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param project body domain.CreateProjectRequest true "Project data"
+// @Success 201 {object} domain.Project "Project created; Location points to it"
+// @Failure 400 {object} server.Problem "Bad request"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /projects [post]
+func (h *ProjectHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
+	var req domain.CreateProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		server.RespondBadRequest(err.Error(), w, r)
+		return
+	}
+
+	project, err := h.projectService.CreateProject(r.Context(), &req)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondCreated("/projects/"+project.ID.String(), project, w, r)
+}
+
+// ListProjects godoc
+// @Summary List projects
+// @Description Get all projects ordered by key; archived projects are hidden unless requested
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param include_archived query bool false "Include archived projects"
+// @Success 200 {array} domain.Project "List of projects"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /projects [get]
+func (h *ProjectHandler) ListProjects(w http.ResponseWriter, r *http.Request) {
+	includeArchived := r.URL.Query().Get("include_archived") == "true"
+
+	projects, err := h.projectService.ListProjects(r.Context(), includeArchived)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(projects, w, r)
+}
+
+// GetProject godoc
+// @Summary Get project by ID
+// @Description Get a single project by its UUID
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path string true "Project ID (UUID)"
+// @Success 200 {object} domain.Project "Project found"
+// @Failure 404 {object} server.Problem "Project not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /projects/{id} [get]
+func (h *ProjectHandler) GetProject(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	project, err := h.projectService.GetProject(r.Context(), id)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(project, w, r)
+}
+
+// UpdateProject godoc
+// @Summary Update project
+// @Description Rename, describe, archive or unarchive a project (partial update supported)
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path string true "Project ID (UUID)"
+// @Param project body domain.UpdateProjectRequest true "Project update data"
+// @Success 200 {object} domain.Project "Project updated"
+// @Failure 400 {object} server.Problem "Bad request"
+// @Failure 404 {object} server.Problem "Project not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /projects/{id} [patch]
+func (h *ProjectHandler) UpdateProject(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var req domain.UpdateProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		server.RespondBadRequest(err.Error(), w, r)
+		return
+	}
+
+	project, err := h.projectService.UpdateProject(r.Context(), id, &req)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(project, w, r)
+}
+
+// DeleteProject godoc
+// @Summary Delete project
+// @Description Delete a project that has no tasks; archive projects that still hold tasks
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path string true "Project ID (UUID)"
+// @Success 200 {object} map[string]string "Project deleted successfully"
+// @Failure 404 {object} server.Problem "Project not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /projects/{id} [delete]
+func (h *ProjectHandler) DeleteProject(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	err := h.projectService.DeleteProject(r.Context(), id)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(fmt.Sprintf("Project %s deleted", id), w, r)
+}
+
+// ListProjectTasks godoc
+// @Summary List tasks in a project
+// @Description Get the tasks filed under a project, optionally only those assigned to someone
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path string true "Project ID (UUID)"
+// @Param assignee query string false "Assignee ID, or \"me\" for the authenticated client"
+// @Success 200 {array} domain.Task "List of tasks"
+// @Failure 404 {object} server.Problem "Project not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /projects/{id}/tasks [get]
+func (h *ProjectHandler) ListProjectTasks(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	if _, err := h.projectService.GetProject(r.Context(), id); err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	assignee := r.URL.Query().Get("assignee")
+	if assignee == "me" {
+		assignee = clientID(r)
+	}
+
+	tasks, err := h.taskService.GetTasks(r.Context(), domain.TaskFilter{
+		Assignee:  assignee,
+		ProjectID: id,
+	})
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(tasks, w, r)
+}
+
+// CreateProjectTask godoc
+// @Summary Create a task in a project
+// @Description Create a task filed under the given project
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param id path string true "Project ID (UUID)"
+// @Param task body domain.CreateTaskRequest true "Task data"
+// @Param Prefer header string false "return=minimal for an empty body"
+// @Success 201 {object} domain.Task "Task created; Location points to it"
+// @Failure 400 {object} server.Problem "Bad request"
+// @Failure 404 {object} server.Problem "Project not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /projects/{id}/tasks [post]
+func (h *ProjectHandler) CreateProjectTask(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var req domain.CreateTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		server.RespondBadRequest(err.Error(), w, r)
+		return
+	}
+	req.ProjectID = id
+
+	taskID, err := h.taskService.CreateTask(r.Context(), &req)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	respondCreatedTask(h.logger, h.taskService, w, r, taskID.String())
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/alexgolang/ishare-task/internal/app/common/server"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type ReminderHandler struct {
+	logger          *log.Logger
+	reminderService *service.ReminderService
+}
+
+func NewReminderHandler(logger *log.Logger, reminderService *service.ReminderService) *ReminderHandler {
+	return &ReminderHandler{
+		logger:          logger,
+		reminderService: reminderService,
+	}
+}
+
+// CreateReminder godoc
+// @Summary Add a reminder to a task
+// @Description Remind the task's assignees at an absolute time, or a duration before the due date
+// @Tags reminders
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID (UUID)"
+// @Param reminder body domain.CreateReminderRequest true "Reminder data"
+// @Success 201 {object} domain.Reminder "Reminder created; Location points to it"
+// @Failure 400 {object} server.Problem "Bad request"
+// @Failure 404 {object} server.Problem "Task not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/reminders [post]
+func (h *ReminderHandler) CreateReminder(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+
+	var req domain.CreateReminderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		server.RespondBadRequest(err.Error(), w, r)
+		return
+	}
+
+	reminder, err := h.reminderService.CreateReminder(r.Context(), taskID, clientID(r), &req)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondCreated("/tasks/"+reminder.TaskID.String()+"/reminders/"+reminder.ID.String(), reminder, w, r)
+}
+
+// ListReminders godoc
+// @Summary List reminders on a task
+// @Description Get the reminders of a task, earliest first, including delivered ones
+// @Tags reminders
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID (UUID)"
+// @Success 200 {array} domain.Reminder "List of reminders"
+// @Failure 404 {object} server.Problem "Task not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/reminders [get]
+func (h *ReminderHandler) ListReminders(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+
+	reminders, err := h.reminderService.ListReminders(r.Context(), taskID)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(reminders, w, r)
+}
+
+// GetReminder godoc
+// @Summary Get a reminder
+// @Description Get a reminder on a task
+// @Tags reminders
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID (UUID)"
+// @Param reminderId path string true "Reminder ID (UUID)"
+// @Success 200 {object} domain.Reminder "Reminder details"
+// @Failure 404 {object} server.Problem "Reminder not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/reminders/{reminderId} [get]
+func (h *ReminderHandler) GetReminder(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+	reminderID := chi.URLParam(r, "reminderId")
+
+	reminder, err := h.reminderService.GetReminder(r.Context(), taskID, reminderID)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(reminder, w, r)
+}
+
+// DeleteReminder godoc
+// @Summary Delete a reminder
+// @Description Cancel a reminder on a task
+// @Tags reminders
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID (UUID)"
+// @Param reminderId path string true "Reminder ID (UUID)"
+// @Success 200 {object} map[string]string "Reminder deleted successfully"
+// @Failure 404 {object} server.Problem "Reminder not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/reminders/{reminderId} [delete]
+func (h *ReminderHandler) DeleteReminder(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+	reminderID := chi.URLParam(r, "reminderId")
+
+	err := h.reminderService.DeleteReminder(r.Context(), taskID, reminderID)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(fmt.Sprintf("Reminder %s deleted", reminderID), w, r)
+}
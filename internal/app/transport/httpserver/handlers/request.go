@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/transport/httpserver/middleware"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// clientID returns the subject of the access token the request was
+// authenticated with, or an empty string for unauthenticated requests.
+func clientID(r *http.Request) string {
+	claims, ok := r.Context().Value(middleware.UserContextKey).(jwt.MapClaims)
+	if !ok {
+		return ""
+	}
+
+	sub, _ := claims["sub"].(string)
+	return sub
+}
+
+// queryInt parses an optional integer query parameter, returning 0 when absent.
+func queryInt(r *http.Request, name string) (int, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return 0, nil
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %q is not a number", name, raw)
+	}
+
+	return value, nil
+}
+
+// queryTime parses an optional time query parameter given as an RFC 3339
+// timestamp or a date, which stands for midnight UTC. It returns nil when the
+// parameter is absent.
+func queryTime(r *http.Request, name string) (*time.Time, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+
+	if value, err := time.Parse(time.RFC3339, raw); err == nil {
+		return &value, nil
+	}
+	value, err := time.Parse(time.DateOnly, raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %q is not an RFC 3339 timestamp or a YYYY-MM-DD date", name, raw)
+	}
+
+	return &value, nil
+}
+
+// decodeOptionalJSON decodes a JSON request body into v, leaving v as it is
+// when the body is empty.
+func decodeOptionalJSON(r *http.Request, v any) error {
+	err := json.NewDecoder(r.Body).Decode(v)
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return err
+}
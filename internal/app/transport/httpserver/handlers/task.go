@@ -1,11 +1,13 @@
 package handlers
 
 import (
-	"database/sql"
 	"encoding/json"
 	"fmt"
+	"io"
+	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/alexgolang/ishare-task/internal/app/common/server"
 	"github.com/alexgolang/ishare-task/internal/app/domain"
@@ -14,12 +16,17 @@ import (
 )
 
 type TaskHandler struct {
+	logger      *log.Logger
 	taskService *service.TaskService
+	// requireIfMatch makes If-Match mandatory on task writes.
+	requireIfMatch bool
 }
 
-func NewTaskHandler(taskService *service.TaskService) *TaskHandler {
+func NewTaskHandler(logger *log.Logger, taskService *service.TaskService, requireIfMatch bool) *TaskHandler {
 	return &TaskHandler{
-		taskService: taskService,
+		logger:         logger,
+		taskService:    taskService,
+		requireIfMatch: requireIfMatch,
 	}
 }
 
@@ -30,43 +37,53 @@ func NewTaskHandler(taskService *service.TaskService) *TaskHandler {
 // @Accept json
 // @Produce json
 // @Param task body domain.CreateTaskRequest true "Task data"
-// @Success 201 {object} map[string]string "Task created successfully"
-// @Failure 400 {object} server.ErrorResponse "Bad request"
-// @Failure 500 {object} server.ErrorResponse "Internal server error"
+// @Param Prefer header string false "return=minimal for an empty body"
+// @Success 201 {object} domain.Task "Task created; Location points to it"
+// @Failure 400 {object} server.Problem "Bad request"
+// @Failure 500 {object} server.Problem "Internal server error"
 // @Router /tasks [post]
 func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 	var req domain.CreateTaskRequest
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		server.RespondError(err, w, r)
+		server.RespondBadRequest(err.Error(), w, r)
 		return
 	}
 
 	id, err := h.taskService.CreateTask(r.Context(), &domain.CreateTaskRequest{
+		ProjectID:   req.ProjectID,
 		Title:       req.Title,
 		Description: req.Description,
 		Status:      req.Status,
 		Priority:    req.Priority,
+		Assignees:   req.Assignees,
+		Tags:        req.Tags,
+		DueAt:       req.DueAt,
+		Estimate:    req.Estimate,
+		Remaining:   req.Remaining,
+		RRule:       req.RRule,
 	})
 
 	if err != nil {
-		server.RespondError(err, w, r)
+		server.RespondError(h.logger, err, w, r)
 		return
 	}
 
-	server.RespondOK(fmt.Sprintf("Task %s created", id), w, r)
+	respondCreatedTask(h.logger, h.taskService, w, r, id.String())
 }
 
 // GetTask godoc
 // @Summary Get task by ID
-// @Description Get a single task by its UUID
+// @Description Get a single task by its UUID or its project key reference (e.g. OPS-42)
 // @Tags tasks
 // @Accept json
 // @Produce json
-// @Param id path string true "Task ID (UUID)"
+// @Param id path string true "Task ID (UUID) or key"
+// @Param If-None-Match header string false "ETag of a cached copy"
 // @Success 200 {object} domain.Task "Task found"
-// @Failure 404 {object} server.ErrorResponse "Task not found"
-// @Failure 500 {object} server.ErrorResponse "Internal server error"
+// @Success 304 "Task not modified"
+// @Failure 404 {object} server.Problem "Task not found"
+// @Failure 500 {object} server.Problem "Internal server error"
 // @Router /tasks/{id} [get]
 func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
@@ -74,11 +91,14 @@ func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
 	task, err := h.taskService.GetTask(r.Context(), id)
 
 	if err != nil {
-		if err == sql.ErrNoRows {
-			server.RespondNotFound("Task not found", w, r)
-			return
-		}
-		server.RespondError(err, w, r)
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	w.Header().Set("ETag", taskETag(task.Version))
+	w.Header().Set("Accept-Patch", acceptPatch)
+	if notModified(r, task.Version) {
+		server.RespondNotModified(w, r)
 		return
 	}
 
@@ -87,86 +107,585 @@ func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
 
 // UpdateTask godoc
 // @Summary Update task
-// @Description Update a task's fields (partial update supported)
+// @Description Update a task's fields. With application/json, omitted and empty fields are left unchanged. With application/merge-patch+json (RFC 7396) an explicit null clears a field; application/json-patch+json (RFC 6902) supports every operation including test. Patches apply to Title, Description, Status, Priority, Assignees, Tags, DueAt, Estimate, Remaining and Comment, and the resulting task is validated as a whole.
 // @Tags tasks
-// @Accept json
+// @Accept json,application/merge-patch+json,application/json-patch+json
 // @Produce json
 // @Param id path string true "Task ID (UUID)"
-// @Param task body domain.UpdateTaskRequest true "Task update data"
-// @Success 200 {object} map[string]string "Task updated successfully"
-// @Failure 400 {object} server.ErrorResponse "Bad request"
-// @Failure 404 {object} server.ErrorResponse "Task not found"
-// @Failure 500 {object} server.ErrorResponse "Internal server error"
+// @Param scope query string false "For recurring tasks: \"occurrence\" (default) or \"series\""
+// @Param If-Match header string false "ETag the update is based on"
+// @Param Prefer header string false "return=minimal for an empty body"
+// @Param task body domain.UpdateTaskRequest true "Task update data, or a patch document"
+// @Success 200 {object} domain.Task "Updated task"
+// @Success 204 "Task updated, with return=minimal"
+// @Failure 400 {object} server.Problem "Bad request"
+// @Failure 404 {object} server.Problem "Task not found"
+// @Failure 409 {object} server.Problem "Status transition not allowed by the workflow, or a JSON Patch test failed"
+// @Failure 412 {object} server.Problem "Task was changed since the given ETag"
+// @Failure 415 {object} server.Problem "Unsupported patch format"
+// @Failure 428 {object} server.Problem "If-Match is required"
+// @Failure 500 {object} server.Problem "Internal server error"
 // @Router /tasks/{id} [patch]
 func (h *TaskHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
+	scope := domain.SeriesScope(r.URL.Query().Get("scope"))
+	if !scope.IsValid() {
+		server.RespondBadRequest(fmt.Sprintf("invalid scope %q", scope), w, r)
+		return
+	}
+
+	format, ok := patchFormat(w, r)
+	if !ok {
+		return
+	}
+
+	if format != "" && scope == domain.SeriesScopeSeries {
+		server.RespondBadRequest("patch documents cannot be applied to a whole series", w, r)
+		return
+	}
+
+	ifMatch, ok := ifMatchVersions(w, r, h.requireIfMatch)
+	if !ok {
+		return
+	}
+
+	if format != "" {
+		patch, err := io.ReadAll(r.Body)
+		if err != nil {
+			server.RespondBadRequest(err.Error(), w, r)
+			return
+		}
+
+		if err := h.taskService.PatchTask(r.Context(), id, format, patch, ifMatch); err != nil {
+			server.RespondError(h.logger, err, w, r)
+			return
+		}
+
+		h.respondUpdatedTask(w, r, id)
+		return
+	}
+
 	var req domain.UpdateTaskRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		server.RespondError(err, w, r)
+		server.RespondBadRequest(err.Error(), w, r)
 		return
 	}
 
-	err := h.taskService.UpdateTask(r.Context(), id, &domain.UpdateTaskRequest{
+	update := &domain.UpdateTaskRequest{
 		Title:       req.Title,
 		Description: req.Description,
 		Status:      req.Status,
 		Priority:    req.Priority,
-	})
+		Assignees:   req.Assignees,
+		Tags:        req.Tags,
+		DueAt:       req.DueAt,
+		Estimate:    req.Estimate,
+		Remaining:   req.Remaining,
+		Comment:     req.Comment,
+	}
+
+	var err error
+	if scope == domain.SeriesScopeSeries {
+		err = h.taskService.UpdateTaskSeries(r.Context(), id, update, ifMatch)
+	} else {
+		err = h.taskService.UpdateTask(r.Context(), id, update, ifMatch)
+	}
 
 	if err != nil {
-		server.RespondError(err, w, r)
+		server.RespondError(h.logger, err, w, r)
 		return
 	}
 
-	server.RespondOK(fmt.Sprintf("Task %s updated", id), w, r)
+	h.respondUpdatedTask(w, r, id)
+}
+
+// MoveTask godoc
+// @Summary Move task on the board
+// @Description Place a task right after AfterID and/or right before BeforeID, optionally in another status (column). Without neighbours the task goes to the bottom of the column. Only the moved task is written; status changes follow the workflow.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID (UUID)"
+// @Param If-Match header string false "ETag the move is based on"
+// @Param Prefer header string false "return=minimal for an empty body"
+// @Param move body domain.MoveTaskRequest true "Target status and neighbours"
+// @Success 200 {object} domain.Task "Moved task"
+// @Success 204 "Task moved, with return=minimal"
+// @Failure 400 {object} server.Problem "Bad request"
+// @Failure 404 {object} server.Problem "Task not found"
+// @Failure 409 {object} server.Problem "Neighbours are not in the target status or not in order, or the status change is not allowed"
+// @Failure 412 {object} server.Problem "Task was changed since the given ETag"
+// @Failure 428 {object} server.Problem "If-Match is required"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/move [post]
+func (h *TaskHandler) MoveTask(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	ifMatch, ok := ifMatchVersions(w, r, h.requireIfMatch)
+	if !ok {
+		return
+	}
+
+	var req domain.MoveTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		server.RespondBadRequest(err.Error(), w, r)
+		return
+	}
+
+	err := h.taskService.MoveTask(r.Context(), id, &req, ifMatch)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	h.respondUpdatedTask(w, r, id)
 }
 
 // DeleteTask godoc
 // @Summary Delete task
-// @Description Delete a task by its UUID
+// @Description Move a task to the trash, or every occurrence of a recurring task with scope=series
 // @Tags tasks
 // @Accept json
 // @Produce json
 // @Param id path string true "Task ID (UUID)"
-// @Success 200 {object} map[string]string "Task deleted successfully"
-// @Failure 404 {object} server.ErrorResponse "Task not found"
-// @Failure 500 {object} server.ErrorResponse "Internal server error"
+// @Param scope query string false "For recurring tasks: \"occurrence\" (default) or \"series\""
+// @Param If-Match header string false "ETag the deletion is based on"
+// @Param Prefer header string false "return=representation for the deleted task"
+// @Success 200 {object} domain.Task "Deleted task, with return=representation"
+// @Success 204 "Task deleted"
+// @Failure 404 {object} server.Problem "Task not found"
+// @Failure 412 {object} server.Problem "Task was changed since the given ETag"
+// @Failure 428 {object} server.Problem "If-Match is required"
+// @Failure 500 {object} server.Problem "Internal server error"
 // @Router /tasks/{id} [delete]
 func (h *TaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
-	err := h.taskService.DeleteTask(r.Context(), id)
+	scope := domain.SeriesScope(r.URL.Query().Get("scope"))
+	if !scope.IsValid() {
+		server.RespondBadRequest(fmt.Sprintf("invalid scope %q", scope), w, r)
+		return
+	}
+
+	ifMatch, ok := ifMatchVersions(w, r, h.requireIfMatch)
+	if !ok {
+		return
+	}
+
+	var err error
+	if scope == domain.SeriesScopeSeries {
+		err = h.taskService.DeleteTaskSeries(r.Context(), id, ifMatch)
+	} else {
+		err = h.taskService.DeleteTask(r.Context(), id, ifMatch)
+	}
 
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			server.RespondNotFound(err.Error(), w, r)
-			return
-		}
-		server.RespondError(err, w, r)
+		server.RespondError(h.logger, err, w, r)
 		return
 	}
 
-	server.RespondOK(fmt.Sprintf("Task %s deleted", id), w, r)
+	if !wantsRepresentation(w, r, false) {
+		server.RespondNoContent(w, r)
+		return
+	}
+
+	task, err := h.taskService.GetTrashedTask(r.Context(), id)
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(task, w, r)
+}
+
+// respondCreatedTask answers a request that created a task with 201, its
+// Location and ETag, and the task unless the client prefers a minimal response.
+func respondCreatedTask(logger *log.Logger, taskService *service.TaskService, w http.ResponseWriter, r *http.Request, id string) {
+	task, err := taskService.GetTask(r.Context(), id)
+	if err != nil {
+		server.RespondError(logger, err, w, r)
+		return
+	}
+
+	w.Header().Set("ETag", taskETag(task.Version))
+	location := "/tasks/" + id
+	if !wantsRepresentation(w, r, true) {
+		server.RespondCreated(location, nil, w, r)
+		return
+	}
+
+	server.RespondCreated(location, task, w, r)
 }
 
+// respondUpdatedTask answers a successful update with the task and its new
+// ETag, or with 204 when the client prefers a minimal response.
+func (h *TaskHandler) respondUpdatedTask(w http.ResponseWriter, r *http.Request, id string) {
+	task, err := h.taskService.GetTask(r.Context(), id)
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	w.Header().Set("ETag", taskETag(task.Version))
+	if !wantsRepresentation(w, r, true) {
+		server.RespondNoContent(w, r)
+		return
+	}
+
+	server.RespondOK(task, w, r)
+}
+
+// ListTrash godoc
+// @Summary List deleted tasks
+// @Description Get the tasks in the trash, most recently deleted first. They are purged once the retention period has passed.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Success 200 {array} domain.Task "Deleted tasks"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/trash [get]
+func (h *TaskHandler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	tasks, err := h.taskService.ListTrash(r.Context())
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(tasks, w, r)
+}
+
+// RestoreTask godoc
+// @Summary Restore task
+// @Description Take a deleted task out of the trash
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID (UUID)"
+// @Success 200 {object} domain.Task "Restored task"
+// @Failure 404 {object} server.Problem "Task not found"
+// @Failure 409 {object} server.Problem "Task is not in the trash"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/restore [post]
+func (h *TaskHandler) RestoreTask(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	task, err := h.taskService.RestoreTask(r.Context(), id)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(task, w, r)
+}
+
+// PurgeTask godoc
+// @Summary Permanently delete task
+// @Description Delete a task and its comments, attachments and reminders for good, whether or not it is in the trash. Its history is kept. Administrators only.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID (UUID)"
+// @Success 204 "Task permanently deleted"
+// @Failure 403 {object} server.Problem "Administrator access required"
+// @Failure 404 {object} server.Problem "Task not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/permanent [delete]
+func (h *TaskHandler) PurgeTask(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	err := h.taskService.PurgeTask(r.Context(), id)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondNoContent(w, r)
+}
 
 // ListTasks godoc
 // @Summary List all tasks
-// @Description Get all tasks in the system
+// @Description Get all tasks in the system ordered by status and board rank, optionally only those assigned to someone, carrying a tag or matching a filter expression
 // @Tags tasks
 // @Accept json
 // @Produce json
+// @Param assignee query string false "Assignee ID, or \"me\" for the authenticated client"
+// @Param tag query string false "Tag the tasks must carry"
+// @Param filter query string false "Filter expression, e.g. priority >= medium and status != done or tag = urgent and updated > now-7d"
 // @Success 200 {array} domain.Task "List of tasks"
-// @Failure 500 {object} server.ErrorResponse "Internal server error"
+// @Failure 400 {object} server.Problem "Invalid filter expression; position gives where it went wrong"
+// @Failure 500 {object} server.Problem "Internal server error"
 // @Router /tasks [get]
 func (h *TaskHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
-	tasks, err := h.taskService.GetTasks(r.Context())
+	tasks, err := h.taskService.GetTasks(r.Context(), taskFilter(r))
 
 	if err != nil {
-		server.RespondError(err, w, r)
+		server.RespondError(h.logger, err, w, r)
 		return
 	}
 
 	server.RespondOK(tasks, w, r)
 }
+
+// GetBoard godoc
+// @Summary Get board
+// @Description Get tasks grouped by status, one column per workflow status in workflow order, each in the order set with POST /tasks/{id}/move
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param project query string false "Project ID (UUID)"
+// @Param assignee query string false "Assignee ID, or \"me\" for the authenticated client"
+// @Param tag query string false "Tag the tasks must carry"
+// @Param filter query string false "Filter expression, see GET /tasks"
+// @Success 200 {object} domain.Board "Board"
+// @Failure 400 {object} server.Problem "Invalid filter expression"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/board [get]
+func (h *TaskHandler) GetBoard(w http.ResponseWriter, r *http.Request) {
+	filter := taskFilter(r)
+	filter.ProjectID = r.URL.Query().Get("project")
+
+	board, err := h.taskService.GetBoard(r.Context(), filter)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(board, w, r)
+}
+
+// taskFilter reads the filters of task listings from the query string.
+func taskFilter(r *http.Request) domain.TaskFilter {
+	assignee := r.URL.Query().Get("assignee")
+	if assignee == "me" {
+		assignee = clientID(r)
+	}
+
+	return domain.TaskFilter{
+		Assignee:   assignee,
+		Tag:        strings.ToLower(strings.TrimSpace(r.URL.Query().Get("tag"))),
+		Expression: r.URL.Query().Get("filter"),
+	}
+}
+
+// GetBurndown godoc
+// @Summary Burndown and burnup report
+// @Description Get the scope, completed work and remaining work of a project's or tag's tasks at the end of every day (UTC) of a date range, replayed from task history so past days are accurate. Amounts are in the unit of the task estimates (story points or hours).
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param project query string false "Project ID (UUID)"
+// @Param tag query string false "Tag the tasks must carry"
+// @Param from query string false "First day, YYYY-MM-DD (default: 13 days before to)"
+// @Param to query string false "Last day, YYYY-MM-DD (default: today)"
+// @Success 200 {object} domain.BurndownReport "Daily series"
+// @Failure 400 {object} server.Problem "Bad request"
+// @Failure 404 {object} server.Problem "Project not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/burndown [get]
+func (h *TaskHandler) GetBurndown(w http.ResponseWriter, r *http.Request) {
+	filter := domain.BurndownFilter{
+		ProjectID: r.URL.Query().Get("project"),
+		Tag:       r.URL.Query().Get("tag"),
+	}
+
+	from, err := queryTime(r, "from")
+	if err != nil {
+		server.RespondBadRequest(err.Error(), w, r)
+		return
+	}
+	if from != nil {
+		filter.From = *from
+	}
+
+	to, err := queryTime(r, "to")
+	if err != nil {
+		server.RespondBadRequest(err.Error(), w, r)
+		return
+	}
+	if to != nil {
+		filter.To = *to
+	}
+
+	report, err := h.taskService.GetBurndown(r.Context(), filter)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(report, w, r)
+}
+
+// GetTaskStats godoc
+// @Summary Task statistics
+// @Description Count the tasks matching the filters in total and per status, priority, assignee, tag or project: how many there are, are done and are overdue, and their average time from creation to done (taken from task history, or from the last update when history has no record). Also counts the tasks created and completed on each day (UTC) of a date range. The service has no tenants, so as with GET /tasks every authenticated client sees statistics over all tasks.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param group_by query string false "Grouping: status, priority, assignee, tag or project (default: status)"
+// @Param project query string false "Project ID (UUID)"
+// @Param assignee query string false "Assignee ID, or \"me\" for the authenticated client"
+// @Param tag query string false "Tag the tasks must carry"
+// @Param filter query string false "Filter expression, see GET /tasks"
+// @Param from query string false "First day of the daily series, YYYY-MM-DD (default: 29 days before to)"
+// @Param to query string false "Last day of the daily series, YYYY-MM-DD (default: today)"
+// @Success 200 {object} domain.TaskStats "Statistics"
+// @Failure 400 {object} server.Problem "Bad request"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/stats [get]
+func (h *TaskHandler) GetTaskStats(w http.ResponseWriter, r *http.Request) {
+	filter := domain.TaskStatsFilter{
+		Tasks:   taskFilter(r),
+		GroupBy: domain.TaskStatsGroupBy(r.URL.Query().Get("group_by")),
+	}
+	filter.Tasks.ProjectID = r.URL.Query().Get("project")
+
+	from, err := queryTime(r, "from")
+	if err != nil {
+		server.RespondBadRequest(err.Error(), w, r)
+		return
+	}
+	if from != nil {
+		filter.From = *from
+	}
+
+	to, err := queryTime(r, "to")
+	if err != nil {
+		server.RespondBadRequest(err.Error(), w, r)
+		return
+	}
+	if to != nil {
+		filter.To = *to
+	}
+
+	stats, err := h.taskService.GetTaskStats(r.Context(), filter)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(stats, w, r)
+}
+
+// GetAssignmentHistory godoc
+// @Summary Get assignment history
+// @Description Get every assignee change made to a task, oldest first
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID (UUID)"
+// @Success 200 {array} domain.AssignmentEvent "Assignment history"
+// @Failure 404 {object} server.Problem "Task not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/assignments [get]
+func (h *TaskHandler) GetAssignmentHistory(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	events, err := h.taskService.GetAssignmentHistory(r.Context(), id)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(events, w, r)
+}
+
+// GetTaskSeries godoc
+// @Summary Get recurrence series
+// @Description Get the recurrence rule and template of the series a recurring task belongs to
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID (UUID)"
+// @Success 200 {object} domain.TaskSeries "Recurrence series"
+// @Failure 404 {object} server.Problem "Task or series not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/series [get]
+func (h *TaskHandler) GetTaskSeries(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	series, err := h.taskService.GetTaskSeries(r.Context(), id)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(series, w, r)
+}
+
+// GetTaskHistory godoc
+// @Summary Get task history
+// @Description Get every change made to a task, oldest first, with the actor, request ID and field-level before/after values. Deleted tasks keep their history.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID (UUID) or key"
+// @Success 200 {array} domain.TaskEvent "Task history"
+// @Failure 404 {object} server.Problem "Task not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/history [get]
+func (h *TaskHandler) GetTaskHistory(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	events, err := h.taskService.GetTaskHistory(r.Context(), id)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(events, w, r)
+}
+
+// GetTaskSnapshot godoc
+// @Summary Get task as of a point in time
+// @Description Reconstruct a task from its history as it was at the given time
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID (UUID) or key"
+// @Param at query string true "Point in time (RFC 3339)"
+// @Success 200 {object} domain.Task "Task as it was"
+// @Failure 400 {object} server.Problem "Bad request"
+// @Failure 404 {object} server.Problem "Task did not exist at that time"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/snapshot [get]
+func (h *TaskHandler) GetTaskSnapshot(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	at, err := time.Parse(time.RFC3339, r.URL.Query().Get("at"))
+	if err != nil {
+		server.RespondBadRequest("at must be an RFC 3339 timestamp", w, r)
+		return
+	}
+
+	task, err := h.taskService.GetTaskAt(r.Context(), id, at)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(task, w, r)
+}
+
+// GetWorkflow godoc
+// @Summary Get workflow
+// @Description Get the task statuses and the transitions allowed between them
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Success 200 {object} domain.Workflow "Effective workflow"
+// @Router /workflow [get]
+func (h *TaskHandler) GetWorkflow(w http.ResponseWriter, r *http.Request) {
+	server.RespondOK(h.taskService.GetWorkflow(), w, r)
+}
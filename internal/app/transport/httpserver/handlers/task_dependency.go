@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/alexgolang/ishare-task/internal/app/common/server"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/go-chi/chi/v5"
+)
+
+// AddDependency godoc
+// @Summary Add a blocker to a task
+// @Description Mark the task as blocked by another task; edges that would create a cycle are rejected
+// @Tags dependencies
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID (UUID)"
+// @Param dependency body domain.AddDependencyRequest true "Blocking task"
+// @Success 204 "Dependency added"
+// @Failure 400 {object} server.Problem "Bad request"
+// @Failure 404 {object} server.Problem "Task not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/dependencies [post]
+func (h *TaskHandler) AddDependency(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var req domain.AddDependencyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		server.RespondBadRequest(err.Error(), w, r)
+		return
+	}
+
+	err := h.taskService.AddDependency(r.Context(), id, req.BlockedByID)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondNoContent(w, r)
+}
+
+// RemoveDependency godoc
+// @Summary Remove a blocker from a task
+// @Description Delete the edge stating that the task is blocked by another task
+// @Tags dependencies
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID (UUID)"
+// @Param blockerId path string true "Blocking task ID (UUID)"
+// @Success 204 "Dependency removed"
+// @Failure 404 {object} server.Problem "Dependency not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/dependencies/{blockerId} [delete]
+func (h *TaskHandler) RemoveDependency(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	blockerID := chi.URLParam(r, "blockerId")
+
+	err := h.taskService.RemoveDependency(r.Context(), id, blockerID)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondNoContent(w, r)
+}
+
+// GetDependencyGraph godoc
+// @Summary Get dependency graph
+// @Description Get all tasks the task transitively waits on, topologically sorted, with the critical path
+// @Tags dependencies
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID (UUID)"
+// @Success 200 {object} domain.DependencyGraph "Dependency graph"
+// @Failure 404 {object} server.Problem "Task not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/dependencies [get]
+func (h *TaskHandler) GetDependencyGraph(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	graph, err := h.taskService.GetDependencyGraph(r.Context(), id)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(graph, w, r)
+}
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/alexgolang/ishare-task/internal/app/common/server"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type TemplateHandler struct {
+	logger          *log.Logger
+	templateService *service.TemplateService
+}
+
+func NewTemplateHandler(logger *log.Logger, templateService *service.TemplateService) *TemplateHandler {
+	return &TemplateHandler{
+		logger:          logger,
+		templateService: templateService,
+	}
+}
+
+// CreateTemplate godoc
+// @Summary Create a template
+// @Description Create a reusable set of tasks with subtasks, tags, due offsets and {{variable}} placeholders
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param template body domain.CreateTemplateRequest true "Template data"
+// @Success 201 {object} domain.Template "Template created; Location points to it"
+// @Failure 400 {object} server.Problem "Bad request"
+// @Failure 409 {object} server.Problem "A template with this name already exists"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /templates [post]
+func (h *TemplateHandler) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	var req domain.CreateTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		server.RespondBadRequest(err.Error(), w, r)
+		return
+	}
+
+	template, err := h.templateService.CreateTemplate(r.Context(), &req)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondCreated("/templates/"+template.ID.String(), template, w, r)
+}
+
+// ListTemplates godoc
+// @Summary List templates
+// @Description Get every template, by name
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Success 200 {array} domain.Template "List of templates"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /templates [get]
+func (h *TemplateHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.templateService.ListTemplates(r.Context())
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(templates, w, r)
+}
+
+// GetTemplate godoc
+// @Summary Get a template
+// @Description Get a template with its tasks and the variables it needs
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param id path string true "Template ID (UUID)"
+// @Success 200 {object} domain.Template "Template details"
+// @Failure 404 {object} server.Problem "Template not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /templates/{id} [get]
+func (h *TemplateHandler) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	template, err := h.templateService.GetTemplate(r.Context(), id)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(template, w, r)
+}
+
+// UpdateTemplate godoc
+// @Summary Update a template
+// @Description Change a template. Tasks already created from it are not changed.
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param id path string true "Template ID (UUID)"
+// @Param template body domain.UpdateTemplateRequest true "Fields to change"
+// @Success 200 {object} domain.Template "Template updated"
+// @Failure 400 {object} server.Problem "Bad request"
+// @Failure 404 {object} server.Problem "Template not found"
+// @Failure 409 {object} server.Problem "A template with this name already exists"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /templates/{id} [patch]
+func (h *TemplateHandler) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var req domain.UpdateTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		server.RespondBadRequest(err.Error(), w, r)
+		return
+	}
+
+	template, err := h.templateService.UpdateTemplate(r.Context(), id, &req)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(template, w, r)
+}
+
+// DeleteTemplate godoc
+// @Summary Delete a template
+// @Description Delete a template. Tasks created from it remain, no longer linked to it.
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param id path string true "Template ID (UUID)"
+// @Success 204 "Template deleted"
+// @Failure 404 {object} server.Problem "Template not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /templates/{id} [delete]
+func (h *TemplateHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	err := h.templateService.DeleteTemplate(r.Context(), id)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondNoContent(w, r)
+}
+
+// InstantiateTemplate godoc
+// @Summary Create tasks from a template
+// @Description Create every task and subtask of a template in one transaction, filling in the {{variable}} placeholders and setting due dates from StartAt plus each task's offset. Either all tasks are created or none. Each task records the template in TemplateID.
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Param id path string true "Template ID (UUID)"
+// @Param instantiation body domain.InstantiateTemplateRequest true "Project, variables and start"
+// @Success 201 {object} domain.TemplateInstance "Tasks created; Location points to the first one"
+// @Failure 400 {object} server.Problem "Bad request or missing variables"
+// @Failure 404 {object} server.Problem "Template or project not found"
+// @Failure 409 {object} server.Problem "Project is archived"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /templates/{id}/instantiate [post]
+func (h *TemplateHandler) InstantiateTemplate(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var req domain.InstantiateTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		server.RespondBadRequest(err.Error(), w, r)
+		return
+	}
+
+	instance, err := h.templateService.InstantiateTemplate(r.Context(), id, &req)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondCreated("/tasks/"+instance.TaskIDs[0].String(), instance, w, r)
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/alexgolang/ishare-task/internal/app/common/server"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/service"
+	"github.com/alexgolang/ishare-task/internal/app/taskio"
+)
+
+type TransferHandler struct {
+	logger        *log.Logger
+	taskService   *service.TaskService
+	importService *service.ImportService
+}
+
+func NewTransferHandler(logger *log.Logger, taskService *service.TaskService, importService *service.ImportService) *TransferHandler {
+	return &TransferHandler{
+		logger:        logger,
+		taskService:   taskService,
+		importService: importService,
+	}
+}
+
+// ExportTasks godoc
+// @Summary Export tasks
+// @Description Stream the tasks matching the list filters as CSV (default), JSON Lines, a Markdown table or iCalendar to-dos. CSV and iCalendar exports can be imported again.
+// @Tags tasks
+// @Produce text/csv,application/jsonl,text/markdown,text/calendar
+// @Param format query string false "csv (default), jsonl, md or ics"
+// @Param assignee query string false "Assignee ID, or \"me\" for the authenticated client"
+// @Param tag query string false "Tag the tasks must carry"
+// @Param filter query string false "Filter expression, see GET /tasks"
+// @Success 200 {file} file "Exported tasks"
+// @Failure 400 {object} server.Problem "Bad request"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/export [get]
+func (h *TransferHandler) ExportTasks(w http.ResponseWriter, r *http.Request) {
+	format := domain.ExportFormat(r.URL.Query().Get("format"))
+	if format == "" {
+		format = domain.ExportFormatCSV
+	}
+	if !format.IsValid() {
+		server.RespondBadRequest(fmt.Sprintf("invalid format %q: use csv, jsonl, md or ics", format), w, r)
+		return
+	}
+
+	filter := taskFilter(r)
+	streamTasks(h.logger, w, r, format, h.taskService.GetWorkflow(), func(fn func(task domain.Task) error) error {
+		return h.taskService.ExportTasks(r.Context(), filter, fn)
+	})
+}
+
+// streamTasks writes the tasks export passes to fn as a file in the given
+// format. Errors before the first task are responded to as usual; once
+// streaming has started the status is sent, and failures can only cut the
+// file short.
+func streamTasks(logger *log.Logger, w http.ResponseWriter, r *http.Request, format domain.ExportFormat, workflow domain.Workflow, export func(fn func(task domain.Task) error) error) {
+	var writer taskio.Writer
+	err := export(func(task domain.Task) error {
+		if writer == nil {
+			writer = startExport(w, format, workflow)
+		}
+		return writer.Write(task)
+	})
+
+	if writer == nil {
+		if err != nil {
+			server.RespondError(logger, err, w, r)
+			return
+		}
+		writer = startExport(w, format, workflow)
+	}
+
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// startExport writes the headers of an export and returns the writer for its
+// body.
+func startExport(w http.ResponseWriter, format domain.ExportFormat, workflow domain.Workflow) taskio.Writer {
+	w.Header().Set("Content-Type", taskio.ContentType(format))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": "tasks." + string(format)}))
+	w.WriteHeader(http.StatusOK)
+
+	// NewWriter only fails for invalid formats, which were refused already.
+	writer, _ := taskio.NewWriter(format, w, workflow)
+	return writer
+}
+
+// ImportTasks godoc
+// @Summary Import tasks
+// @Description Create or update tasks from a CSV file with a header row, from JSON Lines, or from the to-dos (VTODO) of an iCalendar file. Rows with an ExternalID update the task imported with that ID before; other rows create tasks. Failing rows are reported and skipped; with dry_run=true every row is validated and nothing is saved.
+// @Tags tasks
+// @Accept text/csv,application/jsonl,text/calendar
+// @Produce json
+// @Param format query string false "csv, jsonl or ics; defaults to the Content-Type"
+// @Param dry_run query bool false "Validate without saving"
+// @Param map query []string false "Column mapping as Field:column, e.g. Title:Summary" collectionFormat(multi)
+// @Success 200 {object} domain.ImportResult "Per-row results"
+// @Failure 400 {object} server.Problem "Bad request"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/import [post]
+func (h *TransferHandler) ImportTasks(w http.ResponseWriter, r *http.Request) {
+	req := domain.ImportRequest{
+		Format:  domain.ImportFormat(r.URL.Query().Get("format")),
+		Mapping: map[string]string{},
+	}
+
+	if req.Format == "" {
+		req.Format = importFormat(r.Header.Get("Content-Type"))
+	}
+	if !req.Format.IsValid() {
+		server.RespondBadRequest("format must be csv, jsonl or ics; set ?format= or a text/csv, application/jsonl or text/calendar Content-Type", w, r)
+		return
+	}
+
+	if raw := r.URL.Query().Get("dry_run"); raw != "" {
+		dryRun, err := strconv.ParseBool(raw)
+		if err != nil {
+			server.RespondBadRequest(fmt.Sprintf("invalid dry_run %q", raw), w, r)
+			return
+		}
+		req.DryRun = dryRun
+	}
+
+	for _, mapping := range r.URL.Query()["map"] {
+		field, column, ok := strings.Cut(mapping, ":")
+		if !ok || field == "" || column == "" {
+			server.RespondBadRequest(fmt.Sprintf("invalid map %q: use Field:column", mapping), w, r)
+			return
+		}
+		req.Mapping[field] = column
+	}
+
+	result, err := h.importService.Import(r.Context(), &req, r.Body)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(result, w, r)
+}
+
+// importFormat infers the import format from a Content-Type.
+func importFormat(contentType string) domain.ImportFormat {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "text/csv":
+		return domain.ImportFormatCSV
+	case "application/jsonl", "application/x-ndjson", "application/x-jsonlines":
+		return domain.ImportFormatJSONL
+	case "text/calendar":
+		return domain.ImportFormatICS
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/alexgolang/ishare-task/internal/app/common/server"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type ViewHandler struct {
+	logger      *log.Logger
+	viewService *service.ViewService
+}
+
+func NewViewHandler(logger *log.Logger, viewService *service.ViewService) *ViewHandler {
+	return &ViewHandler{
+		logger:      logger,
+		viewService: viewService,
+	}
+}
+
+// CreateView godoc
+// @Summary Save a view
+// @Description Save a named task filter with an optional sort order and column selection, owned by the authenticated client and optionally shared with every client
+// @Tags views
+// @Accept json
+// @Produce json
+// @Param view body domain.CreateViewRequest true "View data"
+// @Success 201 {object} domain.View "View created; Location points to it"
+// @Failure 400 {object} server.Problem "Bad request"
+// @Failure 409 {object} server.Problem "You already have a view with this name"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /views [post]
+func (h *ViewHandler) CreateView(w http.ResponseWriter, r *http.Request) {
+	var req domain.CreateViewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		server.RespondBadRequest(err.Error(), w, r)
+		return
+	}
+
+	view, err := h.viewService.CreateView(r.Context(), clientID(r), &req)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondCreated("/views/"+view.ID.String(), view, w, r)
+}
+
+// ListViews godoc
+// @Summary List views
+// @Description Get your views and the views shared with every client, by name
+// @Tags views
+// @Accept json
+// @Produce json
+// @Success 200 {array} domain.View "List of views"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /views [get]
+func (h *ViewHandler) ListViews(w http.ResponseWriter, r *http.Request) {
+	views, err := h.viewService.ListViews(r.Context(), clientID(r))
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(views, w, r)
+}
+
+// GetView godoc
+// @Summary Get a view
+// @Description Get one of your views or a shared view
+// @Tags views
+// @Accept json
+// @Produce json
+// @Param id path string true "View ID (UUID)"
+// @Success 200 {object} domain.View "View details"
+// @Failure 404 {object} server.Problem "View not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /views/{id} [get]
+func (h *ViewHandler) GetView(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	view, err := h.viewService.GetView(r.Context(), id, clientID(r))
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(view, w, r)
+}
+
+// UpdateView godoc
+// @Summary Update a view
+// @Description Change one of your views. Cursors handed out before the change keep listing with the old definition.
+// @Tags views
+// @Accept json
+// @Produce json
+// @Param id path string true "View ID (UUID)"
+// @Param view body domain.UpdateViewRequest true "Fields to change"
+// @Success 200 {object} domain.View "View updated"
+// @Failure 400 {object} server.Problem "Bad request"
+// @Failure 403 {object} server.Problem "Not the owner"
+// @Failure 404 {object} server.Problem "View not found"
+// @Failure 409 {object} server.Problem "You already have a view with this name"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /views/{id} [patch]
+func (h *ViewHandler) UpdateView(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var req domain.UpdateViewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		server.RespondBadRequest(err.Error(), w, r)
+		return
+	}
+
+	view, err := h.viewService.UpdateView(r.Context(), id, clientID(r), &req)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(view, w, r)
+}
+
+// DeleteView godoc
+// @Summary Delete a view
+// @Description Delete one of your views
+// @Tags views
+// @Accept json
+// @Produce json
+// @Param id path string true "View ID (UUID)"
+// @Success 204 "View deleted"
+// @Failure 403 {object} server.Problem "Not the owner"
+// @Failure 404 {object} server.Problem "View not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /views/{id} [delete]
+func (h *ViewHandler) DeleteView(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	err := h.viewService.DeleteView(r.Context(), id, clientID(r))
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondNoContent(w, r)
+}
+
+// RunView godoc
+// @Summary List the tasks of a view
+// @Description Get a page of the tasks a view selects, in its sort order and with its columns. Pass NextCursor as cursor to get the next page; a listing keeps the definition it started with even if the view is edited meanwhile.
+// @Tags views
+// @Accept json
+// @Produce json
+// @Param id path string true "View ID (UUID)"
+// @Param limit query int false "Page size (default 50, max 200)"
+// @Param cursor query string false "NextCursor of the previous page"
+// @Success 200 {object} domain.ViewPage "Page of tasks"
+// @Failure 400 {object} server.Problem "Bad request"
+// @Failure 404 {object} server.Problem "View not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /views/{id}/tasks [get]
+func (h *ViewHandler) RunView(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	limit, err := queryInt(r, "limit")
+	if err != nil {
+		server.RespondBadRequest(err.Error(), w, r)
+		return
+	}
+
+	page, err := h.viewService.RunView(r.Context(), id, clientID(r), r.URL.Query().Get("cursor"), limit)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(page, w, r)
+}
@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/alexgolang/ishare-task/internal/app/common/server"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/service"
+	"github.com/go-chi/chi/v5"
+)
+
+type WorklogHandler struct {
+	logger         *log.Logger
+	worklogService *service.WorklogService
+}
+
+func NewWorklogHandler(logger *log.Logger, worklogService *service.WorklogService) *WorklogHandler {
+	return &WorklogHandler{
+		logger:         logger,
+		worklogService: worklogService,
+	}
+}
+
+// LogWork godoc
+// @Summary Log time on a task
+// @Description Record time the authenticated client spent on a task, from a start time and either an end time or a duration
+// @Tags worklogs
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID (UUID)"
+// @Param worklog body domain.CreateWorklogRequest true "Worklog data"
+// @Success 201 {object} domain.Worklog "Worklog created; Location points to it"
+// @Failure 400 {object} server.Problem "Bad request"
+// @Failure 404 {object} server.Problem "Task not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/worklogs [post]
+func (h *WorklogHandler) LogWork(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+
+	var req domain.CreateWorklogRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		server.RespondBadRequest(err.Error(), w, r)
+		return
+	}
+
+	worklog, err := h.worklogService.LogWork(r.Context(), taskID, clientID(r), &req)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondCreated(worklogLocation(worklog), worklog, w, r)
+}
+
+// ListWorklogs godoc
+// @Summary List time logged on a task
+// @Description Get the worklogs of a task, oldest first, including running timers
+// @Tags worklogs
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID (UUID)"
+// @Success 200 {array} domain.Worklog "List of worklogs"
+// @Failure 404 {object} server.Problem "Task not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/worklogs [get]
+func (h *WorklogHandler) ListWorklogs(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+
+	worklogs, err := h.worklogService.ListWorklogs(r.Context(), taskID)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(worklogs, w, r)
+}
+
+// GetWorklog godoc
+// @Summary Get a worklog
+// @Description Get a worklog of a task, or a running timer
+// @Tags worklogs
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID (UUID)"
+// @Param worklogId path string true "Worklog ID (UUID)"
+// @Success 200 {object} domain.Worklog "Worklog details"
+// @Failure 404 {object} server.Problem "Worklog not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/worklogs/{worklogId} [get]
+func (h *WorklogHandler) GetWorklog(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+	worklogID := chi.URLParam(r, "worklogId")
+
+	worklog, err := h.worklogService.GetWorklog(r.Context(), taskID, worklogID)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(worklog, w, r)
+}
+
+// DeleteWorklog godoc
+// @Summary Delete a worklog
+// @Description Delete one of your own worklogs, or discard your running timer
+// @Tags worklogs
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID (UUID)"
+// @Param worklogId path string true "Worklog ID (UUID)"
+// @Success 204 "Worklog deleted"
+// @Failure 403 {object} server.Problem "Not the author"
+// @Failure 404 {object} server.Problem "Worklog not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/worklogs/{worklogId} [delete]
+func (h *WorklogHandler) DeleteWorklog(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+	worklogID := chi.URLParam(r, "worklogId")
+
+	err := h.worklogService.DeleteWorklog(r.Context(), taskID, worklogID, clientID(r))
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondNoContent(w, r)
+}
+
+// StartTimer godoc
+// @Summary Start a timer
+// @Description Start measuring the time the authenticated client spends on a task. Your timer running on another task is stopped and logged first. Starting a timer that already runs on this task returns it with 200.
+// @Tags worklogs
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID (UUID)"
+// @Param timer body domain.TimerRequest false "Optional note"
+// @Success 201 {object} domain.Worklog "Timer started; Location points to its worklog"
+// @Success 200 {object} domain.Worklog "Timer was already running"
+// @Failure 400 {object} server.Problem "Bad request"
+// @Failure 404 {object} server.Problem "Task not found"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/timer/start [post]
+func (h *WorklogHandler) StartTimer(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+
+	var req domain.TimerRequest
+	if err := decodeOptionalJSON(r, &req); err != nil {
+		server.RespondBadRequest(err.Error(), w, r)
+		return
+	}
+
+	worklog, started, err := h.worklogService.StartTimer(r.Context(), taskID, clientID(r), &req)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	if !started {
+		server.RespondOK(worklog, w, r)
+		return
+	}
+
+	server.RespondCreated(worklogLocation(worklog), worklog, w, r)
+}
+
+// StopTimer godoc
+// @Summary Stop a timer
+// @Description Stop the authenticated client's timer on a task and log the time measured
+// @Tags worklogs
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID (UUID)"
+// @Param timer body domain.TimerRequest false "Optional note, replacing the one given at start"
+// @Success 200 {object} domain.Worklog "Finished worklog"
+// @Failure 400 {object} server.Problem "Bad request"
+// @Failure 404 {object} server.Problem "No timer running on the task"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /tasks/{id}/timer/stop [post]
+func (h *WorklogHandler) StopTimer(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "id")
+
+	var req domain.TimerRequest
+	if err := decodeOptionalJSON(r, &req); err != nil {
+		server.RespondBadRequest(err.Error(), w, r)
+		return
+	}
+
+	worklog, err := h.worklogService.StopTimer(r.Context(), taskID, clientID(r), &req)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(worklog, w, r)
+}
+
+// GetWorklogReport godoc
+// @Summary Time report
+// @Description Add up the time logged with finished worklogs, in total and per task, assignee (the client that logged the time) or UTC date
+// @Tags worklogs
+// @Accept json
+// @Produce json
+// @Param group_by query string false "task (default), assignee or date"
+// @Param task query string false "Only time logged on this task (UUID)"
+// @Param assignee query string false "Only time logged by this client, or \"me\""
+// @Param from query string false "Worklogs started at or after this RFC 3339 time or date"
+// @Param to query string false "Worklogs started before this RFC 3339 time or date"
+// @Success 200 {object} domain.WorklogReport "Time report"
+// @Failure 400 {object} server.Problem "Bad request"
+// @Failure 500 {object} server.Problem "Internal server error"
+// @Router /worklogs/report [get]
+func (h *WorklogHandler) GetWorklogReport(w http.ResponseWriter, r *http.Request) {
+	filter := domain.WorklogFilter{
+		TaskID:   r.URL.Query().Get("task"),
+		Assignee: r.URL.Query().Get("assignee"),
+		GroupBy:  domain.WorklogGroup(r.URL.Query().Get("group_by")),
+	}
+	if filter.Assignee == "me" {
+		filter.Assignee = clientID(r)
+	}
+
+	var err error
+	if filter.From, err = queryTime(r, "from"); err != nil {
+		server.RespondBadRequest(err.Error(), w, r)
+		return
+	}
+	if filter.To, err = queryTime(r, "to"); err != nil {
+		server.RespondBadRequest(err.Error(), w, r)
+		return
+	}
+
+	report, err := h.worklogService.Report(r.Context(), filter)
+
+	if err != nil {
+		server.RespondError(h.logger, err, w, r)
+		return
+	}
+
+	server.RespondOK(report, w, r)
+}
+
+func worklogLocation(worklog domain.Worklog) string {
+	return "/tasks/" + worklog.TaskID.String() + "/worklogs/" + worklog.ID.String()
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/alexgolang/ishare-task/internal/app/auth"
 	"github.com/alexgolang/ishare-task/internal/app/common/server"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
 )
 
 type contextKey string
@@ -14,12 +16,21 @@ type contextKey string
 const UserContextKey contextKey = "user"
 
 type AuthMiddleware struct {
-	jwtService *auth.JWTService
+	jwtService   *auth.JWTService
+	adminClients map[string]bool
 }
 
-func NewAuthMiddleware(jwtService *auth.JWTService) *AuthMiddleware {
+func NewAuthMiddleware(jwtService *auth.JWTService, adminClients []string) *AuthMiddleware {
+	admins := make(map[string]bool, len(adminClients))
+	for _, client := range adminClients {
+		if client = strings.TrimSpace(client); client != "" {
+			admins[client] = true
+		}
+	}
+
 	return &AuthMiddleware{
-		jwtService: jwtService,
+		jwtService:   jwtService,
+		adminClients: admins,
 	}
 }
 
@@ -27,27 +38,43 @@ func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
-			server.RespondBadRequest("Authorization header required", w, r)
+			server.RespondUnauthorized("Authorization header required", w, r)
 			return
 		}
 
 		if !strings.HasPrefix(authHeader, "Bearer ") {
-			server.RespondBadRequest("Invalid authorization header format. Expected 'Bearer <token>'", w, r)
+			server.RespondUnauthorized("Invalid authorization header format. Expected 'Bearer <token>'", w, r)
 			return
 		}
 
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 		if tokenString == "" {
-			server.RespondBadRequest("Token required", w, r)
+			server.RespondUnauthorized("Token required", w, r)
 			return
 		}
 
 		claims, err := m.jwtService.ValidateAccessToken(tokenString)
 		if err != nil {
-			server.RespondBadRequest("Invalid token: "+err.Error(), w, r)
+			server.RespondUnauthorized("Invalid token: "+err.Error(), w, r)
 			return
 		}
 		ctx := context.WithValue(r.Context(), UserContextKey, claims)
+		if sub, ok := claims["sub"].(string); ok {
+			ctx = domain.WithActor(ctx, sub)
+		}
+		ctx = domain.WithRequestID(ctx, chiMiddleware.GetReqID(ctx))
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// RequireAdmin lets through only the clients configured as administrators. It
+// must run after RequireAuth.
+func (m *AuthMiddleware) RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !m.adminClients[domain.ActorFromContext(r.Context())] {
+			server.RespondForbidden("Administrator access required", w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
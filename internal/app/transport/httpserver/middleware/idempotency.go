@@ -0,0 +1,179 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/alexgolang/ishare-task/internal/app/common/server"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+)
+
+// maxIdempotencyKeyLength bounds the Idempotency-Key header.
+const maxIdempotencyKeyLength = 255
+
+// IdempotencyStore keeps the responses to requests made with an
+// Idempotency-Key.
+type IdempotencyStore interface {
+	Reserve(ctx context.Context, clientID, key, requestHash string) (domain.IdempotencyRecord, bool, error)
+	Complete(ctx context.Context, clientID, key string, response domain.StoredResponse) error
+	Release(ctx context.Context, clientID, key string) error
+}
+
+type IdempotencyMiddleware struct {
+	store  IdempotencyStore
+	logger *log.Logger
+	// maxBodySize bounds the bodies read to hash a request.
+	maxBodySize int64
+}
+
+func NewIdempotencyMiddleware(store IdempotencyStore, logger *log.Logger, maxBodySize int64) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{
+		store:       store,
+		logger:      logger,
+		maxBodySize: maxBodySize,
+	}
+}
+
+// Handle makes POST, PATCH and DELETE requests that carry an Idempotency-Key
+// safe to retry: the first response for a client and key is stored and
+// replayed to later requests with the same key. Reusing a key for a different
+// request is rejected with 422, and a retry that arrives while the first
+// request is still running gets 409. Server errors are not stored, so such
+// requests can be retried. Bodies over maxBodySize are refused with 413. It
+// must run after RequireAuth.
+func (m *IdempotencyMiddleware) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("Idempotency-Key")
+		if key == "" || (r.Method != http.MethodPost && r.Method != http.MethodPatch && r.Method != http.MethodDelete) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			server.RespondBadRequest("Idempotency-Key must be at most 255 characters", w, r)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, m.maxBodySize))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			server.RespondRequestEntityTooLarge(fmt.Sprintf("Requests with an Idempotency-Key must have a body of at most %d bytes", m.maxBodySize), w, r)
+			return
+		}
+		if err != nil {
+			server.RespondBadRequest("Failed to read request body", w, r)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		clientID := domain.ActorFromContext(r.Context())
+		requestHash := hashRequest(r, body)
+
+		record, reserved, err := m.store.Reserve(r.Context(), clientID, key, requestHash)
+		if err != nil {
+			server.RespondError(m.logger, err, w, r)
+			return
+		}
+
+		if !reserved {
+			switch {
+			case record.RequestHash != requestHash:
+				server.RespondUnprocessableEntity("Idempotency-Key was already used for a different request", w, r)
+			case record.Response == nil:
+				server.RespondConflict("A request with this Idempotency-Key is still in progress", w, r)
+			default:
+				replay(w, *record.Response)
+			}
+			return
+		}
+
+		recorder := &responseRecorder{header: http.Header{}, status: http.StatusOK}
+		defer func() {
+			// Keep the key usable if the handler panicked before responding.
+			if p := recover(); p != nil {
+				m.release(r.Context(), clientID, key)
+				panic(p)
+			}
+		}()
+
+		next.ServeHTTP(recorder, r)
+
+		response := domain.StoredResponse{
+			StatusCode: recorder.status,
+			Header:     recorder.header,
+			Body:       recorder.body.Bytes(),
+		}
+
+		// The outcome is saved even if the client has gone away, since it
+		// is exactly the client that will retry.
+		ctx := context.WithoutCancel(r.Context())
+		if response.StatusCode >= http.StatusInternalServerError {
+			m.release(ctx, clientID, key)
+		} else if err := m.store.Complete(ctx, clientID, key, response); err != nil {
+			m.logger.Printf("failed to store response for idempotency key %q: %v", key, err)
+			m.release(ctx, clientID, key)
+		}
+
+		copyResponse(w, response)
+	})
+}
+
+func (m *IdempotencyMiddleware) release(ctx context.Context, clientID, key string) {
+	if err := m.store.Release(ctx, clientID, key); err != nil {
+		m.logger.Printf("failed to release idempotency key %q: %v", key, err)
+	}
+}
+
+// hashRequest identifies a request by its method, URL and body.
+func hashRequest(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	io.WriteString(hash, r.Method+" "+r.URL.RequestURI()+"\n")
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func replay(w http.ResponseWriter, response domain.StoredResponse) {
+	w.Header().Set("Idempotent-Replayed", "true")
+	copyResponse(w, response)
+}
+
+func copyResponse(w http.ResponseWriter, response domain.StoredResponse) {
+	for name, values := range response.Header {
+		w.Header()[name] = values
+	}
+	w.WriteHeader(response.StatusCode)
+	_, _ = w.Write(response.Body)
+}
+
+// responseRecorder buffers a response so that it can be stored before it is
+// sent.
+type responseRecorder struct {
+	header      http.Header
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.wroteHeader {
+		return
+	}
+	r.status = status
+	r.wroteHeader = true
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.wroteHeader = true
+	return r.body.Write(data)
+}
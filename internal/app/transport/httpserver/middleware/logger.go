@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/alexgolang/ishare-task/internal/app/common/server"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// redactingLogFormatter logs requests like chi's default formatter, but hides
+// secrets carried in the request path.
+type redactingLogFormatter struct {
+	*chiMiddleware.DefaultLogFormatter
+}
+
+// NewRequestLogger returns a request logger that writes chi's default log
+// lines, with the token of calendar feed URLs redacted.
+func NewRequestLogger() func(http.Handler) http.Handler {
+	return chiMiddleware.RequestLogger(&redactingLogFormatter{
+		DefaultLogFormatter: &chiMiddleware.DefaultLogFormatter{Logger: log.New(os.Stdout, "", log.LstdFlags)},
+	})
+}
+
+func (f *redactingLogFormatter) NewLogEntry(r *http.Request) chiMiddleware.LogEntry {
+	if path := server.RedactPath(r.URL.Path); path != r.URL.Path {
+		redacted := r.Clone(r.Context())
+		redacted.RequestURI = path
+		if r.URL.RawQuery != "" {
+			redacted.RequestURI += "?" + r.URL.RawQuery
+		}
+		r = redacted
+	}
+	return f.DefaultLogFormatter.NewLogEntry(r)
+}
@@ -14,18 +14,23 @@ import (
 )
 
 type Server struct {
-	taskHandler *handlers.TaskHandler
-	authHandler *handlers.AuthHandler
-	port        string
-	srv         *http.Server
+	taskHandler       *handlers.TaskHandler
+	commentHandler    *handlers.CommentHandler
+	attachmentHandler *handlers.AttachmentHandler
+	projectHandler    *handlers.ProjectHandler
+	reminderHandler   *handlers.ReminderHandler
+	bulkHandler       *handlers.BulkHandler
+	authHandler       *handlers.AuthHandler
+	port              string
+	srv               *http.Server
 }
 
-func NewServer(taskHandler *handlers.TaskHandler, authHandler *handlers.AuthHandler, jwtService *auth.JWTService, port string) *Server {
+func NewServer(taskHandler *handlers.TaskHandler, commentHandler *handlers.CommentHandler, attachmentHandler *handlers.AttachmentHandler, projectHandler *handlers.ProjectHandler, reminderHandler *handlers.ReminderHandler, worklogHandler *handlers.WorklogHandler, bulkHandler *handlers.BulkHandler, transferHandler *handlers.TransferHandler, calendarHandler *handlers.CalendarHandler, viewHandler *handlers.ViewHandler, templateHandler *handlers.TemplateHandler, authHandler *handlers.AuthHandler, jwtService *auth.JWTService, adminClients []string, idempotency *middleware.IdempotencyMiddleware, port string) *Server {
 	router := chi.NewRouter()
 
-	authMiddleware := middleware.NewAuthMiddleware(jwtService)
+	authMiddleware := middleware.NewAuthMiddleware(jwtService, adminClients)
 
-	router.Use(chiMiddleware.Logger)
+	router.Use(middleware.NewRequestLogger())
 	router.Use(chiMiddleware.Recoverer)
 	router.Use(chiMiddleware.RequestID)
 
@@ -37,17 +42,117 @@ func NewServer(taskHandler *handlers.TaskHandler, authHandler *handlers.AuthHand
 
 	router.Route("/tasks", func(r chi.Router) {
 		r.Use(authMiddleware.RequireAuth)
+		r.Use(idempotency.Handle)
 		r.Post("/", taskHandler.CreateTask)
 		r.Get("/", taskHandler.ListTasks)
+		r.Get("/trash", taskHandler.ListTrash)
+		r.Get("/burndown", taskHandler.GetBurndown)
+		r.Get("/board", taskHandler.GetBoard)
+		r.Get("/stats", taskHandler.GetTaskStats)
+		r.Post("/bulk", bulkHandler.BulkTasks)
+		r.Get("/export", transferHandler.ExportTasks)
+		r.Post("/import", transferHandler.ImportTasks)
 		r.Get("/{id}", taskHandler.GetTask)
 		r.Patch("/{id}", taskHandler.UpdateTask)
 		r.Delete("/{id}", taskHandler.DeleteTask)
+		r.Post("/{id}/restore", taskHandler.RestoreTask)
+		r.Post("/{id}/move", taskHandler.MoveTask)
+		r.With(authMiddleware.RequireAdmin).Delete("/{id}/permanent", taskHandler.PurgeTask)
+		r.Get("/{id}/assignments", taskHandler.GetAssignmentHistory)
+		r.Get("/{id}/series", taskHandler.GetTaskSeries)
+		r.Get("/{id}/history", taskHandler.GetTaskHistory)
+		r.Get("/{id}/snapshot", taskHandler.GetTaskSnapshot)
+		r.Get("/{id}/dependencies", taskHandler.GetDependencyGraph)
+		r.Post("/{id}/dependencies", taskHandler.AddDependency)
+		r.Delete("/{id}/dependencies/{blockerId}", taskHandler.RemoveDependency)
+		r.Get("/{id}/comments", commentHandler.ListComments)
+		r.Post("/{id}/comments", commentHandler.CreateComment)
+		r.Get("/{id}/comments/{commentId}", commentHandler.GetComment)
+		r.Patch("/{id}/comments/{commentId}", commentHandler.UpdateComment)
+		r.Delete("/{id}/comments/{commentId}", commentHandler.DeleteComment)
+		r.Get("/{id}/comments/{commentId}/history", commentHandler.GetCommentHistory)
+		r.Get("/{id}/attachments", attachmentHandler.ListAttachments)
+		r.Post("/{id}/attachments", attachmentHandler.UploadAttachment)
+		r.Get("/{id}/attachments/{attachmentId}", attachmentHandler.DownloadAttachment)
+		r.Delete("/{id}/attachments/{attachmentId}", attachmentHandler.DeleteAttachment)
+		r.Get("/{id}/reminders", reminderHandler.ListReminders)
+		r.Post("/{id}/reminders", reminderHandler.CreateReminder)
+		r.Get("/{id}/reminders/{reminderId}", reminderHandler.GetReminder)
+		r.Delete("/{id}/reminders/{reminderId}", reminderHandler.DeleteReminder)
+		r.Get("/{id}/worklogs", worklogHandler.ListWorklogs)
+		r.Post("/{id}/worklogs", worklogHandler.LogWork)
+		r.Get("/{id}/worklogs/{worklogId}", worklogHandler.GetWorklog)
+		r.Delete("/{id}/worklogs/{worklogId}", worklogHandler.DeleteWorklog)
+		r.Post("/{id}/timer/start", worklogHandler.StartTimer)
+		r.Post("/{id}/timer/stop", worklogHandler.StopTimer)
+	})
+
+	router.Route("/worklogs", func(r chi.Router) {
+		r.Use(authMiddleware.RequireAuth)
+		r.Get("/report", worklogHandler.GetWorklogReport)
+	})
+
+	router.Route("/projects", func(r chi.Router) {
+		r.Use(authMiddleware.RequireAuth)
+		r.Use(idempotency.Handle)
+		r.Post("/", projectHandler.CreateProject)
+		r.Get("/", projectHandler.ListProjects)
+		r.Get("/{id}", projectHandler.GetProject)
+		r.Patch("/{id}", projectHandler.UpdateProject)
+		r.Delete("/{id}", projectHandler.DeleteProject)
+		r.Get("/{id}/tasks", projectHandler.ListProjectTasks)
+		r.Post("/{id}/tasks", projectHandler.CreateProjectTask)
+	})
+
+	router.Route("/views", func(r chi.Router) {
+		r.Use(authMiddleware.RequireAuth)
+		r.Use(idempotency.Handle)
+		r.Post("/", viewHandler.CreateView)
+		r.Get("/", viewHandler.ListViews)
+		r.Get("/{id}", viewHandler.GetView)
+		r.Patch("/{id}", viewHandler.UpdateView)
+		r.Delete("/{id}", viewHandler.DeleteView)
+		r.Get("/{id}/tasks", viewHandler.RunView)
+	})
+
+	router.Route("/templates", func(r chi.Router) {
+		r.Use(authMiddleware.RequireAuth)
+		r.Use(idempotency.Handle)
+		r.Post("/", templateHandler.CreateTemplate)
+		r.Get("/", templateHandler.ListTemplates)
+		r.Get("/{id}", templateHandler.GetTemplate)
+		r.Patch("/{id}", templateHandler.UpdateTemplate)
+		r.Delete("/{id}", templateHandler.DeleteTemplate)
+		r.Post("/{id}/instantiate", templateHandler.InstantiateTemplate)
+	})
+
+	router.Route("/calendar", func(r chi.Router) {
+		// Calendar apps cannot send bearer tokens; the feed token in the URL
+		// authenticates the feed.
+		r.Get("/{token}.ics", calendarHandler.GetFeedCalendar)
+
+		r.Group(func(r chi.Router) {
+			r.Use(authMiddleware.RequireAuth)
+			r.Post("/feed", calendarHandler.CreateFeed)
+			r.Get("/feed", calendarHandler.GetFeed)
+			r.Delete("/feed", calendarHandler.DeleteFeed)
+		})
+	})
+
+	router.Route("/workflow", func(r chi.Router) {
+		r.Use(authMiddleware.RequireAuth)
+		r.Get("/", taskHandler.GetWorkflow)
 	})
 
 	return &Server{
-		taskHandler: taskHandler,
-		authHandler: authHandler,
-		port:        port,
+		taskHandler:       taskHandler,
+		commentHandler:    commentHandler,
+		attachmentHandler: attachmentHandler,
+		projectHandler:    projectHandler,
+		reminderHandler:   reminderHandler,
+		bulkHandler:       bulkHandler,
+		authHandler:       authHandler,
+		port:              port,
 		srv: &http.Server{
 			Addr:    fmt.Sprintf(":%s", port),
 			Handler: router,
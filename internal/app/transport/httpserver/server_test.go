@@ -0,0 +1,126 @@
+package httpserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexgolang/ishare-task/internal/app/auth"
+	"github.com/alexgolang/ishare-task/internal/app/db/sqlite"
+	"github.com/alexgolang/ishare-task/internal/app/domain"
+	"github.com/alexgolang/ishare-task/internal/app/service"
+	"github.com/alexgolang/ishare-task/internal/app/storage"
+	"github.com/alexgolang/ishare-task/internal/app/transport/httpserver/handlers"
+	"github.com/alexgolang/ishare-task/internal/app/transport/httpserver/middleware"
+)
+
+func TestServer_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	db, err := sqlite.NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.RunMigrations(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	blobs, err := storage.NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create blob store: %v", err)
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	jwtService, err := auth.NewJWTService(string(keyPEM), "http://localhost:8080", time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create JWT service: %v", err)
+	}
+	token, err := jwtService.CreateAccessToken("client-a")
+	if err != nil {
+		t.Fatalf("Failed to create access token: %v", err)
+	}
+
+	logger := log.New(os.Stderr, "INTEGRATION_TEST: ", log.LstdFlags)
+	taskService := service.NewTaskService(logger, db, blobs, domain.DefaultWorkflow())
+	var handlerLogs bytes.Buffer
+	worklogHandler := handlers.NewWorklogHandler(log.New(&handlerLogs, "", 0), service.NewWorklogService(logger, db))
+	idempotency := middleware.NewIdempotencyMiddleware(service.NewIdempotencyService(logger, db, time.Hour), logger, 1<<20)
+
+	// Only the handlers the tests call are set up.
+	srv := NewServer(nil, nil, nil, nil, nil, worklogHandler, nil, nil, nil, nil, nil, nil, jwtService, nil, idempotency, "0")
+
+	ctx := domain.WithActor(context.Background(), "client-a")
+	taskID, err := taskService.CreateTask(ctx, &domain.CreateTaskRequest{Title: "Track time"})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		srv.srv.Handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("the location of a new worklog can be fetched and deleted", func(t *testing.T) {
+		created := do(http.MethodPost, "/tasks/"+taskID.String()+"/worklogs", `{"StartedAt": "2025-03-01T09:00:00Z", "Duration": "30m"}`)
+		if created.Code != http.StatusCreated {
+			t.Fatalf("Expected status 201, got %d: %s", created.Code, created.Body.String())
+		}
+
+		location := created.Header().Get("Location")
+		fetched := do(http.MethodGet, location, "")
+		if fetched.Code != http.StatusOK {
+			t.Fatalf("Expected status 200 at %s, got %d: %s", location, fetched.Code, fetched.Body.String())
+		}
+
+		var worklog domain.Worklog
+		if err := json.Unmarshal(fetched.Body.Bytes(), &worklog); err != nil {
+			t.Fatalf("Failed to decode worklog: %v", err)
+		}
+		if worklog.TaskID != taskID || !strings.HasSuffix(location, worklog.ID.String()) {
+			t.Errorf("Expected the created worklog, got %+v", worklog)
+		}
+
+		deleted := do(http.MethodDelete, location, "")
+		if deleted.Code != http.StatusNoContent || deleted.Body.Len() != 0 {
+			t.Errorf("Expected status 204 without a body, got %d: %s", deleted.Code, deleted.Body.String())
+		}
+	})
+
+	t.Run("internal errors are logged instead of sent", func(t *testing.T) {
+		if err := db.Close(); err != nil {
+			t.Fatalf("Failed to close database: %v", err)
+		}
+
+		rec := do(http.MethodGet, "/tasks/"+taskID.String()+"/worklogs", "")
+		if rec.Code != http.StatusInternalServerError || strings.Contains(rec.Body.String(), "closed") {
+			t.Errorf("Expected a 500 without details, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if !strings.Contains(handlerLogs.String(), "database is closed") {
+			t.Errorf("Expected the error in the handler's log, got %q", handlerLogs.String())
+		}
+	})
+}